@@ -0,0 +1,20 @@
+// Package actor carries the identity of the user on whose behalf a request
+// is executed. Until authentication is introduced the identity is optional
+// and comes from a request header.
+package actor
+
+import "context"
+
+type ctxKey struct{}
+
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ctxKey{}).(string)
+	return id, ok && id != ""
+}
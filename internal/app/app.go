@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,10 +14,16 @@ import (
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/controller/http/v1"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/controller/http/v1/routers"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/metrics"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/migrate"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/scheduler"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/labstack/echo/v4"
 
 	_ "github.com/lib/pq"
 )
@@ -48,34 +55,11 @@ func Run(configPath string) {
 
 	log.Info("Connected to PostgreSQL")
 
-	// Initializing repositories
-	log.Info("Initializing repositories...")
-	teamRepo := postgres.NewTeamPostgres(db)
-	userRepo := postgres.NewUserPostgres(db)
-	prRepo := postgres.NewPRPostgres(db)
-	statsRepo := postgres.NewStatsPostgres(db)
-	log.Info("Repositories are ready")
-
-	// Initializing services
-	log.Info("Initializing services...")
-	teamSvc := service.NewTeamService(teamRepo)
-	userSvc := service.NewUserService(userRepo, teamRepo)
-	prSvc := service.NewPRService(prRepo, userRepo, teamRepo)
-	statsSvc := service.NewStatsService(statsRepo)
-	log.Info("Services are ready")
+	e := NewServer(db, cfg.PostgresURL())
 
-	// Initializing controllers
-	log.Info("Initializing controllers...")
-	teamCtrl := routers.NewTeamController(teamSvc, userSvc)
-	userCtrl := routers.NewUserController(userSvc, prSvc)
-	prCtrl := routers.NewPRController(prSvc)
-	statsCtrl := routers.NewStatsController(statsSvc)
-	log.Info("Controllers are ready")
-
-	// Initializing router
-	log.Info("Initializing router...")
-	e := v1.NewHTTPServer(teamCtrl, userCtrl, prCtrl, statsCtrl)
-	log.Info("Router is ready")
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	e.RunBackground(bgCtx)
 
 	// Running server
 	log.Info("Server is running", slog.Int("port", cfg.HTTPPort))
@@ -109,3 +93,307 @@ func Run(configPath string) {
 
 	log.Info("Server stopped successfully")
 }
+
+// Server is the HTTP router together with the background jobs that keep the
+// data behind it in shape.
+type Server struct {
+	*echo.Echo
+
+	background []func(ctx context.Context)
+}
+
+// RunBackground starts the background jobs. They stop when ctx is done.
+func (s *Server) RunBackground(ctx context.Context) {
+	for _, job := range s.background {
+		go job(ctx)
+	}
+}
+
+// NewServer wires repositories, services and controllers on top of db and
+// returns the HTTP router. dsn must point at the same database; it is used
+// for connections outside the pool, such as LISTEN. The configuration must
+// already be loaded.
+func NewServer(db *sql.DB, dsn string) *Server {
+	log := logger.L()
+	cfg := config.C()
+
+	// Initializing repositories
+	log.Info("Initializing repositories...")
+	teamRepo := postgres.NewTeamPostgres(db)
+	userRepo := postgres.NewUserPostgres(db)
+	prRepo := postgres.NewPRPostgres(db)
+	statsRepo := postgres.NewStatsPostgres(db)
+	erasureRepo := postgres.NewErasurePostgres(db)
+	idempotencyRepo := postgres.NewIdempotencyPostgres(db)
+	auditRepo := postgres.NewAuditPostgres(db)
+	prEventRepo := postgres.NewPREventPostgres(db)
+	prEventNotifier := postgres.NewPREventNotifier(dsn)
+	outboxRepo := postgres.NewOutboxPostgres(db)
+	webhookRepo := postgres.NewWebhookPostgres(db)
+	reviewerSyncRepo := postgres.NewReviewerSyncPostgres(db)
+	notificationRepo := postgres.NewNotificationPostgres(db)
+	reviewSLARepo := postgres.NewReviewSLAPostgres(db)
+	stalePRRepo := postgres.NewStalePRPostgres(db)
+	absenceRepo := postgres.NewAbsencePostgres(db)
+	metricsRepo := postgres.NewMetricsPostgres(db)
+	txManager := postgres.NewTxManager(db)
+	log.Info("Repositories are ready")
+
+	// Initializing metrics
+	if err := metrics.RegisterDB(db); err != nil {
+		log.Error("can not register database metrics", slog.Any("err", err))
+		os.Exit(1)
+	}
+	if err := metrics.RegisterBusiness(metricsRepo, cfg.PGTimeout); err != nil {
+		log.Error("can not register business metrics", slog.Any("err", err))
+		os.Exit(1)
+	}
+
+	// Initializing services
+	log.Info("Initializing services...")
+	auditSvc := service.NewAuditService(auditRepo)
+	outboxSvc := service.NewOutboxService(outboxRepo)
+	teamSvc := service.NewTeamService(txManager, teamRepo, userRepo, auditSvc)
+	userSvc := service.NewUserService(txManager, userRepo, teamRepo, auditSvc, outboxSvc)
+	reviewerSyncSvc := service.NewReviewerSyncService(
+		reviewerSyncRepo,
+		prRepo,
+		userRepo,
+		gitHostClients(cfg),
+		service.DeliveryPolicy{
+			BatchSize:   cfg.ReviewerSyncBatchSize,
+			MaxAttempts: cfg.ReviewerSyncMaxAttempts,
+			BackoffBase: cfg.ReviewerSyncBackoffBase,
+			BackoffMax:  cfg.ReviewerSyncBackoffMax,
+			// Pushing one job takes up to a few API calls.
+			Lease: 4 * cfg.ReviewerSyncRequestTimeout,
+		},
+	)
+	digestAt, err := domain.ParseClock(cfg.NotificationDigestTime)
+	if err != nil {
+		log.Error("invalid notification digest time", slog.Any("err", err))
+		os.Exit(1)
+	}
+	notificationSvc := service.NewNotificationService(
+		notificationRepo,
+		userRepo,
+		prRepo,
+		emailSender(cfg),
+		service.DeliveryPolicy{
+			BatchSize:   cfg.NotificationBatchSize,
+			MaxAttempts: cfg.NotificationMaxAttempts,
+			BackoffBase: cfg.NotificationBackoffBase,
+			BackoffMax:  cfg.NotificationBackoffMax,
+			// A batch is sent letter by letter.
+			Lease: time.Duration(cfg.NotificationBatchSize) * cfg.SMTPTimeout,
+		},
+		service.NotificationSchedule{
+			DigestAt:    digestAt,
+			RemindAfter: cfg.NotificationRemindAfter,
+		},
+	)
+	prSvc := service.NewPRService(
+		txManager,
+		prRepo,
+		userRepo,
+		teamRepo,
+		prEventRepo,
+		auditSvc,
+		outboxSvc,
+		reviewerSyncSvc,
+		notificationSvc,
+	)
+	reviewSLASvc := service.NewReviewSLAService(txManager, reviewSLARepo, teamRepo, prSvc, notificationSvc)
+	stalePRSvc := service.NewStalePRService(
+		stalePRRepo,
+		teamRepo,
+		userRepo,
+		notificationSvc,
+		domain.StalePolicy{MaxAge: cfg.StalePRMaxAge, MaxIdle: cfg.StalePRMaxIdle},
+	)
+	statsSvc := service.NewStatsService(statsRepo, teamRepo, absenceRepo)
+	absenceSvc := service.NewAbsenceService(absenceRepo, userRepo)
+	erasureSvc := service.NewErasureService(txManager, userRepo, prRepo, erasureRepo, prSvc, auditSvc, outboxSvc)
+	gitHostSvc := service.NewGitHostService(txManager, prRepo, userRepo, prSvc, reviewerSyncSvc)
+	eventStreamSvc := service.NewEventStreamService(prEventRepo, prEventNotifier, cfg.EventsSubscriberBuffer, cfg.EventsBacklogLimit, cfg.EventsPollInterval)
+	idempotencySvc := service.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL, cfg.IdempotencyLease)
+	webhookSvc := service.NewWebhookService(
+		webhookRepo,
+		&http.Client{Timeout: cfg.WebhookRequestTimeout},
+		service.DeliveryPolicy{
+			BatchSize:   cfg.WebhookBatchSize,
+			MaxAttempts: cfg.WebhookMaxAttempts,
+			BackoffBase: cfg.WebhookBackoffBase,
+			BackoffMax:  cfg.WebhookBackoffMax,
+			Lease:       2 * cfg.WebhookRequestTimeout,
+		},
+	)
+	log.Info("Services are ready")
+
+	// Initializing controllers
+	log.Info("Initializing controllers...")
+	teamCtrl := routers.NewTeamController(teamSvc, userSvc)
+	userCtrl := routers.NewUserController(userSvc, prSvc, erasureSvc)
+	notificationCtrl := routers.NewNotificationController(notificationSvc)
+	reviewSLACtrl := routers.NewReviewSLAController(reviewSLASvc)
+	stalePRCtrl := routers.NewStalePRController(stalePRSvc)
+	absenceCtrl := routers.NewAbsenceController(absenceSvc)
+	prCtrl := routers.NewPRController(prSvc)
+	statsCtrl := routers.NewStatsController(statsSvc)
+	auditCtrl := routers.NewAuditController(auditSvc)
+	webhookCtrl := routers.NewWebhookController(webhookSvc)
+	gitHostCtrl := routers.NewGitHostController(gitHostSvc, reviewerSyncSvc)
+	eventsCtrl := routers.NewEventsController(eventStreamSvc)
+	log.Info("Controllers are ready")
+
+	// Initializing router
+	log.Info("Initializing router...")
+	e := v1.NewHTTPServer(
+		teamCtrl,
+		userCtrl,
+		prCtrl,
+		statsCtrl,
+		auditCtrl,
+		webhookCtrl,
+		gitHostCtrl,
+		eventsCtrl,
+		notificationCtrl,
+		reviewSLACtrl,
+		stalePRCtrl,
+		absenceCtrl,
+		idempotencySvc,
+	)
+	log.Info("Router is ready")
+
+	// Initializing scheduler
+	log.Info("Initializing scheduler...")
+	sched := scheduler.New(postgres.NewAdvisoryLocker(db), postgres.NewJobRunPostgres(db), cfg.SchedulerJobTimeout)
+	pruneSchedule, err := scheduler.Parse(cfg.SchedulerPruneSchedule)
+	if err != nil {
+		log.Error("invalid job history prune schedule", slog.Any("err", err))
+		os.Exit(1)
+	}
+	statsCheckSchedule, err := scheduler.Parse(cfg.StatsCheckSchedule)
+	if err != nil {
+		log.Error("invalid statistics counters check schedule", slog.Any("err", err))
+		os.Exit(1)
+	}
+	statsSnapshotSchedule, err := scheduler.Parse(cfg.StatsSnapshotSchedule)
+	if err != nil {
+		log.Error("invalid statistics snapshot schedule", slog.Any("err", err))
+		os.Exit(1)
+	}
+	jobs := []scheduler.Job{
+		{
+			Name:     "idempotency.purge_expired",
+			Schedule: scheduler.Every(cfg.IdempotencyCleanupInterval),
+			Run: func(ctx context.Context) error {
+				_, err := idempotencySvc.PurgeExpired(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "notifications.remind",
+			Schedule: scheduler.Every(cfg.NotificationReminderInterval),
+			Run: func(ctx context.Context) error {
+				_, err := notificationSvc.Remind(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "review_sla.check",
+			Schedule: scheduler.Every(cfg.ReviewSLACheckInterval),
+			Run: func(ctx context.Context) error {
+				_, err := reviewSLASvc.Check(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "stale_pr.notify",
+			Schedule: scheduler.Every(cfg.StalePRNotifyInterval),
+			Run: func(ctx context.Context) error {
+				_, err := stalePRSvc.Notify(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "stats.check_counters",
+			Schedule: statsCheckSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := statsSvc.CheckCounters(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "stats.snapshot",
+			Schedule: statsSnapshotSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := statsSvc.Snapshot(ctx, cfg.StatsHistoryRetention)
+				return err
+			},
+		},
+		{
+			Name:     "scheduler.prune_history",
+			Schedule: pruneSchedule,
+			Run: func(ctx context.Context) error {
+				return sched.PruneHistory(ctx, time.Now().UTC().Add(-cfg.SchedulerHistoryRetention))
+			},
+		},
+	}
+	for _, job := range jobs {
+		if err := sched.Register(job); err != nil {
+			log.Error("can not register job", slog.Any("err", err))
+			os.Exit(1)
+		}
+	}
+	log.Info("Scheduler is ready")
+
+	return &Server{
+		Echo: e,
+		// Workers below share their queues safely and run on every
+		// replica; periodic jobs go through the scheduler.
+		background: []func(ctx context.Context){
+			sched.Run,
+			func(ctx context.Context) {
+				webhookSvc.RunDispatcher(ctx, cfg.WebhookDispatchInterval)
+			},
+			func(ctx context.Context) {
+				reviewerSyncSvc.RunWorker(ctx, cfg.ReviewerSyncInterval)
+			},
+			eventStreamSvc.Run,
+			func(ctx context.Context) {
+				notificationSvc.RunDispatcher(ctx, cfg.NotificationDispatchInterval)
+			},
+		},
+	}
+}
+
+// gitHostClients returns an API client for every provider with a token.
+func gitHostClients(cfg *config.Config) map[string]githost.Client {
+	httpClient := &http.Client{Timeout: cfg.ReviewerSyncRequestTimeout}
+
+	clients := map[string]githost.Client{}
+	if cfg.GitHubToken != "" {
+		clients[domain.ProviderGitHub] = githost.NewGitHubClient(cfg.GitHubAPIURL, cfg.GitHubToken, httpClient)
+	}
+	if cfg.GitLabToken != "" {
+		clients[domain.ProviderGitLab] = githost.NewGitLabClient(cfg.GitLabAPIURL, cfg.GitLabToken, httpClient)
+	}
+	return clients
+}
+
+// emailSender returns nil, which turns email notifications off, unless an
+// SMTP server is configured.
+func emailSender(cfg *config.Config) notify.Sender {
+	if cfg.SMTPHost == "" {
+		return nil
+	}
+	return notify.NewSMTPSender(notify.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+		Timeout:  cfg.SMTPTimeout,
+	})
+}
@@ -16,9 +16,18 @@ var (
 )
 
 type Config struct {
-	Env        string `yaml:"env" env-default:"local"`
-	HTTPServer `yaml:"http_server"`
-	Postgres   `yaml:"postgres"`
+	Env           string `yaml:"env" env-default:"local"`
+	HTTPServer    `yaml:"http_server"`
+	Postgres      `yaml:"postgres"`
+	Idempotency   `yaml:"idempotency"`
+	Webhooks      `yaml:"webhooks"`
+	GitHost       `yaml:"githost"`
+	Events        `yaml:"events"`
+	Notifications `yaml:"notifications"`
+	ReviewSLA     `yaml:"review_sla"`
+	StalePR       `yaml:"stale_pr"`
+	Scheduler     `yaml:"scheduler"`
+	Stats         `yaml:"stats"`
 }
 
 type HTTPServer struct {
@@ -37,6 +46,86 @@ type Postgres struct {
 	PGTimeout  time.Duration `yaml:"timeout" env-default:"4s"`
 }
 
+type Idempotency struct {
+	IdempotencyTTL             time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	IdempotencyCleanupInterval time.Duration `yaml:"cleanup_interval" env-default:"1h"`
+	IdempotencyLease           time.Duration `yaml:"lease" env-default:"30s"`
+	IdempotencyMaxBody         int64         `yaml:"max_body" env-default:"1048576"`
+}
+
+type Webhooks struct {
+	WebhookDispatchInterval time.Duration `yaml:"dispatch_interval" env-default:"5s"`
+	WebhookBatchSize        int           `yaml:"batch_size" env-default:"100"`
+	WebhookRequestTimeout   time.Duration `yaml:"request_timeout" env-default:"10s"`
+	WebhookMaxAttempts      int           `yaml:"max_attempts" env-default:"8"`
+	WebhookBackoffBase      time.Duration `yaml:"backoff_base" env-default:"30s"`
+	WebhookBackoffMax       time.Duration `yaml:"backoff_max" env-default:"1h"`
+}
+
+type GitHost struct {
+	GitHubWebhookSecret string `yaml:"github_webhook_secret" env:"GITHUB_WEBHOOK_SECRET"`
+	GitLabWebhookToken  string `yaml:"gitlab_webhook_token" env:"GITLAB_WEBHOOK_TOKEN"`
+
+	GitHubAPIURL string `yaml:"github_api_url" env:"GITHUB_API_URL" env-default:"https://api.github.com"`
+	GitHubToken  string `yaml:"github_token" env:"GITHUB_TOKEN"`
+	GitLabAPIURL string `yaml:"gitlab_api_url" env:"GITLAB_API_URL" env-default:"https://gitlab.com/api/v4"`
+	GitLabToken  string `yaml:"gitlab_token" env:"GITLAB_TOKEN"`
+
+	ReviewerSyncInterval       time.Duration `yaml:"reviewer_sync_interval" env-default:"10s"`
+	ReviewerSyncBatchSize      int           `yaml:"reviewer_sync_batch_size" env-default:"50"`
+	ReviewerSyncRequestTimeout time.Duration `yaml:"reviewer_sync_request_timeout" env-default:"10s"`
+	ReviewerSyncMaxAttempts    int           `yaml:"reviewer_sync_max_attempts" env-default:"6"`
+	ReviewerSyncBackoffBase    time.Duration `yaml:"reviewer_sync_backoff_base" env-default:"30s"`
+	ReviewerSyncBackoffMax     time.Duration `yaml:"reviewer_sync_backoff_max" env-default:"30m"`
+}
+
+type Events struct {
+	EventsHeartbeatInterval time.Duration `yaml:"heartbeat_interval" env-default:"15s"`
+	EventsBacklogLimit      int           `yaml:"backlog_limit" env-default:"500"`
+	EventsSubscriberBuffer  int           `yaml:"subscriber_buffer" env-default:"256"`
+	EventsPollInterval      time.Duration `yaml:"poll_interval" env-default:"1s"`
+}
+
+type Notifications struct {
+	SMTPHost     string        `yaml:"smtp_host" env:"SMTP_HOST"`
+	SMTPPort     int           `yaml:"smtp_port" env:"SMTP_PORT" env-default:"587"`
+	SMTPUsername string        `yaml:"smtp_username" env:"SMTP_USERNAME"`
+	SMTPPassword string        `yaml:"smtp_password" env:"SMTP_PASSWORD"`
+	SMTPFrom     string        `yaml:"smtp_from" env:"SMTP_FROM" env-default:"pr-reviewers@localhost"`
+	SMTPTimeout  time.Duration `yaml:"smtp_timeout" env-default:"10s"`
+
+	NotificationDispatchInterval time.Duration `yaml:"dispatch_interval" env-default:"30s"`
+	NotificationBatchSize        int           `yaml:"batch_size" env-default:"100"`
+	NotificationMaxAttempts      int           `yaml:"max_attempts" env-default:"5"`
+	NotificationBackoffBase      time.Duration `yaml:"backoff_base" env-default:"1m"`
+	NotificationBackoffMax       time.Duration `yaml:"backoff_max" env-default:"1h"`
+	NotificationDigestTime       string        `yaml:"digest_time" env-default:"09:00"`
+	NotificationRemindAfter      time.Duration `yaml:"remind_after" env-default:"24h"`
+	NotificationReminderInterval time.Duration `yaml:"reminder_interval" env-default:"1h"`
+}
+
+type ReviewSLA struct {
+	ReviewSLACheckInterval time.Duration `yaml:"check_interval" env-default:"5m"`
+}
+
+type StalePR struct {
+	StalePRMaxAge         time.Duration `yaml:"max_age" env:"STALE_PR_MAX_AGE" env-default:"168h"`
+	StalePRMaxIdle        time.Duration `yaml:"max_idle" env:"STALE_PR_MAX_IDLE" env-default:"72h"`
+	StalePRNotifyInterval time.Duration `yaml:"notify_interval" env-default:"1h"`
+}
+
+type Scheduler struct {
+	SchedulerJobTimeout       time.Duration `yaml:"job_timeout" env-default:"5m"`
+	SchedulerHistoryRetention time.Duration `yaml:"history_retention" env-default:"720h"`
+	SchedulerPruneSchedule    string        `yaml:"prune_schedule" env-default:"0 3 * * *"`
+}
+
+type Stats struct {
+	StatsCheckSchedule    string        `yaml:"check_schedule" env-default:"30 4 * * *"`
+	StatsSnapshotSchedule string        `yaml:"snapshot_schedule" env-default:"10 0 * * *"`
+	StatsHistoryRetention time.Duration `yaml:"history_retention" env-default:"8760h"`
+}
+
 func Load(configPath string) *Config {
 	once.Do(func() {
 		if configPath == "" {
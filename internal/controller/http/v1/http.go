@@ -4,6 +4,8 @@ import (
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/controller/http/v1/middleware"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/controller/http/v1/routers"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/metrics"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
 
 	"github.com/labstack/echo/v4"
 	mw "github.com/labstack/echo/v4/middleware"
@@ -14,6 +16,15 @@ func NewHTTPServer(
 	userCtrl *routers.UserController,
 	prCtrl *routers.PRController,
 	statsCtrl *routers.StatsController,
+	auditCtrl *routers.AuditController,
+	webhookCtrl *routers.WebhookController,
+	gitHostCtrl *routers.GitHostController,
+	eventsCtrl *routers.EventsController,
+	notificationCtrl *routers.NotificationController,
+	reviewSLACtrl *routers.ReviewSLAController,
+	stalePRCtrl *routers.StalePRController,
+	absenceCtrl *routers.AbsenceController,
+	idempotencySvc *service.IdempotencyService,
 ) *echo.Echo {
 	cfg := config.C()
 	e := echo.New()
@@ -23,12 +34,25 @@ func NewHTTPServer(
 	e.Server.IdleTimeout = cfg.HTTPIdleTimeout
 
 	e.Use(middleware.HTTPLogger())
+	e.Use(middleware.Metrics())
 	e.Use(mw.Recover())
+	e.Use(middleware.Actor())
+	e.Use(middleware.Idempotency(idempotencySvc))
 
 	routers.RegisterTeamRoutes(e, teamCtrl)
 	routers.RegisterUserRoutes(e, userCtrl)
 	routers.RegisterPRRoutes(e, prCtrl)
 	routers.RegisterStatsRoutes(e, statsCtrl)
+	routers.RegisterAuditRoutes(e, auditCtrl)
+	routers.RegisterWebhookRoutes(e, webhookCtrl)
+	routers.RegisterGitHostRoutes(e, gitHostCtrl)
+	routers.RegisterEventsRoutes(e, eventsCtrl)
+	routers.RegisterNotificationRoutes(e, notificationCtrl)
+	routers.RegisterReviewSLARoutes(e, reviewSLACtrl)
+	routers.RegisterStalePRRoutes(e, stalePRCtrl)
+	routers.RegisterAbsenceRoutes(e, absenceCtrl)
+
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
 
 	return e
 }
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
+	"github.com/labstack/echo/v4"
+)
+
+const HeaderActorID = "X-Actor-Id"
+
+func Actor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			if id := req.Header.Get(HeaderActorID); id != "" {
+				c.SetRequest(req.WithContext(actor.WithID(req.Context(), id)))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// replayedHeaders are the response headers stored together with the body.
+var replayedHeaders = []string{echo.HeaderContentType, "ETag"}
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to
+// retry: the first response is stored and returned again for every retry
+// with the same key and body.
+func Idempotency(svc *service.IdempotencyService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if req.Method != http.MethodPost || key == "" {
+				return next(c)
+			}
+
+			// The body is held in memory to hash it, so its size is capped.
+			body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, config.C().IdempotencyMaxBody))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					return writeIdempotencyError(c, http.StatusRequestEntityTooLarge, dto.ErrorCodeRequestTooLarge, "request body is too large")
+				}
+				return writeIdempotencyError(c, http.StatusBadRequest, dto.ErrorCodeNotFound, "invalid request body")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			path := c.Path()
+			hash := requestHash(req.URL.RawQuery, body)
+
+			ctx, cancel := context.WithTimeout(req.Context(), config.C().PGTimeout)
+			stored, err := svc.Begin(ctx, key, req.Method, path, hash)
+			cancel()
+
+			switch {
+			case errors.Is(err, domain.ErrInvalidIdempotencyKey):
+				return writeIdempotencyError(c, http.StatusBadRequest, dto.ErrorCodeInvalidIdempotencyKey, err.Error())
+			case errors.Is(err, domain.ErrIdempotencyKeyReused):
+				return writeIdempotencyError(c, http.StatusUnprocessableEntity, dto.ErrorCodeIdempotencyKeyReused, err.Error())
+			case errors.Is(err, domain.ErrIdempotencyInProgress):
+				return writeIdempotencyError(c, http.StatusConflict, dto.ErrorCodeIdempotencyInProgress, err.Error())
+			case err != nil:
+				return writeIdempotencyError(c, http.StatusInternalServerError, dto.ErrorCodeNotFound, err.Error())
+			}
+
+			if stored != nil {
+				return replay(c, stored)
+			}
+
+			res := c.Response()
+			recorder := &bodyRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+
+			stop := svc.KeepAlive(context.WithoutCancel(req.Context()), key, req.Method, path)
+			handlerErr := next(c)
+			stop()
+
+			// The client may already have given up on this request; the
+			// outcome still has to be recorded for its retry.
+			ctx, cancel = context.WithTimeout(context.WithoutCancel(req.Context()), config.C().PGTimeout)
+			defer cancel()
+
+			if handlerErr != nil || !res.Committed {
+				_ = svc.Release(ctx, key, req.Method, path)
+				return handlerErr
+			}
+
+			rec := &domain.IdempotencyRecord{
+				Key:        key,
+				Method:     req.Method,
+				Path:       path,
+				StatusCode: res.Status,
+				Headers:    make(map[string]string, len(replayedHeaders)),
+				Body:       recorder.buf.Bytes(),
+			}
+			for _, h := range replayedHeaders {
+				if v := res.Header().Get(h); v != "" {
+					rec.Headers[h] = v
+				}
+			}
+
+			if err := svc.Finish(ctx, rec); err != nil {
+				logger.L().Warn("idempotent response was not stored",
+					slog.String("path", path),
+					slog.Any("err", err),
+				)
+			}
+
+			return nil
+		}
+	}
+}
+
+func requestHash(query string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(query))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(c echo.Context, rec *domain.IdempotencyRecord) error {
+	header := c.Response().Header()
+	for name, value := range rec.Headers {
+		header.Set(name, value)
+	}
+	header.Set(HeaderIdempotentReplayed, "true")
+
+	contentType := rec.Headers[echo.HeaderContentType]
+	return c.Blob(rec.StatusCode, contentType, rec.Body)
+}
+
+func writeIdempotencyError(c echo.Context, status int, code dto.ErrorCode, message string) error {
+	return c.JSON(status, dto.ErrorResponse{
+		Error: dto.ErrorObject{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
+// bodyRecorder copies everything written to the client.
+type bodyRecorder struct {
+	http.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.buf.Write(b)
+	return r.ResponseWriter.Write(b)
+}
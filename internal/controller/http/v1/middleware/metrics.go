@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/metrics"
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels the requests no route matched, so that scanners do
+// not make up new series.
+const unmatchedRoute = "unmatched"
+
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+
+			// An error is written by the error handler after the middleware
+			// returns.
+			status := c.Response().Status
+			if err != nil {
+				status = http.StatusInternalServerError
+				var he *echo.HTTPError
+				if errors.As(err, &he) {
+					status = he.Code
+				}
+			}
+
+			route := c.Path()
+			if route == "" || errors.Is(err, echo.ErrNotFound) {
+				route = unmatchedRoute
+			}
+
+			metrics.HTTPRequestDuration.
+				WithLabelValues(c.Request().Method, route, strconv.Itoa(status)).
+				Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type AbsenceController struct {
+	absenceService *service.AbsenceService
+}
+
+func NewAbsenceController(absenceService *service.AbsenceService) *AbsenceController {
+	return &AbsenceController{absenceService: absenceService}
+}
+
+func RegisterAbsenceRoutes(e *echo.Echo, h *AbsenceController) {
+	e.POST("/users/addAbsence", h.Add)
+	e.POST("/users/removeAbsence", h.Remove)
+	e.GET("/users/absences", h.List)
+}
+
+func (h *AbsenceController) Add(c echo.Context) error {
+	var req dto.AddAbsenceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	absence, err := h.absenceService.Add(ctx, &domain.Absence{
+		UserID: req.UserID,
+		From:   req.From,
+		To:     req.To,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, dto.AddAbsenceResponse{
+		Absence: dto.ToAbsenceDTO(absence),
+	})
+}
+
+func (h *AbsenceController) Remove(c echo.Context) error {
+	var req dto.RemoveAbsenceRequest
+	if err := c.Bind(&req); err != nil || req.ID <= 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	if err := h.absenceService.Remove(ctx, req.ID); err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *AbsenceController) List(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "user_id is required",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	list, err := h.absenceService.List(ctx, userID)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.AbsencesResponse{
+		Absences: make([]dto.AbsenceDTO, 0, len(list)),
+	}
+	for i := range list {
+		resp.Absences = append(resp.Absences, dto.ToAbsenceDTO(&list[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
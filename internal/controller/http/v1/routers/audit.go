@@ -0,0 +1,84 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditController struct {
+	auditService *service.AuditService
+}
+
+func NewAuditController(auditService *service.AuditService) *AuditController {
+	return &AuditController{auditService: auditService}
+}
+
+func RegisterAuditRoutes(e *echo.Echo, h *AuditController) {
+	e.GET("/audit", h.List)
+}
+
+func (h *AuditController) List(c echo.Context) error {
+	filter := domain.AuditFilter{
+		EntityType: c.QueryParam("entity_type"),
+		EntityID:   c.QueryParam("entity_id"),
+		ActorID:    c.QueryParam("actor_id"),
+		Action:     domain.AuditAction(c.QueryParam("action")),
+	}
+
+	for name, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		raw := c.QueryParam(name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: dto.ErrorObject{
+					Code:    dto.ErrorCodeNotFound,
+					Message: name + " must be an RFC 3339 timestamp",
+				},
+			})
+		}
+		*dst = &t
+	}
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: dto.ErrorObject{
+					Code:    dto.ErrorCodeNotFound,
+					Message: "limit must be a positive integer",
+				},
+			})
+		}
+		filter.Limit = limit
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	page, err := h.auditService.ListEvents(ctx, filter, c.QueryParam("cursor"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.ListAuditResponse{
+		Events:     make([]dto.AuditEventDTO, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+
+	for i := range page.Items {
+		resp.Events = append(resp.Events, dto.ToAuditEventDTO(&page.Items[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -28,6 +28,10 @@ func writeDomainError(c echo.Context, err error) error {
 		status = http.StatusConflict
 		code = dto.ErrorCodePRMerged
 
+	case errors.Is(err, domain.ErrPRClosed):
+		status = http.StatusConflict
+		code = dto.ErrorCodePRClosed
+
 	case errors.Is(err, domain.ErrNotAssigned):
 		status = http.StatusConflict
 		code = dto.ErrorCodeNotAssigned
@@ -36,9 +40,72 @@ func writeDomainError(c echo.Context, err error) error {
 		status = http.StatusConflict
 		code = dto.ErrorCodeNoCandidate
 
+	case errors.Is(err, domain.ErrNotTeamMember):
+		status = http.StatusConflict
+		code = dto.ErrorCodeNotTeamMember
+
+	case errors.Is(err, domain.ErrTeamRequired):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeTeamRequired
+
+	case errors.Is(err, domain.ErrInvalidTeamSettings):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidTeamSettings
+
+	case errors.Is(err, domain.ErrForbidden):
+		status = http.StatusForbidden
+		code = dto.ErrorCodeForbidden
+
+	case errors.Is(err, domain.ErrInvalidCursor):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidCursor
+
+	case errors.Is(err, domain.ErrExternalAccountTaken):
+		status = http.StatusConflict
+		code = dto.ErrorCodeExternalAccountTaken
+
+	case errors.Is(err, domain.ErrUserErased):
+		status = http.StatusConflict
+		code = dto.ErrorCodeUserErased
+
+	case errors.Is(err, domain.ErrPRVersionMismatch):
+		status = http.StatusPreconditionFailed
+		code = dto.ErrorCodePRVersionMismatch
+
+	case errors.Is(err, domain.ErrInvalidVerdict):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidVerdict
+
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidWebhook
+
+	case errors.Is(err, domain.ErrInvalidPreferences):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidPreferences
+
+	case errors.Is(err, domain.ErrInvalidSLA):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidSLA
+
+	case errors.Is(err, domain.ErrInvalidStatsFilter):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidStatsFilter
+
+	case errors.Is(err, domain.ErrInvalidAbsence):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidAbsence
+
+	case errors.Is(err, domain.ErrInvalidExportFormat):
+		status = http.StatusBadRequest
+		code = dto.ErrorCodeInvalidExportFormat
+
 	case errors.Is(err, domain.ErrUserNotFound),
 		errors.Is(err, domain.ErrTeamNotFound),
-		errors.Is(err, domain.ErrPRNotFound):
+		errors.Is(err, domain.ErrPRNotFound),
+		errors.Is(err, domain.ErrWebhookNotFound),
+		errors.Is(err, domain.ErrDeliveryNotFound),
+		errors.Is(err, domain.ErrAbsenceNotFound):
 		status = http.StatusNotFound
 		code = dto.ErrorCodeNotFound
 
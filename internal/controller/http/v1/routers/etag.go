@@ -0,0 +1,55 @@
+package routers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/labstack/echo/v4"
+)
+
+// setETag exposes the pull request version as a strong entity tag.
+func setETag(c echo.Context, pr *domain.PullRequest) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.FormatInt(pr.Version, 10)))
+}
+
+// ifMatchVersion reads the If-Match header. It returns 0 when the header is
+// absent or "*", which means the write is unconditional. A value that can
+// not be a pull request version never matches.
+func ifMatchVersion(c echo.Context) (int64, bool) {
+	header := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	tag, err := strconv.Unquote(tag)
+	if err != nil {
+		tag = header
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// writePreconditionFailed answers 412 with the current state of the pull
+// request so the client can refresh its view without another request.
+func writePreconditionFailed(c echo.Context, pr *domain.PullRequest) error {
+	if pr == nil {
+		return writeDomainError(c, domain.ErrPRVersionMismatch)
+	}
+
+	setETag(c, pr)
+	return c.JSON(http.StatusPreconditionFailed, dto.PreconditionFailedResponse{
+		Error: dto.ErrorObject{
+			Code:    dto.ErrorCodePRVersionMismatch,
+			Message: domain.ErrPRVersionMismatch.Error(),
+		},
+		PR: dto.ToPullRequestDTO(pr),
+	})
+}
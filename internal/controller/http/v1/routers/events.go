@@ -0,0 +1,142 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// lastEventIDHeader is sent by EventSource clients when they reconnect.
+const lastEventIDHeader = "Last-Event-ID"
+
+type EventsController struct {
+	streamService *service.EventStreamService
+}
+
+func NewEventsController(streamService *service.EventStreamService) *EventsController {
+	return &EventsController{streamService: streamService}
+}
+
+func RegisterEventsRoutes(e *echo.Echo, h *EventsController) {
+	e.GET("/events/stream", h.Stream)
+}
+
+// Stream sends server-sent events until the client goes away. Clients that
+// reconnect with Last-Event-ID first get the events they missed.
+func (h *EventsController) Stream(c echo.Context) error {
+	filter := domain.PRStreamFilter{
+		UserID:   c.QueryParam("user_id"),
+		TeamName: c.QueryParam("team_name"),
+	}
+
+	// The query parameter serves clients that can not set headers, such as
+	// the browser EventSource on the first connect.
+	rawLastID := c.Request().Header.Get(lastEventIDHeader)
+	if rawLastID == "" {
+		rawLastID = c.QueryParam("last_event_id")
+	}
+
+	// Event ids on the stream are opaque stream positions rather than
+	// timeline ids, so resuming never skips an event that committed late.
+	resuming := rawLastID != ""
+	var sent domain.PRStreamPosition
+	if resuming {
+		pos, err := service.DecodeStreamPosition(rawLastID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: dto.ErrorObject{
+					Code:    dto.ErrorCodeInvalidCursor,
+					Message: "invalid Last-Event-ID",
+				},
+			})
+		}
+		sent = pos
+	}
+
+	// Subscribing before loading the backlog makes sure nothing committed
+	// in between is missed.
+	sub := h.streamService.Subscribe(filter)
+	defer h.streamService.Unsubscribe(sub)
+
+	res := c.Response()
+	rc := http.NewResponseController(res)
+	// The server write timeout is meant for regular requests.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	ctx := c.Request().Context()
+
+	for resuming {
+		pageCtx, cancel := context.WithTimeout(ctx, config.C().PGTimeout)
+		events, more, err := h.streamService.Backlog(pageCtx, filter, sent)
+		cancel()
+		if err != nil {
+			return nil
+		}
+
+		for i := range events {
+			if err := writeStreamEvent(res, &events[i]); err != nil {
+				return nil
+			}
+			sent = events[i].Position
+		}
+		res.Flush()
+
+		if !more {
+			break
+		}
+	}
+
+	heartbeat := time.NewTicker(config.C().EventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+
+		case e, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			// Already sent as part of the backlog.
+			if resuming && !sent.Before(e.Position) {
+				continue
+			}
+			if err := writeStreamEvent(res, &e); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+func writeStreamEvent(res *echo.Response, e *domain.PRStreamEvent) error {
+	data, err := json.Marshal(dto.ToStreamEventDTO(e))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", service.EncodeStreamPosition(e.Position), e.Type, data)
+	return err
+}
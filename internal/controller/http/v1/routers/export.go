@@ -0,0 +1,85 @@
+package routers
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/export"
+	"github.com/labstack/echo/v4"
+)
+
+// exportFormat picks the response format from the format query parameter
+// or, without it, from the first media type of the Accept header that is a
+// spreadsheet. JSON is the default.
+func exportFormat(c echo.Context) (export.Format, error) {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	if raw := c.QueryParam("format"); raw != "" {
+		switch f := export.Format(strings.ToLower(raw)); f {
+		case export.FormatJSON, export.FormatCSV, export.FormatXLSX:
+			return f, nil
+		default:
+			return "", fmt.Errorf("%w: format must be json, csv or xlsx", domain.ErrInvalidExportFormat)
+		}
+	}
+
+	for _, accepted := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType, _, err := mime.ParseMediaType(accepted)
+		if err != nil {
+			continue
+		}
+		for _, f := range []export.Format{export.FormatCSV, export.FormatXLSX} {
+			if t, _, _ := mime.ParseMediaType(f.ContentType()); t == mediaType {
+				return f, nil
+			}
+		}
+	}
+
+	return export.FormatJSON, nil
+}
+
+// exportLang picks the language of spreadsheet headers from the lang query
+// parameter or the Accept-Language header.
+func exportLang(c echo.Context) string {
+	if raw := c.QueryParam("lang"); raw != "" {
+		return export.ParseLanguage(raw)
+	}
+	return export.ParseLanguage(c.Request().Header.Get("Accept-Language"))
+}
+
+// writeExport answers with the tables as a file named name. A CSV file
+// holds a single table, the one the table query parameter names or the
+// first.
+func writeExport(c echo.Context, name string, format export.Format, tables []export.Table) error {
+	lang := exportLang(c)
+
+	if format == export.FormatCSV {
+		i := 0
+		if raw := c.QueryParam("table"); raw != "" {
+			i = slices.IndexFunc(tables, func(t export.Table) bool { return t.Name == raw })
+			if i < 0 {
+				return writeDomainError(c, fmt.Errorf("%w: unknown table %q", domain.ErrInvalidExportFormat, raw))
+			}
+		}
+		if len(tables) > 1 {
+			name += "-" + tables[i].Name
+		}
+		tables = tables[i : i+1]
+	}
+
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, format.ContentType())
+	resp.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{
+		"filename": name + "." + string(format),
+	}))
+	resp.WriteHeader(http.StatusOK)
+
+	if format == export.FormatCSV {
+		return export.WriteCSV(resp, tables[0], lang)
+	}
+	return export.WriteXLSX(resp, tables, lang)
+}
@@ -0,0 +1,136 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// maxGitHostPayload caps webhook bodies; GitHub sends at most 25 MB but pull
+// request payloads are far smaller.
+const maxGitHostPayload = 5 << 20
+
+type GitHostController struct {
+	gitHostService      *service.GitHostService
+	reviewerSyncService *service.ReviewerSyncService
+}
+
+func NewGitHostController(
+	gitHostService *service.GitHostService,
+	reviewerSyncService *service.ReviewerSyncService,
+) *GitHostController {
+	return &GitHostController{
+		gitHostService:      gitHostService,
+		reviewerSyncService: reviewerSyncService,
+	}
+}
+
+func RegisterGitHostRoutes(e *echo.Echo, h *GitHostController) {
+	e.POST("/integrations/github/webhook", h.GitHub)
+	e.POST("/integrations/gitlab/webhook", h.GitLab)
+	e.GET("/integrations/reviewerSync", h.ReviewerSync)
+}
+
+func (h *GitHostController) GitHub(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxGitHostPayload))
+	if err != nil {
+		return writeGitHostError(c, http.StatusBadRequest, dto.ErrorCodeInvalidPayload, "can not read request body")
+	}
+
+	sig := c.Request().Header.Get(githost.GitHubSignatureHeader)
+	if !githost.VerifyGitHub(config.C().GitHubWebhookSecret, body, sig) {
+		return writeGitHostError(c, http.StatusUnauthorized, dto.ErrorCodeInvalidSignature, "invalid webhook signature")
+	}
+
+	ev, err := githost.ParseGitHub(c.Request().Header.Get(githost.GitHubEventHeader), body)
+	return h.handle(c, ev, err)
+}
+
+func (h *GitHostController) GitLab(c echo.Context) error {
+	token := c.Request().Header.Get(githost.GitLabTokenHeader)
+	if !githost.VerifyGitLab(config.C().GitLabWebhookToken, token) {
+		return writeGitHostError(c, http.StatusUnauthorized, dto.ErrorCodeInvalidSignature, "invalid webhook token")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxGitHostPayload))
+	if err != nil {
+		return writeGitHostError(c, http.StatusBadRequest, dto.ErrorCodeInvalidPayload, "can not read request body")
+	}
+
+	ev, err := githost.ParseGitLab(c.Request().Header.Get(githost.GitLabEventHeader), body)
+	return h.handle(c, ev, err)
+}
+
+func (h *GitHostController) handle(c echo.Context, ev *domain.HostPREvent, parseErr error) error {
+	if errors.Is(parseErr, githost.ErrMalformedPayload) {
+		return writeGitHostError(c, http.StatusBadRequest, dto.ErrorCodeInvalidPayload, parseErr.Error())
+	}
+	if ev == nil {
+		return c.JSON(http.StatusOK, dto.GitHostWebhookResponse{Result: dto.GitHostResultIgnored})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	pr, err := h.gitHostService.HandlePullRequestEvent(ctx, *ev)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+	if pr == nil {
+		return c.JSON(http.StatusOK, dto.GitHostWebhookResponse{Result: dto.GitHostResultIgnored})
+	}
+
+	prDTO := dto.ToPullRequestDTO(pr)
+
+	return c.JSON(http.StatusOK, dto.GitHostWebhookResponse{
+		Result: dto.GitHostResultApplied,
+		PR:     &prDTO,
+	})
+}
+
+func (h *GitHostController) ReviewerSync(c echo.Context) error {
+	prID := c.QueryParam("pull_request_id")
+	if prID == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "pull_request_id is required",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	jobs, err := h.reviewerSyncService.ListJobs(ctx, prID)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.ReviewerSyncResponse{
+		PullRequestID: prID,
+		Jobs:          make([]dto.ReviewerSyncJobDTO, 0, len(jobs)),
+	}
+	for i := range jobs {
+		resp.Jobs = append(resp.Jobs, dto.ToReviewerSyncJobDTO(&jobs[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func writeGitHostError(c echo.Context, status int, code dto.ErrorCode, message string) error {
+	return c.JSON(status, dto.ErrorResponse{
+		Error: dto.ErrorObject{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationController struct {
+	notificationService *service.NotificationService
+}
+
+func NewNotificationController(notificationService *service.NotificationService) *NotificationController {
+	return &NotificationController{notificationService: notificationService}
+}
+
+func RegisterNotificationRoutes(e *echo.Echo, h *NotificationController) {
+	e.GET("/users/notificationPreferences", h.GetPreferences)
+	e.POST("/users/notificationPreferences", h.UpdatePreferences)
+}
+
+func (h *NotificationController) GetPreferences(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "user_id is required",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	prefs, err := h.notificationService.GetPreferences(ctx, userID)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ToNotificationPreferencesDTO(prefs))
+}
+
+func (h *NotificationController) UpdatePreferences(c echo.Context) error {
+	var req dto.UpdateNotificationPreferencesRequest
+	if err := c.Bind(&req); err != nil || req.UserID == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	prefs, err := dto.UpdateNotificationPreferencesRequestToDomain(req)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	prefs, err = h.notificationService.UpdatePreferences(ctx, prefs)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ToNotificationPreferencesDTO(prefs))
+}
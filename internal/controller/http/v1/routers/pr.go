@@ -2,9 +2,11 @@ package routers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
 	"github.com/labstack/echo/v4"
@@ -24,6 +26,8 @@ func RegisterPRRoutes(e *echo.Echo, h *PRController) {
 	e.POST("/pullRequest/create", h.Create)
 	e.POST("/pullRequest/merge", h.Merge)
 	e.POST("/pullRequest/reassign", h.Reassign)
+	e.POST("/pullRequest/review", h.Review)
+	e.GET("/pullRequest/timeline", h.Timeline)
 }
 
 func (h *PRController) Create(c echo.Context) error {
@@ -40,7 +44,7 @@ func (h *PRController) Create(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
 	defer cancel()
 
-	pr, err := h.prService.CreatePR(ctx, req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.prService.CreatePR(ctx, req.PullRequestID, req.PullRequestName, req.AuthorID, req.TeamName)
 	if err != nil {
 		return writeDomainError(c, err)
 	}
@@ -49,6 +53,8 @@ func (h *PRController) Create(c echo.Context) error {
 		PR: dto.ToPullRequestDTO(pr),
 	}
 
+	setETag(c, pr)
+
 	return c.JSON(http.StatusCreated, resp)
 }
 
@@ -66,7 +72,15 @@ func (h *PRController) Merge(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
 	defer cancel()
 
-	pr, err := h.prService.MergePR(ctx, req.PullRequestID)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return writeDomainError(c, domain.ErrPRVersionMismatch)
+	}
+
+	pr, err := h.prService.MergePR(ctx, req.PullRequestID, version)
+	if errors.Is(err, domain.ErrPRVersionMismatch) {
+		return writePreconditionFailed(c, pr)
+	}
 	if err != nil {
 		return writeDomainError(c, err)
 	}
@@ -75,6 +89,8 @@ func (h *PRController) Merge(c echo.Context) error {
 		PR: dto.ToPullRequestDTO(pr),
 	}
 
+	setETag(c, pr)
+
 	return c.JSON(http.StatusOK, resp)
 }
 
@@ -92,7 +108,15 @@ func (h *PRController) Reassign(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
 	defer cancel()
 
-	pr, newID, err := h.prService.ReassignReviewer(ctx, req.PullRequestID, req.OldReviewerID)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return writeDomainError(c, domain.ErrPRVersionMismatch)
+	}
+
+	pr, newID, err := h.prService.ReassignReviewer(ctx, req.PullRequestID, req.OldReviewerID, version)
+	if errors.Is(err, domain.ErrPRVersionMismatch) {
+		return writePreconditionFailed(c, pr)
+	}
 	if err != nil {
 		return writeDomainError(c, err)
 	}
@@ -102,5 +126,79 @@ func (h *PRController) Reassign(c echo.Context) error {
 		ReplacedBy: newID,
 	}
 
+	setETag(c, pr)
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *PRController) Review(c echo.Context) error {
+	var req dto.SubmitReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return writeDomainError(c, domain.ErrPRVersionMismatch)
+	}
+
+	pr, err := h.prService.SubmitReview(
+		ctx,
+		req.PullRequestID,
+		req.ReviewerID,
+		domain.ReviewVerdict(req.Verdict),
+		version,
+	)
+	if errors.Is(err, domain.ErrPRVersionMismatch) {
+		return writePreconditionFailed(c, pr)
+	}
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.SubmitReviewResponse{
+		PR: dto.ToPullRequestDTO(pr),
+	}
+
+	setETag(c, pr)
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *PRController) Timeline(c echo.Context) error {
+	prID := c.QueryParam("pull_request_id")
+	if prID == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "pull_request_id is required",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	events, err := h.prService.GetTimeline(ctx, prID)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.TimelineResponse{
+		PullRequestID: prID,
+		Events:        make([]dto.PREventDTO, 0, len(events)),
+	}
+	for i := range events {
+		resp.Events = append(resp.Events, dto.ToPREventDTO(&events[i]))
+	}
+
 	return c.JSON(http.StatusOK, resp)
 }
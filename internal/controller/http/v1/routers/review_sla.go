@@ -0,0 +1,42 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewSLAController struct {
+	reviewSLAService *service.ReviewSLAService
+}
+
+func NewReviewSLAController(reviewSLAService *service.ReviewSLAService) *ReviewSLAController {
+	return &ReviewSLAController{reviewSLAService: reviewSLAService}
+}
+
+func RegisterReviewSLARoutes(e *echo.Echo, h *ReviewSLAController) {
+	e.GET("/pullRequest/overdueReviews", h.Overdue)
+}
+
+func (h *ReviewSLAController) Overdue(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	reviews, err := h.reviewSLAService.ListOverdue(ctx, c.QueryParam("team_name"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.OverdueReviewsResponse{
+		Reviews: make([]dto.OverdueReviewDTO, 0, len(reviews)),
+	}
+	for i := range reviews {
+		resp.Reviews = append(resp.Reviews, dto.ToOverdueReviewDTO(&reviews[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,46 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type StalePRController struct {
+	stalePRService *service.StalePRService
+}
+
+func NewStalePRController(stalePRService *service.StalePRService) *StalePRController {
+	return &StalePRController{stalePRService: stalePRService}
+}
+
+func RegisterStalePRRoutes(e *echo.Echo, h *StalePRController) {
+	e.GET("/pullRequest/stale", h.Stale)
+}
+
+func (h *StalePRController) Stale(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	prs, err := h.stalePRService.List(ctx, domain.StalePRFilter{
+		TeamName:   c.QueryParam("team_name"),
+		ReviewerID: c.QueryParam("reviewer_id"),
+	})
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.StalePRsResponse{
+		PullRequests: make([]dto.StalePRDTO, 0, len(prs)),
+	}
+	for i := range prs {
+		resp.PullRequests = append(resp.PullRequests, dto.ToStalePRDTO(&prs[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -2,10 +2,15 @@ package routers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/export"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
 	"github.com/labstack/echo/v4"
 )
@@ -20,40 +25,161 @@ func NewStatsController(statsService *service.StatsService) *StatsController {
 
 func RegisterStatsRoutes(e *echo.Echo, h *StatsController) {
 	e.GET("/stats", h.Get)
+	e.GET("/stats/cycleTime", h.CycleTime)
+	e.GET("/stats/fairness", h.Fairness)
+	e.GET("/stats/history", h.History)
 }
 
 func (h *StatsController) Get(c echo.Context) error {
+	filter := domain.StatsFilter{
+		TeamName: c.QueryParam("team_name"),
+		Status:   domain.PRStatus(c.QueryParam("status")),
+	}
+
+	if err := parseWindow(c, &filter.From, &filter.To); err != nil {
+		return writeDomainError(c, err)
+	}
+
+	if raw := c.QueryParam("pr_limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return writeDomainError(c, fmt.Errorf("%w: pr_limit must be a positive integer", domain.ErrInvalidStatsFilter))
+		}
+		filter.PRLimit = limit
+	}
+
+	format, err := exportFormat(c)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
 	defer cancel()
 
-	byUser, byPR, err := h.statsService.GetStats(ctx)
+	stats, err := h.statsService.GetStats(ctx, filter)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error: dto.ErrorObject{
-				Code:    dto.ErrorCodeNotFound,
-				Message: err.Error(),
-			},
-		})
+		return writeDomainError(c, err)
+	}
+
+	if format != export.FormatJSON {
+		return writeExport(c, "stats", format, dto.StatsTables(stats))
 	}
 
 	resp := dto.StatsResponse{
-		ByUser: make([]dto.UserStatsDTO, 0, len(byUser)),
-		ByPR:   make([]dto.PRStatsDTO, 0, len(byPR)),
+		ByUser:        make([]dto.UserStatsDTO, 0, len(stats.ByUser)),
+		ByPR:          make([]dto.PRStatsDTO, 0, len(stats.ByPR)),
+		ByPRTruncated: stats.ByPRTruncated,
+		ByTeam:        make([]dto.TeamStatsDTO, 0, len(stats.ByTeam)),
 	}
 
-	for _, s := range byUser {
+	for _, s := range stats.ByUser {
 		resp.ByUser = append(resp.ByUser, dto.UserStatsDTO{
 			UserID:      s.UserID,
 			Assignments: s.Assignments,
 		})
 	}
 
-	for _, s := range byPR {
+	for _, s := range stats.ByPR {
 		resp.ByPR = append(resp.ByPR, dto.PRStatsDTO{
 			PullRequestID: s.PRID,
 			Reviewers:     s.Reviewers,
 		})
 	}
 
+	for _, s := range stats.ByTeam {
+		resp.ByTeam = append(resp.ByTeam, dto.TeamStatsDTO{
+			TeamName:     s.TeamName,
+			PullRequests: s.PullRequests,
+			Open:         s.Open,
+			Merged:       s.Merged,
+			Closed:       s.Closed,
+			Assignments:  s.Assignments,
+		})
+	}
+
 	return c.JSON(http.StatusOK, resp)
 }
+
+func (h *StatsController) CycleTime(c echo.Context) error {
+	filter := domain.CycleTimeFilter{
+		TeamName: c.QueryParam("team_name"),
+	}
+	if err := parseWindow(c, &filter.From, &filter.To); err != nil {
+		return writeDomainError(c, err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	ct, err := h.statsService.GetCycleTime(ctx, filter)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ToCycleTimeResponse(ct))
+}
+
+func (h *StatsController) Fairness(c echo.Context) error {
+	filter := domain.FairnessFilter{
+		TeamName: c.QueryParam("team_name"),
+	}
+	if err := parseWindow(c, &filter.From, &filter.To); err != nil {
+		return writeDomainError(c, err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	report, err := h.statsService.GetFairness(ctx, filter)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ToFairnessResponse(report))
+}
+
+func (h *StatsController) History(c echo.Context) error {
+	filter := domain.StatsHistoryFilter{
+		TeamName: c.QueryParam("team_name"),
+		UserID:   c.QueryParam("user_id"),
+	}
+	var from, to *time.Time
+	if err := parseWindow(c, &from, &to); err != nil {
+		return writeDomainError(c, err)
+	}
+	if from != nil {
+		filter.From = *from
+	}
+	if to != nil {
+		filter.To = *to
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	history, err := h.statsService.GetHistory(ctx, filter)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ToStatsHistoryResponse(history))
+}
+
+// parseWindow reads the from and to query parameters.
+func parseWindow(c echo.Context, from, to **time.Time) error {
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", from}, {"to", to}} {
+		raw := c.QueryParam(p.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Errorf("%w: %s must be an RFC 3339 timestamp", domain.ErrInvalidStatsFilter, p.name)
+		}
+		*p.dst = &t
+	}
+	return nil
+}
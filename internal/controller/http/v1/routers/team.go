@@ -28,6 +28,8 @@ func NewTeamController(
 func RegisterTeamRoutes(e *echo.Echo, h *TeamController) {
 	e.POST("/team/add", h.AddTeam)
 	e.GET("/team/get", h.GetTeam)
+	e.GET("/team/settings", h.GetSettings)
+	e.PATCH("/team/settings", h.UpdateSettings)
 }
 
 func (h *TeamController) AddTeam(c echo.Context) error {
@@ -50,7 +52,7 @@ func (h *TeamController) AddTeam(c echo.Context) error {
 	}
 
 	for _, m := range req.Members {
-		_, err := h.userService.UpsertUser(ctx, m.UserID, m.Username, team.Name, m.IsActive)
+		_, err := h.userService.UpsertUser(ctx, dto.ToUserUpsert(m, team.Name))
 		if err != nil {
 			return writeDomainError(c, err)
 		}
@@ -91,12 +93,8 @@ func (h *TeamController) GetTeam(c echo.Context) error {
 	}
 
 	members := make([]dto.TeamMemberDTO, 0, len(users))
-	for _, u := range users {
-		members = append(members, dto.TeamMemberDTO{
-			UserID:   u.ID,
-			Username: u.Username,
-			IsActive: u.IsActive,
-		})
+	for i := range users {
+		members = append(members, dto.ToTeamMemberDTO(&users[i], team.Name))
 	}
 
 	resp := dto.TeamDTO{
@@ -106,3 +104,51 @@ func (h *TeamController) GetTeam(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, resp)
 }
+
+func (h *TeamController) GetSettings(c echo.Context) error {
+	teamName := c.QueryParam("team_name")
+	if teamName == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "team_name is required",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	team, err := h.teamService.GetTeam(ctx, teamName)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ToTeamSettingsDTO(team))
+}
+
+func (h *TeamController) UpdateSettings(c echo.Context) error {
+	var req dto.UpdateTeamSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	team, err := h.teamService.UpdateSettings(ctx, req.TeamName, dto.ToTeamSettingsPatch(req))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.UpdateTeamSettingsResponse{
+		Settings: dto.ToTeamSettingsDTO(team),
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
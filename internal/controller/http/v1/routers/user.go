@@ -3,31 +3,41 @@ package routers
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/export"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
 type UserController struct {
-	userService *service.UserService
-	prService   *service.PRService
+	userService    *service.UserService
+	prService      *service.PRService
+	erasureService *service.ErasureService
 }
 
 func NewUserController(
 	userService *service.UserService,
 	prService *service.PRService,
+	erasureService *service.ErasureService,
 ) *UserController {
 	return &UserController{
-		userService: userService,
-		prService:   prService,
+		userService:    userService,
+		prService:      prService,
+		erasureService: erasureService,
 	}
 }
 
 func RegisterUserRoutes(e *echo.Echo, h *UserController) {
 	e.POST("/users/setIsActive", h.SetIsActive)
+	e.POST("/users/upsert", h.Upsert)
+	e.POST("/users/erase", h.Erase)
 	e.GET("/users/getReview", h.GetReview)
+	e.GET("/users/get", h.Get)
+	e.GET("/users/list", h.List)
 }
 
 func (h *UserController) SetIsActive(c echo.Context) error {
@@ -56,6 +66,58 @@ func (h *UserController) SetIsActive(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+func (h *UserController) Upsert(c echo.Context) error {
+	var req dto.UpsertUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	user, err := h.userService.UpsertUser(ctx, dto.UpsertUserRequestToDomain(req))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.UpsertUserResponse{
+		User: dto.ToUserDTO(user),
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserController) Erase(c echo.Context) error {
+	var req dto.EraseUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	erasure, err := h.erasureService.EraseUser(ctx, req.UserID)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.EraseUserResponse{
+		Erasure: dto.ToUserErasureDTO(erasure),
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 func (h *UserController) GetReview(c echo.Context) error {
 	userID := c.QueryParam("user_id")
 	if userID == "" {
@@ -67,6 +129,11 @@ func (h *UserController) GetReview(c echo.Context) error {
 		})
 	}
 
+	format, err := exportFormat(c)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
 	defer cancel()
 
@@ -75,6 +142,10 @@ func (h *UserController) GetReview(c echo.Context) error {
 		return writeDomainError(c, err)
 	}
 
+	if format != export.FormatJSON {
+		return writeExport(c, "review-"+userID, format, []export.Table{dto.PullRequestsTable(prs)})
+	}
+
 	shorts := make([]dto.PullRequestShortDTO, 0, len(prs))
 	for _, pr := range prs {
 		shorts = append(shorts, dto.ToPullRequestShortDTO(pr))
@@ -87,3 +158,78 @@ func (h *UserController) GetReview(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, resp)
 }
+
+func (h *UserController) Get(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "user_id is required",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	user, err := h.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ToUserDTO(user))
+}
+
+func (h *UserController) List(c echo.Context) error {
+	filter := domain.UserFilter{
+		TeamName:       c.QueryParam("team_name"),
+		UsernamePrefix: c.QueryParam("username_prefix"),
+	}
+
+	if raw := c.QueryParam("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: dto.ErrorObject{
+					Code:    dto.ErrorCodeNotFound,
+					Message: "is_active must be a boolean",
+				},
+			})
+		}
+		filter.IsActive = &isActive
+	}
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: dto.ErrorObject{
+					Code:    dto.ErrorCodeNotFound,
+					Message: "limit must be a positive integer",
+				},
+			})
+		}
+		filter.Limit = limit
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	page, err := h.userService.ListUsers(ctx, filter, c.QueryParam("cursor"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.ListUsersResponse{
+		Users:      make([]dto.UserListItemDTO, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+
+	for i := range page.Items {
+		resp.Users = append(resp.Users, dto.ToUserListItemDTO(&page.Items[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
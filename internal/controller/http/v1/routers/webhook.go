@@ -0,0 +1,144 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookController struct {
+	webhookService *service.WebhookService
+}
+
+func NewWebhookController(webhookService *service.WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
+
+func RegisterWebhookRoutes(e *echo.Echo, h *WebhookController) {
+	e.POST("/webhooks/create", h.Create)
+	e.GET("/webhooks/list", h.List)
+	e.GET("/webhooks/deliveries", h.Deliveries)
+	e.POST("/webhooks/redeliver", h.Redeliver)
+}
+
+func (h *WebhookController) Create(c echo.Context) error {
+	var req dto.CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	eventTypes := make([]domain.EventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		eventTypes = append(eventTypes, domain.EventType(t))
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	sub, err := h.webhookService.CreateSubscription(ctx, req.URL, req.Secret, eventTypes)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, dto.CreateWebhookResponse{
+		Webhook: dto.ToWebhookSubscriptionDTO(sub),
+		Secret:  sub.Secret,
+	})
+}
+
+func (h *WebhookController) List(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	subs, err := h.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.ListWebhooksResponse{
+		Webhooks: make([]dto.WebhookSubscriptionDTO, 0, len(subs)),
+	}
+	for i := range subs {
+		resp.Webhooks = append(resp.Webhooks, dto.ToWebhookSubscriptionDTO(&subs[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *WebhookController) Deliveries(c echo.Context) error {
+	subscriptionID, err := strconv.ParseInt(c.QueryParam("subscription_id"), 10, 64)
+	if err != nil || subscriptionID <= 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "subscription_id must be a positive integer",
+			},
+		})
+	}
+
+	var limit int
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: dto.ErrorObject{
+					Code:    dto.ErrorCodeNotFound,
+					Message: "limit must be a positive integer",
+				},
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	page, err := h.webhookService.ListDeliveries(ctx, subscriptionID, limit, c.QueryParam("cursor"))
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	resp := dto.ListDeliveriesResponse{
+		Deliveries: make([]dto.WebhookDeliveryDTO, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Items {
+		resp.Deliveries = append(resp.Deliveries, dto.ToWebhookDeliveryDTO(&page.Items[i]))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *WebhookController) Redeliver(c echo.Context) error {
+	var req dto.RedeliverRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: dto.ErrorObject{
+				Code:    dto.ErrorCodeNotFound,
+				Message: "invalid request body",
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), config.C().PGTimeout)
+	defer cancel()
+
+	d, err := h.webhookService.Redeliver(ctx, req.DeliveryID)
+	if err != nil {
+		return writeDomainError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, dto.RedeliverResponse{
+		Delivery: dto.ToWebhookDeliveryDTO(d),
+	})
+}
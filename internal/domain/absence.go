@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxAbsenceReasonLength caps the free-text reason of an absence.
+const MaxAbsenceReasonLength = 200
+
+// Absence is a period a user is out of office, from From up to To.
+type Absence struct {
+	ID        int64
+	UserID    string
+	From      time.Time
+	To        time.Time
+	Reason    string
+	CreatedAt time.Time
+}
+
+// Validate checks the absence and returns an error wrapping
+// ErrInvalidAbsence.
+func (a *Absence) Validate() error {
+	switch {
+	case a.UserID == "":
+		return fmt.Errorf("%w: user_id is required", ErrInvalidAbsence)
+	case a.From.IsZero() || a.To.IsZero():
+		return fmt.Errorf("%w: from and to are required", ErrInvalidAbsence)
+	case !a.From.Before(a.To):
+		return fmt.Errorf("%w: from must be before to", ErrInvalidAbsence)
+	case len(a.Reason) > MaxAbsenceReasonLength:
+		return fmt.Errorf("%w: reason is longer than %d bytes", ErrInvalidAbsence, MaxAbsenceReasonLength)
+	}
+	return nil
+}
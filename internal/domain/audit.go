@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditTeamCreated         AuditAction = "team.created"
+	AuditTeamSettingsUpdated AuditAction = "team.settings_updated"
+
+	AuditUserUpserted    AuditAction = "user.upserted"
+	AuditUserActivated   AuditAction = "user.activated"
+	AuditUserDeactivated AuditAction = "user.deactivated"
+	AuditUserErased      AuditAction = "user.erased"
+
+	AuditPRCreated    AuditAction = "pr.created"
+	AuditPRMerged     AuditAction = "pr.merged"
+	AuditPRReassigned AuditAction = "pr.reassigned"
+	AuditPRReviewed   AuditAction = "pr.reviewed"
+	AuditPRClosed     AuditAction = "pr.closed"
+	AuditPRReopened   AuditAction = "pr.reopened"
+
+	AuditPRReviewerRemoved AuditAction = "pr.reviewer_removed"
+)
+
+const (
+	AuditEntityTeam        = "team"
+	AuditEntityUser        = "user"
+	AuditEntityPullRequest = "pull_request"
+)
+
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 500
+)
+
+// AuditEvent is one entry of the append-only change log. Before and After
+// hold JSON snapshots of the entity; either may be empty.
+type AuditEvent struct {
+	ID         int64
+	OccurredAt time.Time
+	ActorID    string
+	Action     AuditAction
+	EntityType string
+	EntityID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+}
+
+// AuditFilter selects audit events, newest first. Empty fields match all.
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+	Action     AuditAction
+	From       *time.Time
+	To         *time.Time
+	BeforeID   int64
+	Limit      int
+}
+
+type AuditPage struct {
+	Items      []AuditEvent
+	NextCursor string
+}
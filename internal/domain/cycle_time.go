@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// OpenAgeBounds split open pull requests into age buckets: younger than a
+// day, one to three days and so on, and the last bucket for the older ones.
+var OpenAgeBounds = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// DurationStat sums up a set of durations. Median and P90 are zero when
+// there is nothing to sum up.
+type DurationStat struct {
+	Count  int
+	Median time.Duration
+	P90    time.Duration
+}
+
+// AgeBucket counts the open pull requests aged from From up to To. To is
+// zero for the last bucket.
+type AgeBucket struct {
+	From  time.Duration
+	To    time.Duration
+	Count int
+}
+
+// TeamCycleTime holds the delivery metrics of a team.
+type TeamCycleTime struct {
+	TeamName string
+	// TimeToMerge runs from the creation of a pull request to its merge.
+	TimeToMerge DurationStat
+	// TimeToApproval runs from the assignment of a reviewer to their
+	// approval.
+	TimeToApproval DurationStat
+	OpenAge        []AgeBucket
+}
+
+// ReviewerCycleTime holds the delivery metrics of a reviewer.
+type ReviewerCycleTime struct {
+	UserID         string
+	TimeToApproval DurationStat
+}
+
+// CycleTime is what /stats/cycleTime reports.
+type CycleTime struct {
+	// AsOf is the time the age of open pull requests is measured at.
+	AsOf       time.Time
+	ByTeam     []TeamCycleTime
+	ByReviewer []ReviewerCycleTime
+}
+
+// CycleTimeFilter selects the period and the team cycle time is measured
+// over. Merges and approvals count when they happened within [From, To);
+// open pull requests are the ones open at To, or now when To is empty.
+type CycleTimeFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+}
+
+// Validate checks the filter and returns an error wrapping
+// ErrInvalidStatsFilter.
+func (f CycleTimeFilter) Validate() error {
+	return validateWindow(f.From, f.To)
+}
+
+// NewAgeBuckets returns empty buckets split by bounds.
+func NewAgeBuckets(bounds []time.Duration) []AgeBucket {
+	buckets := make([]AgeBucket, len(bounds)+1)
+	for i, b := range bounds {
+		buckets[i].To = b
+		buckets[i+1].From = b
+	}
+	return buckets
+}
+
+func validateWindow(from, to *time.Time) error {
+	if from != nil && to != nil && !from.Before(*to) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidStatsFilter)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// UserErasure records that a user's personal data was anonymized.
+type UserErasure struct {
+	ID                int64
+	UserID            string
+	RequestedBy       string
+	ReassignedReviews int
+	RemovedReviews    int
+	ErasedAt          time.Time
+}
@@ -11,8 +11,44 @@ var (
 	ErrPRExists   = errors.New("pull request already exists")
 
 	ErrPRAlreadyMerged = errors.New("pull request is already merged")
+	ErrPRClosed        = errors.New("pull request is closed")
 
 	ErrNotAssigned = errors.New("user is not assigned as reviewer")
 
 	ErrNoCandidate = errors.New("no candidate reviewer available")
+
+	ErrNotTeamMember = errors.New("user is not a member of the team")
+	ErrTeamRequired  = errors.New("team is required: author has no primary team")
+
+	ErrInvalidTeamSettings = errors.New("invalid team settings")
+
+	ErrForbidden = errors.New("action is not allowed for this user")
+
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+	ErrExternalAccountTaken = errors.New("external account is linked to another user")
+
+	ErrUserErased = errors.New("user is already erased")
+
+	ErrPRVersionMismatch = errors.New("pull request was changed by another request")
+	ErrInvalidVerdict    = errors.New("unknown review verdict")
+
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was used with a different request")
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is still in progress")
+
+	ErrInvalidWebhook   = errors.New("invalid webhook subscription")
+	ErrWebhookNotFound  = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrInvalidPreferences = errors.New("invalid notification preferences")
+
+	ErrInvalidSLA = errors.New("invalid review SLA")
+
+	ErrInvalidStatsFilter = errors.New("invalid statistics filter")
+
+	ErrInvalidAbsence  = errors.New("invalid absence")
+	ErrAbsenceNotFound = errors.New("absence not found")
+
+	ErrInvalidExportFormat = errors.New("invalid export format")
 )
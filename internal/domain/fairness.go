@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultFairnessWindow is the period the fairness report covers when
+	// it is not given.
+	DefaultFairnessWindow = 30 * 24 * time.Hour
+
+	// FairnessTop is how many of the most over- and underloaded members the
+	// fairness report names.
+	FairnessTop = 3
+)
+
+// ActivityChange is a user being activated or deactivated.
+type ActivityChange struct {
+	At     time.Time
+	Active bool
+}
+
+// Activity tells whether a user was active over a period: at its start and
+// after each change within it, in the order they happened.
+type Activity struct {
+	ActiveAtStart bool
+	Changes       []ActivityChange
+}
+
+// FairnessMember is the review load of a team member over a period.
+type FairnessMember struct {
+	TeamName    string
+	UserID      string
+	Username    string
+	Assignments int
+	// Available is how long the member was active and in office.
+	Available time.Duration
+	// Share is the part of the team's assignments the member got, and
+	// ExpectedShare the part of the team's available time they had.
+	Share         float64
+	ExpectedShare float64
+	// Load is Share over ExpectedShare: above 1 the member got more than
+	// their fair share.
+	Load float64
+}
+
+// TeamFairness tells how evenly reviews were spread across a team.
+type TeamFairness struct {
+	TeamName    string
+	Assignments int
+	// Gini is 0 when every member got reviews in proportion to their
+	// available time and approaches 1 when one member got them all.
+	Gini        float64
+	Members     []FairnessMember
+	Overloaded  []string
+	Underloaded []string
+}
+
+// Fairness is what /stats/fairness reports.
+type Fairness struct {
+	From  time.Time
+	To    time.Time
+	Teams []TeamFairness
+}
+
+// FairnessFilter selects the period and the team of the fairness report.
+// Assignments count when they were made within [From, To).
+type FairnessFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+}
+
+// Validate checks the filter and returns an error wrapping
+// ErrInvalidStatsFilter.
+func (f FairnessFilter) Validate() error {
+	return validateWindow(f.From, f.To)
+}
+
+// Available returns how much of [from, to) the user was active and not
+// absent.
+func (a Activity) Available(from, to time.Time, absences []Absence) time.Duration {
+	points := []time.Time{from, to}
+	add := func(t time.Time) {
+		if t.After(from) && t.Before(to) {
+			points = append(points, t)
+		}
+	}
+	for _, c := range a.Changes {
+		add(c.At)
+	}
+	for _, abs := range absences {
+		add(abs.From)
+		add(abs.To)
+	}
+	slices.SortFunc(points, func(a, b time.Time) int { return a.Compare(b) })
+	points = slices.CompactFunc(points, time.Time.Equal)
+
+	var total time.Duration
+	for i := 0; i+1 < len(points); i++ {
+		start := points[i]
+
+		active := a.ActiveAtStart
+		for _, c := range a.Changes {
+			if !c.At.After(start) {
+				active = c.Active
+			}
+		}
+		absent := slices.ContainsFunc(absences, func(abs Absence) bool {
+			return !abs.From.After(start) && abs.To.After(start)
+		})
+
+		if active && !absent {
+			total += points[i+1].Sub(start)
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// HostPRAction is a pull request lifecycle change reported by a Git hosting
+// provider.
+type HostPRAction string
+
+const (
+	HostPROpened   HostPRAction = "opened"
+	HostPRClosed   HostPRAction = "closed"
+	HostPRMerged   HostPRAction = "merged"
+	HostPRReopened HostPRAction = "reopened"
+)
+
+// HostPREvent is a provider webhook reduced to what the service acts on.
+type HostPREvent struct {
+	Action HostPRAction
+	Source PRSource
+	Title  string
+	// AuthorLogin is the provider login of the pull request author.
+	AuthorLogin string
+	// SenderLogin is the provider login of whoever triggered the event.
+	SenderLogin string
+}
+
+type ReviewerSyncStatus string
+
+const (
+	ReviewerSyncPending   ReviewerSyncStatus = "PENDING"
+	ReviewerSyncSucceeded ReviewerSyncStatus = "SUCCEEDED"
+	ReviewerSyncFailed    ReviewerSyncStatus = "FAILED"
+)
+
+// ReviewerSyncJob pushes a change of assigned reviewers to the Git hosting
+// provider the pull request came from. Add and Remove hold user ids; they
+// are resolved to provider logins when the job runs.
+type ReviewerSyncJob struct {
+	ID            int64
+	PRID          string
+	Add           []string
+	Remove        []string
+	Status        ReviewerSyncStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
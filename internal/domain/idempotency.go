@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// MaxIdempotencyKeyLength bounds the Idempotency-Key header.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord is the stored outcome of a request sent with an
+// Idempotency-Key. StatusCode is zero while the first request is in flight;
+// the request holds the key until LockedUntil and renews it while it runs.
+type IdempotencyRecord struct {
+	Key         string
+	Method      string
+	Path        string
+	RequestHash string
+	StatusCode  int
+	Headers     map[string]string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	LockedUntil time.Time
+}
+
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type JobRunStatus string
+
+const (
+	JobRunRunning   JobRunStatus = "RUNNING"
+	JobRunSucceeded JobRunStatus = "SUCCEEDED"
+	JobRunFailed    JobRunStatus = "FAILED"
+)
+
+// JobRun is one run of a scheduled background job. A job runs at most once
+// for every time it is scheduled at, on whichever instance gets to it first.
+type JobRun struct {
+	ID          int64
+	JobName     string
+	ScheduledAt time.Time
+	// Instance is the host and process that ran the job.
+	Instance   string
+	Status     JobRunStatus
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Error      string
+}
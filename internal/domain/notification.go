@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type NotificationChannel string
+
+const ChannelEmail NotificationChannel = "email"
+
+func IsKnownChannel(c NotificationChannel) bool {
+	return c == ChannelEmail
+}
+
+// NotificationMode decides whether notifications are sent as they happen or
+// collected into a daily digest.
+type NotificationMode string
+
+const (
+	NotificationImmediate NotificationMode = "immediate"
+	NotificationDigest    NotificationMode = "digest"
+)
+
+const (
+	LanguageEN = "en"
+	LanguageRU = "ru"
+)
+
+func IsKnownLanguage(lang string) bool {
+	return lang == LanguageEN || lang == LanguageRU
+}
+
+type NotificationKind string
+
+const (
+	NotificationReviewAssigned   NotificationKind = "review_assigned"
+	NotificationReviewReassigned NotificationKind = "review_reassigned"
+	NotificationReviewReminder   NotificationKind = "review_reminder"
+	// NotificationReviewOverdue tells a reviewer that the review SLA of the
+	// team was missed.
+	NotificationReviewOverdue NotificationKind = "review_overdue"
+	// NotificationReviewEscalated tells a team lead about a review that
+	// stayed unanswered past the escalation threshold.
+	NotificationReviewEscalated NotificationKind = "review_escalated"
+	// NotificationStaleReview reminds a reviewer of a pending review on a
+	// stale pull request.
+	NotificationStaleReview NotificationKind = "stale_review"
+	// NotificationStalePR tells a team lead about a stale pull request of
+	// the team.
+	NotificationStalePR NotificationKind = "stale_pull_request"
+)
+
+// DigestOnly reports whether notifications of the kind always wait for the
+// daily digest, whatever mode the recipient chose.
+func (k NotificationKind) DigestOnly() bool {
+	return k == NotificationStaleReview || k == NotificationStalePR
+}
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "PENDING"
+	NotificationSent    NotificationStatus = "SENT"
+	// NotificationSkipped is a notification there was nobody or nothing
+	// to send to, such as a user without an email address.
+	NotificationSkipped NotificationStatus = "SKIPPED"
+	NotificationFailed  NotificationStatus = "FAILED"
+)
+
+// Notification tells a reviewer about a pull request. It is rendered in the
+// recipient's language when sent.
+type Notification struct {
+	ID     int64
+	UserID string
+	Kind   NotificationKind
+	PRID   string
+	// PreviousReviewerID is set on reassignment to the replaced reviewer
+	// and on escalation to the reviewer who missed the SLA.
+	PreviousReviewerID string
+
+	Status        NotificationStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// QuietHours is a daily time range, in minutes after midnight, during
+// which nothing is sent. The range wraps around midnight when End is
+// before Start.
+type QuietHours struct {
+	Start int
+	End   int
+}
+
+func (q QuietHours) Contains(minute int) bool {
+	if q.Start <= q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+type NotificationPreferences struct {
+	UserID     string
+	Channels   []NotificationChannel
+	Language   string
+	Mode       NotificationMode
+	QuietHours *QuietHours
+	Timezone   string
+	UpdatedAt  time.Time
+}
+
+// DefaultNotificationPreferences applies to users who never changed them.
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:   userID,
+		Channels: []NotificationChannel{ChannelEmail},
+		Language: LanguageEN,
+		Mode:     NotificationImmediate,
+		Timezone: "UTC",
+	}
+}
+
+func (p *NotificationPreferences) HasChannel(c NotificationChannel) bool {
+	return slices.Contains(p.Channels, c)
+}
+
+// Validate checks the preferences and returns an error wrapping
+// ErrInvalidPreferences.
+func (p *NotificationPreferences) Validate() error {
+	for _, c := range p.Channels {
+		if !IsKnownChannel(c) {
+			return fmt.Errorf("%w: unknown channel %q", ErrInvalidPreferences, c)
+		}
+	}
+	if !IsKnownLanguage(p.Language) {
+		return fmt.Errorf("%w: unsupported language %q", ErrInvalidPreferences, p.Language)
+	}
+	if p.Mode != NotificationImmediate && p.Mode != NotificationDigest {
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidPreferences, p.Mode)
+	}
+	if q := p.QuietHours; q != nil {
+		if q.Start < 0 || q.Start >= minutesPerDay || q.End < 0 || q.End >= minutesPerDay || q.Start == q.End {
+			return fmt.Errorf("%w: quiet hours must be two different times of day", ErrInvalidPreferences)
+		}
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidPreferences, p.Timezone)
+	}
+	return nil
+}
+
+// DeliverAt returns when a notification created at createdAt may be sent:
+// digests wait for the next digestAt (minutes after midnight) and nothing is
+// sent during quiet hours. Times are taken in the user's timezone.
+func (p *NotificationPreferences) DeliverAt(createdAt time.Time, digestAt int) time.Time {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	t := createdAt.In(loc)
+	if p.Mode == NotificationDigest {
+		t = nextClock(t, digestAt)
+	}
+	if p.QuietHours != nil && p.QuietHours.Contains(t.Hour()*60+t.Minute()) {
+		t = nextClock(t, p.QuietHours.End)
+	}
+	return t
+}
+
+const minutesPerDay = 24 * 60
+
+// nextClock returns the first moment at or after t when the clock shows
+// minute minutes after midnight.
+func nextClock(t time.Time, minute int) time.Time {
+	at := time.Date(t.Year(), t.Month(), t.Day(), minute/60, minute%60, 0, 0, t.Location())
+	if at.Before(t) {
+		at = time.Date(t.Year(), t.Month(), t.Day()+1, minute/60, minute%60, 0, 0, t.Location())
+	}
+	return at
+}
+
+// ParseClock reads a "HH:MM" time of day into minutes after midnight.
+func ParseClock(s string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || len(s) != 5 || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return h*60 + m, nil
+}
+
+// FormatClock writes minutes after midnight as "HH:MM".
+func FormatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
@@ -1,20 +1,67 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type PRStatus string
 
 const (
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
+type ReviewVerdict string
+
+const (
+	VerdictApproved         ReviewVerdict = "APPROVED"
+	VerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+)
+
+func IsKnownVerdict(v ReviewVerdict) bool {
+	return v == VerdictApproved || v == VerdictChangesRequested
+}
+
 type PullRequest struct {
 	ID                string
 	Name              string
 	AuthorID          string
+	TeamName          string
 	Status            PRStatus
 	AssignedReviewers []string
+	Verdicts          map[string]ReviewVerdict
 	CreatedAt         *time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
+
+	// Version grows by one with every change of the pull request or its
+	// reviewers and lets clients detect that they act on a stale view.
+	Version int64
+}
+
+// MatchesVersion reports whether the pull request satisfies an If-Match
+// precondition. Zero means the client did not send one.
+func (pr *PullRequest) MatchesVersion(expected int64) bool {
+	return expected == 0 || pr.Version == expected
+}
+
+// PRSource links a pull request to the pull or merge request on a Git
+// hosting provider it was created from.
+type PRSource struct {
+	Provider   string
+	Repository string
+	Number     int64
+	URL        string
+}
+
+// PRID is the pull request id used for requests created from the hosting
+// provider, e.g. "github:acme/api#42" or "gitlab:acme/api!42".
+func (s PRSource) PRID() string {
+	sep := "#"
+	if s.Provider == ProviderGitLab {
+		sep = "!"
+	}
+	return fmt.Sprintf("%s:%s%s%d", s.Provider, s.Repository, sep, s.Number)
 }
@@ -0,0 +1,104 @@
+package domain
+
+import "time"
+
+type PREventType string
+
+const (
+	PREventCreated            PREventType = "created"
+	PREventReviewerAssigned   PREventType = "reviewer_assigned"
+	PREventReviewerReassigned PREventType = "reviewer_reassigned"
+	PREventReviewerRemoved    PREventType = "reviewer_removed"
+	PREventReviewSubmitted    PREventType = "review_submitted"
+	PREventMerged             PREventType = "merged"
+	PREventClosed             PREventType = "closed"
+	PREventReopened           PREventType = "reopened"
+
+	// PREventReviewOverdue and PREventReviewEscalated mark a review that
+	// ran past the response time and the escalation threshold of the
+	// team's review SLA.
+	PREventReviewOverdue   PREventType = "review_overdue"
+	PREventReviewEscalated PREventType = "review_escalated"
+)
+
+// AssignmentStrategyRandom picks reviewers uniformly at random among the
+// eligible members of the pull request's team.
+const AssignmentStrategyRandom = "random"
+
+// PREvent is one entry in the history of a pull request. Events are stored
+// when they happen and never rewritten, so the timeline explains how the
+// pull request got into its current state.
+type PREvent struct {
+	ID         int64
+	PRID       string
+	Type       PREventType
+	OccurredAt time.Time
+	ActorID    string
+
+	// ReviewerID is the reviewer the event is about: the one assigned,
+	// removed, submitting a verdict or missing the SLA, or the replacement
+	// on reassignment.
+	ReviewerID string
+	// PreviousReviewerID is set on reassignment to the replaced reviewer.
+	PreviousReviewerID string
+
+	Strategy string
+	Reason   string
+	Verdict  ReviewVerdict
+}
+
+// PRStreamEvent is a timeline event pushed to live subscribers, together
+// with the pull request details they filter and display it by.
+type PRStreamEvent struct {
+	PREvent
+
+	Position PRStreamPosition
+
+	PRName   string
+	AuthorID string
+	TeamName string
+}
+
+// PRStreamPosition orders streamed events by the transaction that appended
+// them, then by id. Events are streamed only once every older transaction
+// has finished, so no event can turn up before a position already passed.
+type PRStreamPosition struct {
+	TxID    int64
+	EventID int64
+}
+
+func (p PRStreamPosition) Before(o PRStreamPosition) bool {
+	return p.TxID < o.TxID || (p.TxID == o.TxID && p.EventID < o.EventID)
+}
+
+// IsStreamedPREvent reports whether live subscribers are told about events
+// of the type: reviewer assignments and merges.
+func IsStreamedPREvent(t PREventType) bool {
+	switch t {
+	case PREventReviewerAssigned, PREventReviewerReassigned, PREventMerged:
+		return true
+	}
+	return false
+}
+
+// PRStreamFilter selects the events a subscriber receives. Empty fields are
+// not applied.
+type PRStreamFilter struct {
+	// UserID matches events that assign or unassign the user and merges
+	// of the user's pull requests.
+	UserID   string
+	TeamName string
+}
+
+func (f PRStreamFilter) Matches(e *PRStreamEvent) bool {
+	if !IsStreamedPREvent(e.Type) {
+		return false
+	}
+	if f.TeamName != "" && e.TeamName != f.TeamName {
+		return false
+	}
+	if f.UserID != "" && e.ReviewerID != f.UserID && e.PreviousReviewerID != f.UserID && e.AuthorID != f.UserID {
+		return false
+	}
+	return true
+}
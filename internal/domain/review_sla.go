@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SLAEscalation is what happens to a review that stays unanswered past the
+// escalation threshold.
+type SLAEscalation string
+
+const (
+	// SLANotifyLead tells the team leads about the review.
+	SLANotifyLead SLAEscalation = "notify_lead"
+	// SLAReassign hands the review to another member of the team.
+	SLAReassign SLAEscalation = "reassign"
+)
+
+const (
+	DefaultSLAResponseHours   = 24
+	DefaultSLAEscalationHours = 48
+	MaxSLAHours               = 24 * 30
+)
+
+// ReviewSLA is how quickly reviewers of a team are expected to respond to a
+// review request. Hours are working hours counted from the assignment, see
+// AddWorkingHours.
+type ReviewSLA struct {
+	// ResponseHours after which a review is overdue. Zero turns SLA
+	// tracking off for the team.
+	ResponseHours int
+	// EscalationHours after which an overdue review is escalated. Zero
+	// turns escalation off.
+	EscalationHours int
+	Escalation      SLAEscalation
+}
+
+func DefaultReviewSLA() ReviewSLA {
+	return ReviewSLA{
+		ResponseHours:   DefaultSLAResponseHours,
+		EscalationHours: DefaultSLAEscalationHours,
+		Escalation:      SLANotifyLead,
+	}
+}
+
+// Validate checks the SLA and returns an error wrapping ErrInvalidSLA.
+func (s ReviewSLA) Validate() error {
+	if s.ResponseHours < 0 || s.ResponseHours > MaxSLAHours ||
+		s.EscalationHours < 0 || s.EscalationHours > MaxSLAHours {
+		return fmt.Errorf("%w: hours must be between 0 and %d", ErrInvalidSLA, MaxSLAHours)
+	}
+	if s.EscalationHours > 0 && s.EscalationHours <= s.ResponseHours {
+		return fmt.Errorf("%w: escalation must come after the response time", ErrInvalidSLA)
+	}
+	if s.Escalation != SLANotifyLead && s.Escalation != SLAReassign {
+		return fmt.Errorf("%w: unknown escalation %q", ErrInvalidSLA, s.Escalation)
+	}
+	return nil
+}
+
+// PendingReview is a review request the reviewer has not answered yet,
+// together with the SLA of the pull request's team.
+type PendingReview struct {
+	PRID       string
+	PRName     string
+	TeamName   string
+	ReviewerID string
+	AssignedAt time.Time
+	// BreachedAt is set once the review was flagged as overdue.
+	BreachedAt *time.Time
+	// EscalatedAt is set once the team leads were told about the review.
+	EscalatedAt *time.Time
+	SLA         ReviewSLA
+}
+
+// DueAt returns when the review becomes overdue.
+func (r *PendingReview) DueAt() time.Time {
+	return AddWorkingHours(r.AssignedAt, r.SLA.ResponseHours)
+}
+
+// EscalateAt returns when the review is escalated.
+func (r *PendingReview) EscalateAt() time.Time {
+	return AddWorkingHours(r.AssignedAt, r.SLA.EscalationHours)
+}
+
+// AddWorkingHours adds hours of working time to t. Working time is Monday
+// to Friday in UTC, so an SLA of 24 hours set on Friday evening expires on
+// Monday evening.
+func AddWorkingHours(t time.Time, hours int) time.Time {
+	t = t.UTC()
+	left := time.Duration(hours) * time.Hour
+
+	for {
+		for isWeekend(t) {
+			t = nextMidnight(t)
+		}
+
+		end := nextMidnight(t)
+		if !t.Add(left).After(end) {
+			return t.Add(left)
+		}
+		left -= end.Sub(t)
+		t = end
+	}
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+func nextMidnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// StalePolicy decides when an open pull request is stale. Zero turns the
+// respective check off.
+type StalePolicy struct {
+	// MaxAge is how long a pull request may stay open.
+	MaxAge time.Duration
+	// MaxIdle is how long a pull request may go without a verdict.
+	MaxIdle time.Duration
+}
+
+// OpenedBefore returns the creation time before which pull requests are
+// stale by age, or the zero time when the check is off.
+func (p StalePolicy) OpenedBefore(now time.Time) time.Time {
+	if p.MaxAge <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-p.MaxAge)
+}
+
+// ActiveBefore returns the last activity time before which pull requests
+// are stale for inactivity, or the zero time when the check is off.
+func (p StalePolicy) ActiveBefore(now time.Time) time.Time {
+	if p.MaxIdle <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-p.MaxIdle)
+}
+
+// Reasons returns why a pull request is stale at now; none means it is not.
+func (p StalePolicy) Reasons(pr *StalePR, now time.Time) []StaleReason {
+	var reasons []StaleReason
+	if p.MaxAge > 0 && !pr.CreatedAt.After(p.OpenedBefore(now)) {
+		reasons = append(reasons, StaleByAge)
+	}
+	if p.MaxIdle > 0 && !pr.LastActivityAt.After(p.ActiveBefore(now)) {
+		reasons = append(reasons, StaleByInactivity)
+	}
+	return reasons
+}
+
+type StaleReason string
+
+const (
+	// StaleByAge is a pull request open longer than the policy allows.
+	StaleByAge StaleReason = "age"
+	// StaleByInactivity is a pull request nobody reviewed for too long.
+	StaleByInactivity StaleReason = "inactivity"
+)
+
+// StalePR is an open pull request that sits without progress.
+type StalePR struct {
+	PRID      string
+	PRName    string
+	AuthorID  string
+	TeamName  string
+	CreatedAt time.Time
+	// LastActivityAt is the time of the latest verdict, or the creation
+	// time when nobody reviewed the pull request yet.
+	LastActivityAt time.Time
+	// PendingReviewers are the assigned reviewers without a verdict.
+	PendingReviewers []string
+	Reasons          []StaleReason
+}
+
+// StalePRFilter narrows the list of stale pull requests. Empty fields match
+// everything.
+type StalePRFilter struct {
+	TeamName   string
+	ReviewerID string
+}
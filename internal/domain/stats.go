@@ -1,5 +1,13 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
+// MaxStatsPRLimit is the largest pr_limit honoured by /stats.
+const MaxStatsPRLimit = 1000
+
 type UserAssignmentStat struct {
 	UserID      string
 	Assignments int
@@ -9,3 +17,51 @@ type PRReviewerStat struct {
 	PRID      string
 	Reviewers int
 }
+
+// TeamStat sums up the pull requests of a team.
+type TeamStat struct {
+	TeamName     string
+	PullRequests int
+	Open         int
+	Merged       int
+	Closed       int
+	// Assignments is the number of reviewers assigned to the pull requests.
+	Assignments int
+}
+
+// Stats is what /stats reports for the pull requests matching a filter.
+// When the filter has a PRLimit, ByPR holds the pull requests with the most
+// reviewers only and ByPRTruncated tells whether more were left out.
+type Stats struct {
+	ByUser        []UserAssignmentStat
+	ByPR          []PRReviewerStat
+	ByPRTruncated bool
+	ByTeam        []TeamStat
+}
+
+// StatsFilter selects the pull requests statistics are collected over.
+// From and To bound the creation time, From inclusive and To exclusive;
+// empty fields match all.
+type StatsFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+	Status   PRStatus
+	// PRLimit caps the number of pull requests in Stats.ByPR; zero means
+	// all of them.
+	PRLimit int
+}
+
+// Validate checks the filter and returns an error wrapping
+// ErrInvalidStatsFilter.
+func (f StatsFilter) Validate() error {
+	if err := validateWindow(f.From, f.To); err != nil {
+		return err
+	}
+	switch f.Status {
+	case "", PRStatusOpen, PRStatusMerged, PRStatusClosed:
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidStatsFilter, f.Status)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Day is the length of a snapshot period. Days start at midnight UTC.
+const Day = 24 * time.Hour
+
+// MaxHistoryDays caps the number of days /stats/history returns at once.
+const MaxHistoryDays = 366
+
+// TeamSnapshot sums up the pull requests of a team for a day.
+type TeamSnapshot struct {
+	Day      time.Time
+	TeamName string
+	// Open is the number of pull requests still open at the end of the day.
+	Open   int
+	Opened int
+	Merged int
+	Closed int
+	// Assignments is the number of reviewers assigned during the day.
+	Assignments int
+}
+
+// UserSnapshot sums up the reviews of a user for a day.
+type UserSnapshot struct {
+	Day    time.Time
+	UserID string
+	// Assignments is the number of pull requests assigned during the day.
+	Assignments int
+	// OpenReviews is the number of open pull requests the user reviews at
+	// the end of the day.
+	OpenReviews int
+}
+
+// TeamHistory holds a series per metric of a team, a value per day of
+// StatsHistory.Days.
+type TeamHistory struct {
+	TeamName    string
+	Open        []int
+	Opened      []int
+	Merged      []int
+	Closed      []int
+	Assignments []int
+}
+
+// UserHistory holds a series per metric of a user, a value per day of
+// StatsHistory.Days.
+type UserHistory struct {
+	UserID      string
+	Assignments []int
+	OpenReviews []int
+}
+
+// StatsHistory is what /stats/history reports for the days [From, To).
+// Days without a snapshot count as zero.
+type StatsHistory struct {
+	From  time.Time
+	To    time.Time
+	Days  []time.Time
+	Teams []TeamHistory
+	Users []UserHistory
+}
+
+// StatsHistoryFilter selects the days [From, To) and the team or user the
+// history is reported for. From and To are midnights UTC.
+type StatsHistoryFilter struct {
+	From     time.Time
+	To       time.Time
+	TeamName string
+	UserID   string
+}
+
+// Validate checks the filter and returns an error wrapping
+// ErrInvalidStatsFilter.
+func (f StatsHistoryFilter) Validate() error {
+	if err := validateWindow(&f.From, &f.To); err != nil {
+		return err
+	}
+	if f.To.Sub(f.From) > MaxHistoryDays*Day {
+		return fmt.Errorf("%w: history spans at most %d days", ErrInvalidStatsFilter, MaxHistoryDays)
+	}
+	return nil
+}
+
+// Days returns the days of the filter.
+func (f StatsHistoryFilter) Days() []time.Time {
+	var days []time.Time
+	for d := f.From; d.Before(f.To); d = d.Add(Day) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// StartOfDay returns the midnight UTC that starts the day of t.
+func StartOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(Day)
+}
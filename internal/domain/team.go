@@ -1,5 +1,54 @@
 package domain
 
+const (
+	DefaultReviewersCount = 2
+	MaxReviewersCount     = 10
+)
+
 type Team struct {
-	Name string
+	Name           string
+	Description    string
+	SlackChannelID string
+	Leads          []string
+	ReviewerPolicy ReviewerPolicy
+	ReviewSLA      ReviewSLA
+}
+
+// ReviewerPolicy controls how reviewers are picked for pull requests of a team.
+type ReviewerPolicy struct {
+	ReviewersCount int
+	ExcludeLeads   bool
+}
+
+// TeamSettingsPatch describes a partial update of team settings.
+// Nil fields are left unchanged.
+type TeamSettingsPatch struct {
+	Description    *string
+	SlackChannelID *string
+	Leads          *[]string
+	ReviewersCount *int
+	ExcludeLeads   *bool
+
+	SLAResponseHours   *int
+	SLAEscalationHours *int
+	SLAEscalation      *SLAEscalation
+}
+
+func NewTeam(name string) *Team {
+	return &Team{
+		Name: name,
+		ReviewerPolicy: ReviewerPolicy{
+			ReviewersCount: DefaultReviewersCount,
+		},
+		ReviewSLA: DefaultReviewSLA(),
+	}
+}
+
+func (t *Team) IsLead(userID string) bool {
+	for _, id := range t.Leads {
+		if id == userID {
+			return true
+		}
+	}
+	return false
 }
@@ -1,8 +1,76 @@
 package domain
 
+import "time"
+
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
 type User struct {
-	ID       string
-	Username string
-	TeamName string
-	IsActive bool
+	ID          string
+	Username    string
+	TeamName    string
+	Teams       []string
+	IsActive    bool
+	Email       string
+	DisplayName string
+
+	// ExternalAccounts maps a Git hosting provider to the user's login there.
+	ExternalAccounts map[string]string
+
+	// ErasedAt is set once the user's personal data has been anonymized.
+	ErasedAt *time.Time
+}
+
+func (u *User) IsMemberOf(teamName string) bool {
+	for _, t := range u.Teams {
+		if t == teamName {
+			return true
+		}
+	}
+	return false
+}
+
+// UserUpsert describes a user created or updated through a team. Nil profile
+// fields keep their current values; an empty login in ExternalAccounts unlinks
+// the account of that provider.
+type UserUpsert struct {
+	ID               string
+	Username         string
+	TeamName         string
+	IsActive         bool
+	IsPrimary        bool
+	Email            *string
+	DisplayName      *string
+	ExternalAccounts map[string]string
+}
+
+func IsKnownProvider(provider string) bool {
+	return provider == ProviderGitHub || provider == ProviderGitLab
+}
+
+const (
+	DefaultUsersPageSize = 50
+	MaxUsersPageSize     = 200
+)
+
+// UserFilter selects users for the directory listing. Empty fields are not applied.
+type UserFilter struct {
+	TeamName       string
+	IsActive       *bool
+	UsernamePrefix string
+	AfterID        string
+	Limit          int
+}
+
+type UserListItem struct {
+	User
+	OpenReviews int
+}
+
+type UserPage struct {
+	Items      []UserListItem
+	NextCursor string
+	Total      int
 }
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType names a change published to webhook subscribers. The values are
+// part of the public webhook contract.
+type EventType string
+
+const (
+	EventPRCreated         EventType = "pr.created"
+	EventPRMerged          EventType = "pr.merged"
+	EventPRReassigned      EventType = "pr.reassigned"
+	EventPRReviewed        EventType = "pr.reviewed"
+	EventPRReviewerRemoved EventType = "pr.reviewer_removed"
+	EventPRClosed          EventType = "pr.closed"
+	EventPRReopened        EventType = "pr.reopened"
+
+	EventUserUpserted    EventType = "user.upserted"
+	EventUserActivated   EventType = "user.activated"
+	EventUserDeactivated EventType = "user.deactivated"
+	EventUserErased      EventType = "user.erased"
+)
+
+func IsKnownEventType(t EventType) bool {
+	switch t {
+	case EventPRCreated, EventPRMerged, EventPRReassigned, EventPRReviewed, EventPRReviewerRemoved,
+		EventPRClosed, EventPRReopened,
+		EventUserUpserted, EventUserActivated, EventUserDeactivated, EventUserErased:
+		return true
+	}
+	return false
+}
+
+// OutboxEvent is an event written in the transaction of the change it
+// describes. The dispatcher turns it into deliveries after the commit.
+type OutboxEvent struct {
+	ID        int64
+	Type      EventType
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+type WebhookSubscription struct {
+	ID     int64
+	URL    string
+	Secret string
+	// EventTypes limits the subscription to the listed events; empty means
+	// all events.
+	EventTypes []EventType
+	IsActive   bool
+	CreatedAt  time.Time
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliverySucceeded DeliveryStatus = "SUCCEEDED"
+	DeliveryFailed    DeliveryStatus = "FAILED"
+)
+
+const (
+	DefaultDeliveryPageSize = 50
+	MaxDeliveryPageSize     = 500
+)
+
+// WebhookDelivery is one event sent to one subscription, together with the
+// outcome of the latest attempt.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64
+	EventType      EventType
+	RedeliveryOf   *int64
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+}
+
+// WebhookDispatch is a delivery claimed by the dispatcher with everything
+// needed to send it.
+type WebhookDispatch struct {
+	Delivery WebhookDelivery
+	URL      string
+	Secret   string
+	Event    OutboxEvent
+}
+
+type DeliveryPage struct {
+	Items      []WebhookDelivery
+	NextCursor string
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type AbsenceDTO struct {
+	ID        int64     `json:"id"`
+	UserID    string    `json:"user_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AddAbsenceRequest struct {
+	UserID string    `json:"user_id"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Reason string    `json:"reason"`
+}
+
+type AddAbsenceResponse struct {
+	Absence AbsenceDTO `json:"absence"`
+}
+
+type RemoveAbsenceRequest struct {
+	ID int64 `json:"id"`
+}
+
+type AbsencesResponse struct {
+	Absences []AbsenceDTO `json:"absences"`
+}
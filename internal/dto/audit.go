@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEventDTO struct {
+	ID         int64           `json:"id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	ActorID    string          `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+}
+
+type ListAuditResponse struct {
+	Events     []AuditEventDTO `json:"events"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
@@ -7,6 +7,39 @@ const (
 	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+
+	ErrorCodeNotTeamMember ErrorCode = "NOT_TEAM_MEMBER"
+	ErrorCodeTeamRequired  ErrorCode = "TEAM_REQUIRED"
+	ErrorCodeForbidden     ErrorCode = "FORBIDDEN"
+	ErrorCodeInvalidCursor ErrorCode = "INVALID_CURSOR"
+
+	ErrorCodeInvalidTeamSettings ErrorCode = "INVALID_TEAM_SETTINGS"
+
+	ErrorCodeExternalAccountTaken ErrorCode = "EXTERNAL_ACCOUNT_TAKEN"
+	ErrorCodeUserErased           ErrorCode = "USER_ERASED"
+
+	ErrorCodePRVersionMismatch ErrorCode = "PR_VERSION_MISMATCH"
+	ErrorCodeInvalidVerdict    ErrorCode = "INVALID_VERDICT"
+
+	ErrorCodeInvalidIdempotencyKey ErrorCode = "INVALID_IDEMPOTENCY_KEY"
+	ErrorCodeIdempotencyKeyReused  ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeIdempotencyInProgress ErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	ErrorCodeRequestTooLarge       ErrorCode = "REQUEST_TOO_LARGE"
+
+	ErrorCodeInvalidWebhook ErrorCode = "INVALID_WEBHOOK"
+
+	ErrorCodePRClosed         ErrorCode = "PR_CLOSED"
+	ErrorCodeInvalidSignature ErrorCode = "INVALID_SIGNATURE"
+	ErrorCodeInvalidPayload   ErrorCode = "INVALID_PAYLOAD"
+
+	ErrorCodeInvalidPreferences ErrorCode = "INVALID_PREFERENCES"
+	ErrorCodeInvalidSLA         ErrorCode = "INVALID_SLA"
+
+	ErrorCodeInvalidStatsFilter ErrorCode = "INVALID_STATS_FILTER"
+
+	ErrorCodeInvalidAbsence ErrorCode = "INVALID_ABSENCE"
+
+	ErrorCodeInvalidExportFormat ErrorCode = "INVALID_EXPORT_FORMAT"
 )
 
 type ErrorResponse struct {
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type StreamEventDTO struct {
+	ID                 int64     `json:"id"`
+	Type               string    `json:"type"`
+	OccurredAt         time.Time `json:"occurred_at"`
+	PullRequestID      string    `json:"pull_request_id"`
+	PullRequestName    string    `json:"pull_request_name"`
+	AuthorID           string    `json:"author_id"`
+	TeamName           string    `json:"team_name,omitempty"`
+	ActorID            string    `json:"actor_id,omitempty"`
+	ReviewerID         string    `json:"reviewer_id,omitempty"`
+	PreviousReviewerID string    `json:"previous_reviewer_id,omitempty"`
+}
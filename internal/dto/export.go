@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/export"
+)
+
+// StatsTables lays /stats out as spreadsheets, one per list of the JSON
+// response and in the same order.
+func StatsTables(s *domain.Stats) []export.Table {
+	return []export.Table{
+		{
+			Name:    "by_user",
+			Columns: []string{"user_id", "assignments"},
+			Len:     len(s.ByUser),
+			Row: func(i int) []any {
+				u := s.ByUser[i]
+				return []any{u.UserID, u.Assignments}
+			},
+		},
+		{
+			Name:    "by_pr",
+			Columns: []string{"pull_request_id", "reviewers"},
+			Len:     len(s.ByPR),
+			Row: func(i int) []any {
+				p := s.ByPR[i]
+				return []any{p.PRID, p.Reviewers}
+			},
+		},
+		{
+			Name:    "by_team",
+			Columns: []string{"team_name", "pull_requests", "open", "merged", "closed", "assignments"},
+			Len:     len(s.ByTeam),
+			Row: func(i int) []any {
+				t := s.ByTeam[i]
+				return []any{t.TeamName, t.PullRequests, t.Open, t.Merged, t.Closed, t.Assignments}
+			},
+		},
+	}
+}
+
+// PullRequestsTable lays a list of pull requests out as a spreadsheet with
+// the fields of PullRequestShortDTO.
+func PullRequestsTable(prs []domain.PullRequest) export.Table {
+	return export.Table{
+		Name:    "pull_requests",
+		Columns: []string{"pull_request_id", "pull_request_name", "author_id", "status"},
+		Len:     len(prs),
+		Row: func(i int) []any {
+			pr := ToPullRequestShortDTO(prs[i])
+			return []any{pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status}
+		},
+	}
+}
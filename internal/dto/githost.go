@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+const (
+	GitHostResultApplied = "applied"
+	GitHostResultIgnored = "ignored"
+)
+
+type GitHostWebhookResponse struct {
+	Result string          `json:"result"`
+	PR     *PullRequestDTO `json:"pr,omitempty"`
+}
+
+type ReviewerSyncJobDTO struct {
+	ID              int64      `json:"id"`
+	AddReviewers    []string   `json:"add_reviewers"`
+	RemoveReviewers []string   `json:"remove_reviewers"`
+	Status          string     `json:"status"`
+	Attempts        int        `json:"attempts"`
+	NextAttemptAt   *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt   *time.Time `json:"last_attempt_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type ReviewerSyncResponse struct {
+	PullRequestID string               `json:"pull_request_id"`
+	Jobs          []ReviewerSyncJobDTO `json:"jobs"`
+}
@@ -1,6 +1,10 @@
 package dto
 
 import (
+	"fmt"
+	"math"
+	"time"
+
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 )
 
@@ -9,7 +13,57 @@ func ToUserDTO(u *domain.User) UserDTO {
 		UserID:   u.ID,
 		Username: u.Username,
 		TeamName: u.TeamName,
+		Teams:    nonNil(u.Teams),
 		IsActive: u.IsActive,
+
+		Email:            u.Email,
+		DisplayName:      u.DisplayName,
+		ExternalAccounts: nonNilMap(u.ExternalAccounts),
+	}
+}
+
+func ToTeamMemberDTO(u *domain.User, teamName string) TeamMemberDTO {
+	return TeamMemberDTO{
+		UserID:           u.ID,
+		Username:         u.Username,
+		IsActive:         u.IsActive,
+		IsPrimary:        u.TeamName == teamName,
+		Email:            &u.Email,
+		DisplayName:      &u.DisplayName,
+		ExternalAccounts: u.ExternalAccounts,
+	}
+}
+
+func ToUserUpsert(m TeamMemberDTO, teamName string) domain.UserUpsert {
+	return domain.UserUpsert{
+		ID:               m.UserID,
+		Username:         m.Username,
+		TeamName:         teamName,
+		IsActive:         m.IsActive,
+		IsPrimary:        m.IsPrimary,
+		Email:            m.Email,
+		DisplayName:      m.DisplayName,
+		ExternalAccounts: m.ExternalAccounts,
+	}
+}
+
+func UpsertUserRequestToDomain(req UpsertUserRequest) domain.UserUpsert {
+	return domain.UserUpsert{
+		ID:               req.UserID,
+		Username:         req.Username,
+		TeamName:         req.TeamName,
+		IsActive:         req.IsActive,
+		IsPrimary:        req.IsPrimary,
+		Email:            req.Email,
+		DisplayName:      req.DisplayName,
+		ExternalAccounts: req.ExternalAccounts,
+	}
+}
+
+func ToUserListItemDTO(item *domain.UserListItem) UserListItemDTO {
+	return UserListItemDTO{
+		UserDTO:     ToUserDTO(&item.User),
+		OpenReviews: item.OpenReviews,
 	}
 }
 
@@ -18,13 +72,29 @@ func ToPullRequestDTO(pr *domain.PullRequest) PullRequestDTO {
 		PullRequestID:     pr.ID,
 		PullRequestName:   pr.Name,
 		AuthorID:          pr.AuthorID,
+		TeamName:          pr.TeamName,
 		Status:            string(pr.Status),
 		AssignedReviewers: pr.AssignedReviewers,
+		Reviews:           toReviewsDTO(pr.Verdicts),
 		CreatedAt:         pr.CreatedAt,
 		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
+		Version:           pr.Version,
 	}
 }
 
+func toReviewsDTO(verdicts map[string]domain.ReviewVerdict) map[string]string {
+	if len(verdicts) == 0 {
+		return nil
+	}
+
+	reviews := make(map[string]string, len(verdicts))
+	for reviewerID, verdict := range verdicts {
+		reviews[reviewerID] = string(verdict)
+	}
+	return reviews
+}
+
 func ToPullRequestShortDTO(pr domain.PullRequest) PullRequestShortDTO {
 	return PullRequestShortDTO{
 		PullRequestID:   pr.ID,
@@ -33,3 +103,389 @@ func ToPullRequestShortDTO(pr domain.PullRequest) PullRequestShortDTO {
 		Status:          string(pr.Status),
 	}
 }
+
+func ToTeamSettingsDTO(t *domain.Team) TeamSettingsDTO {
+	return TeamSettingsDTO{
+		TeamName:       t.Name,
+		Description:    t.Description,
+		SlackChannelID: t.SlackChannelID,
+		Leads:          nonNil(t.Leads),
+		ReviewerPolicy: ReviewerPolicyDTO{
+			ReviewersCount: t.ReviewerPolicy.ReviewersCount,
+			ExcludeLeads:   t.ReviewerPolicy.ExcludeLeads,
+		},
+		ReviewSLA: ReviewSLADTO{
+			ResponseHours:   t.ReviewSLA.ResponseHours,
+			EscalationHours: t.ReviewSLA.EscalationHours,
+			Escalation:      string(t.ReviewSLA.Escalation),
+		},
+	}
+}
+
+func ToTeamSettingsPatch(req UpdateTeamSettingsRequest) domain.TeamSettingsPatch {
+	patch := domain.TeamSettingsPatch{
+		Description:    req.Description,
+		SlackChannelID: req.SlackChannelID,
+		Leads:          req.Leads,
+	}
+
+	if req.ReviewerPolicy != nil {
+		patch.ReviewersCount = req.ReviewerPolicy.ReviewersCount
+		patch.ExcludeLeads = req.ReviewerPolicy.ExcludeLeads
+	}
+
+	if req.ReviewSLA != nil {
+		patch.SLAResponseHours = req.ReviewSLA.ResponseHours
+		patch.SLAEscalationHours = req.ReviewSLA.EscalationHours
+		if req.ReviewSLA.Escalation != nil {
+			escalation := domain.SLAEscalation(*req.ReviewSLA.Escalation)
+			patch.SLAEscalation = &escalation
+		}
+	}
+
+	return patch
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+func ToUserErasureDTO(e *domain.UserErasure) UserErasureDTO {
+	return UserErasureDTO{
+		UserID:            e.UserID,
+		RequestedBy:       e.RequestedBy,
+		ReassignedReviews: e.ReassignedReviews,
+		RemovedReviews:    e.RemovedReviews,
+		ErasedAt:          e.ErasedAt,
+	}
+}
+
+func ToAuditEventDTO(e *domain.AuditEvent) AuditEventDTO {
+	return AuditEventDTO{
+		ID:         e.ID,
+		OccurredAt: e.OccurredAt,
+		ActorID:    e.ActorID,
+		Action:     string(e.Action),
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Before:     e.Before,
+		After:      e.After,
+	}
+}
+
+func ToPREventDTO(e *domain.PREvent) PREventDTO {
+	return PREventDTO{
+		ID:                 e.ID,
+		Type:               string(e.Type),
+		OccurredAt:         e.OccurredAt,
+		ActorID:            e.ActorID,
+		ReviewerID:         e.ReviewerID,
+		PreviousReviewerID: e.PreviousReviewerID,
+		Strategy:           e.Strategy,
+		Reason:             e.Reason,
+		Verdict:            string(e.Verdict),
+	}
+}
+
+func ToWebhookSubscriptionDTO(s *domain.WebhookSubscription) WebhookSubscriptionDTO {
+	eventTypes := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return WebhookSubscriptionDTO{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: eventTypes,
+		IsActive:   s.IsActive,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func ToWebhookDeliveryDTO(d *domain.WebhookDelivery) WebhookDeliveryDTO {
+	out := WebhookDeliveryDTO{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		RedeliveryOf:   d.RedeliveryOf,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == domain.DeliveryPending {
+		next := d.NextAttemptAt
+		out.NextAttemptAt = &next
+	}
+	return out
+}
+
+func ToReviewerSyncJobDTO(job *domain.ReviewerSyncJob) ReviewerSyncJobDTO {
+	out := ReviewerSyncJobDTO{
+		ID:              job.ID,
+		AddReviewers:    job.Add,
+		RemoveReviewers: job.Remove,
+		Status:          string(job.Status),
+		Attempts:        job.Attempts,
+		LastAttemptAt:   job.LastAttemptAt,
+		LastError:       job.LastError,
+		CreatedAt:       job.CreatedAt,
+	}
+	if out.AddReviewers == nil {
+		out.AddReviewers = []string{}
+	}
+	if out.RemoveReviewers == nil {
+		out.RemoveReviewers = []string{}
+	}
+	if job.Status == domain.ReviewerSyncPending {
+		next := job.NextAttemptAt
+		out.NextAttemptAt = &next
+	}
+	return out
+}
+
+func ToStreamEventDTO(e *domain.PRStreamEvent) StreamEventDTO {
+	return StreamEventDTO{
+		ID:                 e.ID,
+		Type:               string(e.Type),
+		OccurredAt:         e.OccurredAt,
+		PullRequestID:      e.PRID,
+		PullRequestName:    e.PRName,
+		AuthorID:           e.AuthorID,
+		TeamName:           e.TeamName,
+		ActorID:            e.ActorID,
+		ReviewerID:         e.ReviewerID,
+		PreviousReviewerID: e.PreviousReviewerID,
+	}
+}
+
+func ToNotificationPreferencesDTO(p *domain.NotificationPreferences) NotificationPreferencesDTO {
+	out := NotificationPreferencesDTO{
+		UserID:   p.UserID,
+		Channels: make([]string, 0, len(p.Channels)),
+		Language: p.Language,
+		Mode:     string(p.Mode),
+		Timezone: p.Timezone,
+	}
+	for _, c := range p.Channels {
+		out.Channels = append(out.Channels, string(c))
+	}
+	if p.QuietHours != nil {
+		out.QuietHours = &QuietHoursDTO{
+			Start: domain.FormatClock(p.QuietHours.Start),
+			End:   domain.FormatClock(p.QuietHours.End),
+		}
+	}
+	if !p.UpdatedAt.IsZero() {
+		updatedAt := p.UpdatedAt
+		out.UpdatedAt = &updatedAt
+	}
+	return out
+}
+
+func UpdateNotificationPreferencesRequestToDomain(
+	req UpdateNotificationPreferencesRequest,
+) (*domain.NotificationPreferences, error) {
+	p := domain.DefaultNotificationPreferences(req.UserID)
+
+	if req.Channels != nil {
+		p.Channels = make([]domain.NotificationChannel, 0, len(req.Channels))
+		for _, c := range req.Channels {
+			p.Channels = append(p.Channels, domain.NotificationChannel(c))
+		}
+	}
+	if req.Language != "" {
+		p.Language = req.Language
+	}
+	if req.Mode != "" {
+		p.Mode = domain.NotificationMode(req.Mode)
+	}
+	if req.Timezone != "" {
+		p.Timezone = req.Timezone
+	}
+	if req.QuietHours != nil {
+		start, err := domain.ParseClock(req.QuietHours.Start)
+		if err != nil {
+			return nil, fmt.Errorf("%w: quiet hours: %v", domain.ErrInvalidPreferences, err)
+		}
+		end, err := domain.ParseClock(req.QuietHours.End)
+		if err != nil {
+			return nil, fmt.Errorf("%w: quiet hours: %v", domain.ErrInvalidPreferences, err)
+		}
+		p.QuietHours = &domain.QuietHours{Start: start, End: end}
+	}
+
+	return p, nil
+}
+
+func ToOverdueReviewDTO(r *domain.PendingReview) OverdueReviewDTO {
+	out := OverdueReviewDTO{
+		PullRequestID:   r.PRID,
+		PullRequestName: r.PRName,
+		TeamName:        r.TeamName,
+		ReviewerID:      r.ReviewerID,
+		AssignedAt:      r.AssignedAt,
+		DueAt:           r.DueAt(),
+		EscalatedAt:     r.EscalatedAt,
+	}
+	if r.BreachedAt != nil {
+		out.BreachedAt = *r.BreachedAt
+	}
+	return out
+}
+
+func ToStalePRDTO(pr *domain.StalePR) StalePRDTO {
+	out := StalePRDTO{
+		PullRequestID:    pr.PRID,
+		PullRequestName:  pr.PRName,
+		AuthorID:         pr.AuthorID,
+		TeamName:         pr.TeamName,
+		CreatedAt:        pr.CreatedAt,
+		LastActivityAt:   pr.LastActivityAt,
+		PendingReviewers: make([]string, 0, len(pr.PendingReviewers)),
+		Reasons:          make([]string, 0, len(pr.Reasons)),
+	}
+	out.PendingReviewers = append(out.PendingReviewers, pr.PendingReviewers...)
+	for _, r := range pr.Reasons {
+		out.Reasons = append(out.Reasons, string(r))
+	}
+	return out
+}
+
+func ToDurationStatDTO(s domain.DurationStat) DurationStatDTO {
+	return DurationStatDTO{
+		Count:         s.Count,
+		MedianSeconds: int64(s.Median / time.Second),
+		P90Seconds:    int64(s.P90 / time.Second),
+	}
+}
+
+func ToCycleTimeResponse(ct *domain.CycleTime) CycleTimeResponse {
+	out := CycleTimeResponse{
+		AsOf:       ct.AsOf,
+		ByTeam:     make([]TeamCycleTimeDTO, 0, len(ct.ByTeam)),
+		ByReviewer: make([]ReviewerCycleTimeDTO, 0, len(ct.ByReviewer)),
+	}
+
+	for _, t := range ct.ByTeam {
+		team := TeamCycleTimeDTO{
+			TeamName:       t.TeamName,
+			TimeToMerge:    ToDurationStatDTO(t.TimeToMerge),
+			TimeToApproval: ToDurationStatDTO(t.TimeToApproval),
+			OpenAge:        make([]AgeBucketDTO, 0, len(t.OpenAge)),
+		}
+		for _, b := range t.OpenAge {
+			bucket := AgeBucketDTO{FromHours: int(b.From / time.Hour), Count: b.Count}
+			if b.To > 0 {
+				to := int(b.To / time.Hour)
+				bucket.ToHours = &to
+			}
+			team.OpenAge = append(team.OpenAge, bucket)
+		}
+		out.ByTeam = append(out.ByTeam, team)
+	}
+
+	for _, r := range ct.ByReviewer {
+		out.ByReviewer = append(out.ByReviewer, ReviewerCycleTimeDTO{
+			UserID:         r.UserID,
+			TimeToApproval: ToDurationStatDTO(r.TimeToApproval),
+		})
+	}
+
+	return out
+}
+
+func ToAbsenceDTO(a *domain.Absence) AbsenceDTO {
+	return AbsenceDTO{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		From:      a.From,
+		To:        a.To,
+		Reason:    a.Reason,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func ToFairnessResponse(f *domain.Fairness) FairnessResponse {
+	out := FairnessResponse{
+		From:  f.From,
+		To:    f.To,
+		Teams: make([]TeamFairnessDTO, 0, len(f.Teams)),
+	}
+
+	for _, t := range f.Teams {
+		team := TeamFairnessDTO{
+			TeamName:    t.TeamName,
+			Assignments: t.Assignments,
+			Gini:        round(t.Gini),
+			Members:     make([]FairnessMemberDTO, 0, len(t.Members)),
+			Overloaded:  append([]string{}, t.Overloaded...),
+			Underloaded: append([]string{}, t.Underloaded...),
+		}
+		for _, m := range t.Members {
+			team.Members = append(team.Members, FairnessMemberDTO{
+				UserID:         m.UserID,
+				Username:       m.Username,
+				Assignments:    m.Assignments,
+				AvailableHours: round(m.Available.Hours()),
+				Share:          round(m.Share),
+				ExpectedShare:  round(m.ExpectedShare),
+				Load:           round(m.Load),
+			})
+		}
+		out.Teams = append(out.Teams, team)
+	}
+
+	return out
+}
+
+func ToStatsHistoryResponse(h *domain.StatsHistory) StatsHistoryResponse {
+	out := StatsHistoryResponse{
+		From:  h.From,
+		To:    h.To,
+		Days:  make([]string, 0, len(h.Days)),
+		Teams: make([]TeamHistoryDTO, 0, len(h.Teams)),
+		Users: make([]UserHistoryDTO, 0, len(h.Users)),
+	}
+
+	for _, d := range h.Days {
+		out.Days = append(out.Days, d.Format(time.DateOnly))
+	}
+	for _, t := range h.Teams {
+		out.Teams = append(out.Teams, TeamHistoryDTO{
+			TeamName:    t.TeamName,
+			Open:        t.Open,
+			Opened:      t.Opened,
+			Merged:      t.Merged,
+			Closed:      t.Closed,
+			Assignments: t.Assignments,
+		})
+	}
+	for _, u := range h.Users {
+		out.Users = append(out.Users, UserHistoryDTO{
+			UserID:      u.UserID,
+			Assignments: u.Assignments,
+			OpenReviews: u.OpenReviews,
+		})
+	}
+
+	return out
+}
+
+// round keeps four decimal places of a ratio.
+func round(v float64) float64 {
+	return math.Round(v*1e4) / 1e4
+}
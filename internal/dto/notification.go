@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type QuietHoursDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type NotificationPreferencesDTO struct {
+	UserID     string         `json:"user_id"`
+	Channels   []string       `json:"channels"`
+	Language   string         `json:"language"`
+	Mode       string         `json:"mode"`
+	QuietHours *QuietHoursDTO `json:"quiet_hours"`
+	Timezone   string         `json:"timezone"`
+	UpdatedAt  *time.Time     `json:"updated_at,omitempty"`
+}
+
+// UpdateNotificationPreferencesRequest replaces the preferences of a user.
+// Omitted fields take their default values.
+type UpdateNotificationPreferencesRequest struct {
+	UserID     string         `json:"user_id"`
+	Channels   []string       `json:"channels"`
+	Language   string         `json:"language"`
+	Mode       string         `json:"mode"`
+	QuietHours *QuietHoursDTO `json:"quiet_hours"`
+	Timezone   string         `json:"timezone"`
+}
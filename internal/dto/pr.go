@@ -3,13 +3,17 @@ package dto
 import "time"
 
 type PullRequestDTO struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         *time.Time `json:"createdAt"`
-	MergedAt          *time.Time `json:"mergedAt"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	AuthorID          string            `json:"author_id"`
+	TeamName          string            `json:"team_name,omitempty"`
+	Status            string            `json:"status"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	Reviews           map[string]string `json:"reviews,omitempty"`
+	CreatedAt         *time.Time        `json:"createdAt"`
+	MergedAt          *time.Time        `json:"mergedAt"`
+	ClosedAt          *time.Time        `json:"closedAt,omitempty"`
+	Version           int64             `json:"version"`
 }
 
 type PullRequestShortDTO struct {
@@ -23,6 +27,7 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	TeamName        string `json:"team_name"`
 }
 
 type CreatePRResponse struct {
@@ -46,3 +51,35 @@ type ReassignReviewerResponse struct {
 	PR         PullRequestDTO `json:"pr"`
 	ReplacedBy string         `json:"replaced_by"`
 }
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Verdict       string `json:"verdict"`
+}
+
+type SubmitReviewResponse struct {
+	PR PullRequestDTO `json:"pr"`
+}
+
+type PreconditionFailedResponse struct {
+	Error ErrorObject    `json:"error"`
+	PR    PullRequestDTO `json:"pr"`
+}
+
+type PREventDTO struct {
+	ID                 int64     `json:"id"`
+	Type               string    `json:"type"`
+	OccurredAt         time.Time `json:"occurred_at"`
+	ActorID            string    `json:"actor_id,omitempty"`
+	ReviewerID         string    `json:"reviewer_id,omitempty"`
+	PreviousReviewerID string    `json:"previous_reviewer_id,omitempty"`
+	Strategy           string    `json:"strategy,omitempty"`
+	Reason             string    `json:"reason,omitempty"`
+	Verdict            string    `json:"verdict,omitempty"`
+}
+
+type TimelineResponse struct {
+	PullRequestID string       `json:"pull_request_id"`
+	Events        []PREventDTO `json:"events"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type OverdueReviewDTO struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	TeamName        string     `json:"team_name"`
+	ReviewerID      string     `json:"reviewer_id"`
+	AssignedAt      time.Time  `json:"assigned_at"`
+	DueAt           time.Time  `json:"due_at"`
+	BreachedAt      time.Time  `json:"breached_at"`
+	EscalatedAt     *time.Time `json:"escalated_at,omitempty"`
+}
+
+type OverdueReviewsResponse struct {
+	Reviews []OverdueReviewDTO `json:"reviews"`
+}
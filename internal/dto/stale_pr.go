@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type StalePRDTO struct {
+	PullRequestID    string    `json:"pull_request_id"`
+	PullRequestName  string    `json:"pull_request_name"`
+	AuthorID         string    `json:"author_id"`
+	TeamName         string    `json:"team_name"`
+	CreatedAt        time.Time `json:"created_at"`
+	LastActivityAt   time.Time `json:"last_activity_at"`
+	PendingReviewers []string  `json:"pending_reviewers"`
+	Reasons          []string  `json:"reasons"`
+}
+
+type StalePRsResponse struct {
+	PullRequests []StalePRDTO `json:"pull_requests"`
+}
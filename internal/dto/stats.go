@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type UserStatsDTO struct {
 	UserID      string `json:"user_id"`
 	Assignments int    `json:"assignments"`
@@ -10,7 +12,101 @@ type PRStatsDTO struct {
 	Reviewers     int    `json:"reviewers"`
 }
 
+type TeamStatsDTO struct {
+	TeamName     string `json:"team_name"`
+	PullRequests int    `json:"pull_requests"`
+	Open         int    `json:"open"`
+	Merged       int    `json:"merged"`
+	Closed       int    `json:"closed"`
+	Assignments  int    `json:"assignments"`
+}
+
 type StatsResponse struct {
-	ByUser []UserStatsDTO `json:"by_user"`
-	ByPR   []PRStatsDTO   `json:"by_pr"`
+	ByUser        []UserStatsDTO `json:"by_user"`
+	ByPR          []PRStatsDTO   `json:"by_pr"`
+	ByPRTruncated bool           `json:"by_pr_truncated"`
+	ByTeam        []TeamStatsDTO `json:"by_team"`
+}
+
+// DurationStatDTO reports durations in seconds.
+type DurationStatDTO struct {
+	Count         int   `json:"count"`
+	MedianSeconds int64 `json:"median_seconds"`
+	P90Seconds    int64 `json:"p90_seconds"`
+}
+
+// AgeBucketDTO counts the open pull requests aged from FromHours up to
+// ToHours; ToHours is null for the last bucket.
+type AgeBucketDTO struct {
+	FromHours int  `json:"from_hours"`
+	ToHours   *int `json:"to_hours"`
+	Count     int  `json:"count"`
+}
+
+type TeamCycleTimeDTO struct {
+	TeamName       string          `json:"team_name"`
+	TimeToMerge    DurationStatDTO `json:"time_to_merge"`
+	TimeToApproval DurationStatDTO `json:"time_to_approval"`
+	OpenAge        []AgeBucketDTO  `json:"open_age"`
+}
+
+type ReviewerCycleTimeDTO struct {
+	UserID         string          `json:"user_id"`
+	TimeToApproval DurationStatDTO `json:"time_to_approval"`
+}
+
+type CycleTimeResponse struct {
+	AsOf       time.Time              `json:"as_of"`
+	ByTeam     []TeamCycleTimeDTO     `json:"by_team"`
+	ByReviewer []ReviewerCycleTimeDTO `json:"by_reviewer"`
+}
+
+type FairnessMemberDTO struct {
+	UserID         string  `json:"user_id"`
+	Username       string  `json:"username"`
+	Assignments    int     `json:"assignments"`
+	AvailableHours float64 `json:"available_hours"`
+	Share          float64 `json:"share"`
+	ExpectedShare  float64 `json:"expected_share"`
+	Load           float64 `json:"load"`
+}
+
+type TeamFairnessDTO struct {
+	TeamName    string              `json:"team_name"`
+	Assignments int                 `json:"assignments"`
+	Gini        float64             `json:"gini"`
+	Members     []FairnessMemberDTO `json:"members"`
+	Overloaded  []string            `json:"overloaded"`
+	Underloaded []string            `json:"underloaded"`
+}
+
+type FairnessResponse struct {
+	From  time.Time         `json:"from"`
+	To    time.Time         `json:"to"`
+	Teams []TeamFairnessDTO `json:"teams"`
+}
+
+// TeamHistoryDTO and UserHistoryDTO hold a value per day of
+// StatsHistoryResponse.Days.
+type TeamHistoryDTO struct {
+	TeamName    string `json:"team_name"`
+	Open        []int  `json:"open"`
+	Opened      []int  `json:"opened"`
+	Merged      []int  `json:"merged"`
+	Closed      []int  `json:"closed"`
+	Assignments []int  `json:"assignments"`
+}
+
+type UserHistoryDTO struct {
+	UserID      string `json:"user_id"`
+	Assignments []int  `json:"assignments"`
+	OpenReviews []int  `json:"open_reviews"`
+}
+
+type StatsHistoryResponse struct {
+	From  time.Time        `json:"from"`
+	To    time.Time        `json:"to"`
+	Days  []string         `json:"days"`
+	Teams []TeamHistoryDTO `json:"teams"`
+	Users []UserHistoryDTO `json:"users"`
 }
@@ -6,9 +6,13 @@ type TeamDTO struct {
 }
 
 type TeamMemberDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID           string            `json:"user_id"`
+	Username         string            `json:"username"`
+	IsActive         bool              `json:"is_active"`
+	IsPrimary        bool              `json:"is_primary"`
+	Email            *string           `json:"email,omitempty"`
+	DisplayName      *string           `json:"display_name,omitempty"`
+	ExternalAccounts map[string]string `json:"external_accounts,omitempty"`
 }
 
 type AddTeamRequest struct {
@@ -24,3 +28,47 @@ type GetTeamResponse struct {
 	TeamName string          `json:"team_name"`
 	Members  []TeamMemberDTO `json:"members"`
 }
+
+type ReviewerPolicyDTO struct {
+	ReviewersCount int  `json:"reviewers_count"`
+	ExcludeLeads   bool `json:"exclude_leads"`
+}
+
+type ReviewSLADTO struct {
+	ResponseHours   int    `json:"response_hours"`
+	EscalationHours int    `json:"escalation_hours"`
+	Escalation      string `json:"escalation"`
+}
+
+type TeamSettingsDTO struct {
+	TeamName       string            `json:"team_name"`
+	Description    string            `json:"description"`
+	SlackChannelID string            `json:"slack_channel_id"`
+	Leads          []string          `json:"leads"`
+	ReviewerPolicy ReviewerPolicyDTO `json:"reviewer_policy"`
+	ReviewSLA      ReviewSLADTO      `json:"review_sla"`
+}
+
+type ReviewerPolicyPatchDTO struct {
+	ReviewersCount *int  `json:"reviewers_count"`
+	ExcludeLeads   *bool `json:"exclude_leads"`
+}
+
+type ReviewSLAPatchDTO struct {
+	ResponseHours   *int    `json:"response_hours"`
+	EscalationHours *int    `json:"escalation_hours"`
+	Escalation      *string `json:"escalation"`
+}
+
+type UpdateTeamSettingsRequest struct {
+	TeamName       string                  `json:"team_name"`
+	Description    *string                 `json:"description"`
+	SlackChannelID *string                 `json:"slack_channel_id"`
+	Leads          *[]string               `json:"leads"`
+	ReviewerPolicy *ReviewerPolicyPatchDTO `json:"reviewer_policy"`
+	ReviewSLA      *ReviewSLAPatchDTO      `json:"review_sla"`
+}
+
+type UpdateTeamSettingsResponse struct {
+	Settings TeamSettingsDTO `json:"settings"`
+}
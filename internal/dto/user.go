@@ -1,10 +1,31 @@
 package dto
 
+import "time"
+
 type UserDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID           string            `json:"user_id"`
+	Username         string            `json:"username"`
+	TeamName         string            `json:"team_name"`
+	Teams            []string          `json:"teams"`
+	IsActive         bool              `json:"is_active"`
+	Email            string            `json:"email"`
+	DisplayName      string            `json:"display_name"`
+	ExternalAccounts map[string]string `json:"external_accounts"`
+}
+
+type UpsertUserRequest struct {
+	UserID           string            `json:"user_id"`
+	Username         string            `json:"username"`
+	TeamName         string            `json:"team_name"`
+	IsActive         bool              `json:"is_active"`
+	IsPrimary        bool              `json:"is_primary"`
+	Email            *string           `json:"email"`
+	DisplayName      *string           `json:"display_name"`
+	ExternalAccounts map[string]string `json:"external_accounts"`
+}
+
+type UpsertUserResponse struct {
+	User UserDTO `json:"user"`
 }
 
 type SetIsActiveUserRequest struct {
@@ -20,3 +41,30 @@ type GetReviewUserResponse struct {
 	UserID       string                `json:"user_id"`
 	PullRequests []PullRequestShortDTO `json:"pull_requests"`
 }
+
+type UserListItemDTO struct {
+	UserDTO
+	OpenReviews int `json:"open_reviews"`
+}
+
+type ListUsersResponse struct {
+	Users      []UserListItemDTO `json:"users"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	Total      int               `json:"total"`
+}
+
+type EraseUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type UserErasureDTO struct {
+	UserID            string    `json:"user_id"`
+	RequestedBy       string    `json:"requested_by,omitempty"`
+	ReassignedReviews int       `json:"reassigned_reviews"`
+	RemovedReviews    int       `json:"removed_reviews"`
+	ErasedAt          time.Time `json:"erased_at"`
+}
+
+type EraseUserResponse struct {
+	Erasure UserErasureDTO `json:"erasure"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+type WebhookSubscriptionDTO struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateWebhookResponse struct {
+	Webhook WebhookSubscriptionDTO `json:"webhook"`
+	// Secret is only returned on creation.
+	Secret string `json:"secret"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookSubscriptionDTO `json:"webhooks"`
+}
+
+type WebhookDeliveryDTO struct {
+	ID             int64      `json:"id"`
+	SubscriptionID int64      `json:"subscription_id"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	RedeliveryOf   *int64     `json:"redelivery_of,omitempty"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ListDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+type RedeliverRequest struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+type RedeliverResponse struct {
+	Delivery WebhookDeliveryDTO `json:"delivery"`
+}
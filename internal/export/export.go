@@ -0,0 +1,169 @@
+// Package export writes tables as CSV and XLSX spreadsheets.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is the format of a response.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json"
+	}
+}
+
+// Table is a spreadsheet. Columns are keys of the titles, which are the
+// names of the same fields in JSON responses; the columns are written in
+// their order. Row returns the values of row i, strings or ints.
+type Table struct {
+	Name    string
+	Columns []string
+	Len     int
+	Row     func(i int) []any
+}
+
+// flushEvery is the number of CSV rows written between flushes.
+const flushEvery = 1000
+
+// utf8BOM lets spreadsheet applications tell the encoding of a CSV file.
+const utf8BOM = "\ufeff"
+
+// WriteCSV writes the table as CSV with a header row in lang, flushing w
+// every flushEvery rows if it is a flusher. Text cells that a spreadsheet
+// application would take for a formula are written with a leading quote.
+func WriteCSV(w io.Writer, t Table, lang string) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Titles(lang, t.Columns)); err != nil {
+		return err
+	}
+
+	record := make([]string, len(t.Columns))
+	for i := 0; i < t.Len; i++ {
+		for j, v := range t.Row(i) {
+			record[j] = formatValue(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+
+		if (i+1)%flushEvery == 0 {
+			if err := flush(w, cw); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush(w, cw)
+}
+
+func flush(w io.Writer, cw *csv.Writer) error {
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// WriteXLSX writes the tables as the sheets of a workbook, each with a
+// header row in lang.
+func WriteXLSX(w io.Writer, tables []Table, lang string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	for i, t := range tables {
+		sheet := Title(lang, t.Name)
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+
+		sw, err := f.NewStreamWriter(sheet)
+		if err != nil {
+			return err
+		}
+
+		header := make([]any, len(t.Columns))
+		for j, title := range Titles(lang, t.Columns) {
+			header[j] = excelize.Cell{StyleID: bold, Value: title}
+		}
+		if err := sw.SetRow("A1", header, excelize.RowOpts{StyleID: bold}); err != nil {
+			return err
+		}
+
+		for r := 0; r < t.Len; r++ {
+			cell, err := excelize.CoordinatesToCellName(1, r+2)
+			if err != nil {
+				return err
+			}
+			if err := sw.SetRow(cell, t.Row(r)); err != nil {
+				return err
+			}
+		}
+
+		if err := sw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return f.Write(w)
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return escapeFormula(v)
+	default:
+		return escapeFormula(fmt.Sprint(v))
+	}
+}
+
+// formulaPrefixes start a cell that Excel and LibreOffice evaluate as a
+// formula when they open a CSV file.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula keeps text such as pull request names, which come from git
+// hosts, from being evaluated as a formula. XLSX cells are typed and need no
+// escaping.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
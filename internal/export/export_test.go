@@ -0,0 +1,115 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/export"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func teams() export.Table {
+	rows := [][]any{
+		{"backend", 3},
+		{`web, "mobile"`, 1},
+	}
+	return export.Table{
+		Name:    "by_team",
+		Columns: []string{"team_name", "assignments"},
+		Len:     len(rows),
+		Row:     func(i int) []any { return rows[i] },
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteCSV(&buf, teams(), domain.LanguageRU))
+
+	require.Equal(t, "\ufeffКоманда,Назначения\nbackend,3\n\"web, \"\"mobile\"\"\",1\n", buf.String())
+}
+
+func TestWriteCSV_EscapesFormulas(t *testing.T) {
+	rows := []string{"=HYPERLINK(\"http://evil\")", "+1", "-1", "@SUM(A1)", "\tx", "\rx", "a=b"}
+	table := export.Table{
+		Name:    "pull_requests",
+		Columns: []string{"pull_request_name"},
+		Len:     len(rows),
+		Row:     func(i int) []any { return []any{rows[i]} },
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteCSV(&buf, table, domain.LanguageEN))
+
+	require.Equal(t,
+		"\ufeffPull request\n\"'=HYPERLINK(\"\"http://evil\"\")\"\n'+1\n'-1\n'@SUM(A1)\n'\tx\n\"'\rx\"\na=b\n",
+		buf.String(),
+	)
+}
+
+func TestWriteXLSX(t *testing.T) {
+	users := export.Table{
+		Name:    "by_user",
+		Columns: []string{"user_id", "assignments"},
+		Len:     1,
+		Row:     func(int) []any { return []any{"u1", 2} },
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteXLSX(&buf, []export.Table{users, teams()}, domain.LanguageEN))
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.Equal(t, []string{"By user", "By team"}, f.GetSheetList())
+
+	rows, err := f.GetRows("By team")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"Team", "Assignments"},
+		{"backend", "3"},
+		{`web, "mobile"`, "1"},
+	}, rows)
+
+	rows, err = f.GetRows("By user")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"User ID", "Assignments"}, {"u1", "2"}}, rows)
+}
+
+func TestParseLanguage(t *testing.T) {
+	for tags, want := range map[string]string{
+		"":                        domain.LanguageEN,
+		"ru":                      domain.LanguageRU,
+		"ru-RU,ru;q=0.9,en;q=0.8": domain.LanguageRU,
+		"de-DE, EN-us;q=0.5":      domain.LanguageEN,
+		"fr":                      domain.LanguageEN,
+	} {
+		require.Equal(t, want, export.ParseLanguage(tags), tags)
+	}
+}
+
+func TestWriteXLSX_FormulasStayText(t *testing.T) {
+	table := export.Table{
+		Name:    "pull_requests",
+		Columns: []string{"pull_request_name"},
+		Len:     1,
+		Row:     func(int) []any { return []any{"=1+1"} },
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteXLSX(&buf, []export.Table{table}, domain.LanguageEN))
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	formula, err := f.GetCellFormula("Pull requests", "A2")
+	require.NoError(t, err)
+	require.Empty(t, formula)
+
+	value, err := f.GetCellValue("Pull requests", "A2")
+	require.NoError(t, err)
+	require.Equal(t, "=1+1", value)
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+// titles hold the column titles and sheet names by language.
+var titles = map[string]map[string]string{
+	domain.LanguageEN: {
+		"by_user":           "By user",
+		"by_pr":             "By pull request",
+		"by_team":           "By team",
+		"pull_requests":     "Pull requests",
+		"user_id":           "User ID",
+		"team_name":         "Team",
+		"pull_request_id":   "Pull request ID",
+		"pull_request_name": "Pull request",
+		"author_id":         "Author ID",
+		"status":            "Status",
+		"reviewers":         "Reviewers",
+		"assignments":       "Assignments",
+		"open":              "Open",
+		"merged":            "Merged",
+		"closed":            "Closed",
+	},
+	domain.LanguageRU: {
+		"by_user":           "По пользователям",
+		"by_pr":             "По PR",
+		"by_team":           "По командам",
+		"pull_requests":     "PR",
+		"user_id":           "ID пользователя",
+		"team_name":         "Команда",
+		"pull_request_id":   "ID PR",
+		"pull_request_name": "PR",
+		"author_id":         "ID автора",
+		"status":            "Статус",
+		"reviewers":         "Ревьюверы",
+		"assignments":       "Назначения",
+		"open":              "Открыто",
+		"merged":            "Смёржено",
+		"closed":            "Закрыто",
+	},
+}
+
+// ParseLanguage returns the first known language of a list of language
+// tags such as the Accept-Language header, ignoring weights, or English.
+func ParseLanguage(tags string) string {
+	for _, tag := range strings.Split(tags, ",") {
+		tag, _, _ = strings.Cut(tag, ";")
+		tag, _, _ = strings.Cut(strings.TrimSpace(tag), "-")
+		if lang := strings.ToLower(tag); domain.IsKnownLanguage(lang) {
+			return lang
+		}
+	}
+	return domain.LanguageEN
+}
+
+// Title returns the title of a column or sheet key in lang, the key itself
+// when it has none.
+func Title(lang string, key string) string {
+	if t, ok := titles[lang][key]; ok {
+		return t
+	}
+	return key
+}
+
+// Titles returns the titles of the keys in lang.
+func Titles(lang string, keys []string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = Title(lang, k)
+	}
+	return out
+}
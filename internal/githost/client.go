@@ -0,0 +1,93 @@
+package githost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+// Client requests and withdraws reviews on a pull request of a Git hosting
+// provider. Logins are the provider's user names.
+type Client interface {
+	RequestReviewers(ctx context.Context, src domain.PRSource, logins []string) error
+	RemoveReviewers(ctx context.Context, src domain.PRSource, logins []string) error
+}
+
+// APIError is an unsuccessful response of a provider API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("git host api responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsPermanent reports whether retrying the request can not help, such as
+// when the token is rejected or the reviewer can not be added to the
+// repository. Network errors, rate limiting and server errors are retried.
+func IsPermanent(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusTooManyRequests
+}
+
+const maxErrorBody = 500
+
+// doJSON sends in as JSON and decodes the response into out. Either may be
+// nil.
+func doJSON(
+	ctx context.Context,
+	client *http.Client,
+	method string,
+	url string,
+	header http.Header,
+	in any,
+	out any,
+) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &APIError{StatusCode: resp.StatusCode, Message: string(msg)}
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
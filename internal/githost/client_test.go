@@ -0,0 +1,102 @@
+package githost_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubClient_RequestReviewers(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		auth   string
+		body   string
+	}
+	var got []request
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, request{r.Method, r.URL.Path, r.Header.Get("Authorization"), string(body)})
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	client := githost.NewGitHubClient(srv.URL, "gh-token", srv.Client())
+	src := domain.PRSource{Provider: domain.ProviderGitHub, Repository: "acme/hello-world", Number: 1347}
+
+	require.NoError(t, client.RemoveReviewers(context.Background(), src, []string{"hubot"}))
+	require.NoError(t, client.RequestReviewers(context.Background(), src, []string{"octocat"}))
+
+	require.Equal(t, []request{
+		{http.MethodDelete, "/repos/acme/hello-world/pulls/1347/requested_reviewers", "Bearer gh-token", `{"reviewers":["hubot"]}`},
+		{http.MethodPost, "/repos/acme/hello-world/pulls/1347/requested_reviewers", "Bearer gh-token", `{"reviewers":["octocat"]}`},
+	}, got)
+}
+
+func TestGitHubClient_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Reviews may only be requested from collaborators."}`, http.StatusUnprocessableEntity)
+	}))
+	defer srv.Close()
+
+	client := githost.NewGitHubClient(srv.URL, "gh-token", srv.Client())
+	err := client.RequestReviewers(context.Background(), domain.PRSource{Repository: "acme/hello-world", Number: 1}, []string{"octocat"})
+
+	var apiErr *githost.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	require.True(t, githost.IsPermanent(err))
+}
+
+func TestGitLabClient_RequestReviewers(t *testing.T) {
+	var updated []int64
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "gl-token", r.Header.Get("PRIVATE-TOKEN"))
+		ids := map[string]int64{"root": 1, "maria": 7}
+		_ = json.NewEncoder(w).Encode([]map[string]any{{"id": ids[r.URL.Query().Get("username")]}})
+	})
+	mux.HandleFunc("GET /projects/gitlabhq%2Fgitlab-test/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"reviewers": []map[string]any{{"id": 1, "username": "root"}}})
+	})
+	mux.HandleFunc("PUT /projects/gitlabhq%2Fgitlab-test/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ReviewerIDs []int64 `json:"reviewer_ids"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		updated = body.ReviewerIDs
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := githost.NewGitLabClient(srv.URL, "gl-token", srv.Client())
+	src := domain.PRSource{Provider: domain.ProviderGitLab, Repository: "gitlabhq/gitlab-test", Number: 1}
+
+	require.NoError(t, client.RequestReviewers(context.Background(), src, []string{"maria"}))
+	require.Equal(t, []int64{1, 7}, updated)
+
+	updated = nil
+	require.NoError(t, client.RequestReviewers(context.Background(), src, []string{"root"}))
+	require.Nil(t, updated, "no update when the reviewer is already requested")
+
+	require.NoError(t, client.RemoveReviewers(context.Background(), src, []string{"root"}))
+	require.Equal(t, []int64{}, updated)
+}
+
+func TestIsPermanent(t *testing.T) {
+	require.True(t, githost.IsPermanent(&githost.APIError{StatusCode: http.StatusNotFound}))
+	require.False(t, githost.IsPermanent(&githost.APIError{StatusCode: http.StatusTooManyRequests}))
+	require.False(t, githost.IsPermanent(&githost.APIError{StatusCode: http.StatusBadGateway}))
+	require.False(t, githost.IsPermanent(errors.New("connection reset")))
+}
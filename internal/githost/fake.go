@@ -0,0 +1,79 @@
+package githost
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+// FakeClient is an in-process Client that keeps the requested reviewers of
+// every pull request in memory. It is meant for tests.
+type FakeClient struct {
+	mu        sync.Mutex
+	reviewers map[domain.PRSource][]string
+	err       error
+	calls     int
+}
+
+func NewFakeClient() *FakeClient {
+	return &FakeClient{reviewers: map[domain.PRSource][]string{}}
+}
+
+func (f *FakeClient) RequestReviewers(_ context.Context, src domain.PRSource, logins []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+
+	for _, login := range logins {
+		if !slices.Contains(f.reviewers[src], login) {
+			f.reviewers[src] = append(f.reviewers[src], login)
+		}
+	}
+	return nil
+}
+
+func (f *FakeClient) RemoveReviewers(_ context.Context, src domain.PRSource, logins []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+
+	f.reviewers[src] = slices.DeleteFunc(f.reviewers[src], func(login string) bool {
+		return slices.Contains(logins, login)
+	})
+	return nil
+}
+
+// Reviewers returns the logins currently requested to review src.
+func (f *FakeClient) Reviewers(src domain.PRSource) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.reviewers[src])
+}
+
+// Calls returns the number of calls made, including failed ones.
+func (f *FakeClient) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+// SetErr makes subsequent calls fail with err instead of being applied. A
+// nil err restores normal behaviour.
+func (f *FakeClient) SetErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
@@ -0,0 +1,34 @@
+// Package githost talks to Git hosting providers. It reads pull and merge
+// request webhooks, which are verified and reduced to domain.HostPREvent
+// (events the service does not act on are reported as nil), and requests
+// reviews on the provider through a Client.
+package githost
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/signature"
+)
+
+var ErrMalformedPayload = errors.New("malformed webhook payload")
+
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+
+	GitLabEventHeader = "X-Gitlab-Event"
+	GitLabTokenHeader = "X-Gitlab-Token"
+)
+
+// VerifyGitHub checks the X-Hub-Signature-256 header of a GitHub delivery.
+// An empty secret rejects every delivery.
+func VerifyGitHub(secret string, body []byte, sig string) bool {
+	return secret != "" && signature.Verify(secret, body, sig)
+}
+
+// VerifyGitLab checks the X-Gitlab-Token header of a GitLab delivery. An
+// empty token rejects every delivery.
+func VerifyGitLab(token string, got string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(got)) == 1
+}
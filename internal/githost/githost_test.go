@@ -0,0 +1,114 @@
+package githost_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return body
+}
+
+func TestParseGitHub(t *testing.T) {
+	source := domain.PRSource{
+		Provider:   domain.ProviderGitHub,
+		Repository: "acme/hello-world",
+		Number:     1347,
+		URL:        "https://github.com/acme/hello-world/pull/1347",
+	}
+
+	tests := []struct {
+		fixture string
+		action  domain.HostPRAction
+		sender  string
+	}{
+		{"github_opened.json", domain.HostPROpened, "octocat"},
+		{"github_closed.json", domain.HostPRClosed, "octocat"},
+		{"github_merged.json", domain.HostPRMerged, "hubot"},
+		{"github_reopened.json", domain.HostPRReopened, "octocat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			ev, err := githost.ParseGitHub("pull_request", fixture(t, tt.fixture))
+
+			require.NoError(t, err)
+			require.Equal(t, tt.action, ev.Action)
+			require.Equal(t, source, ev.Source)
+			require.Equal(t, "github:acme/hello-world#1347", ev.Source.PRID())
+			require.Equal(t, "Add search endpoint", ev.Title)
+			require.Equal(t, "octocat", ev.AuthorLogin)
+			require.Equal(t, tt.sender, ev.SenderLogin)
+		})
+	}
+}
+
+func TestParseGitHub_Ignored(t *testing.T) {
+	ev, err := githost.ParseGitHub("pull_request", fixture(t, "github_synchronize.json"))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+
+	ev, err = githost.ParseGitHub("ping", []byte(`{"zen":"Keep it logically awesome."}`))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+
+	_, err = githost.ParseGitHub("pull_request", []byte(`{"action":"opened"}`))
+	require.ErrorIs(t, err, githost.ErrMalformedPayload)
+}
+
+func TestParseGitLab(t *testing.T) {
+	tests := []struct {
+		fixture string
+		action  domain.HostPRAction
+	}{
+		{"gitlab_open.json", domain.HostPROpened},
+		{"gitlab_close.json", domain.HostPRClosed},
+		{"gitlab_merge.json", domain.HostPRMerged},
+		{"gitlab_reopen.json", domain.HostPRReopened},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			ev, err := githost.ParseGitLab("Merge Request Hook", fixture(t, tt.fixture))
+
+			require.NoError(t, err)
+			require.Equal(t, tt.action, ev.Action)
+			require.Equal(t, "gitlab:gitlabhq/gitlab-test!1", ev.Source.PRID())
+			require.Equal(t, "https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1", ev.Source.URL)
+			require.Equal(t, "MS-Viewport", ev.Title)
+			require.Equal(t, "root", ev.AuthorLogin)
+		})
+	}
+}
+
+func TestParseGitLab_Ignored(t *testing.T) {
+	ev, err := githost.ParseGitLab("Merge Request Hook", fixture(t, "gitlab_update.json"))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+
+	ev, err = githost.ParseGitLab("Push Hook", []byte(`{"object_kind":"push"}`))
+	require.NoError(t, err)
+	require.Nil(t, ev)
+}
+
+func TestVerify(t *testing.T) {
+	body := fixture(t, "github_opened.json")
+
+	require.True(t, githost.VerifyGitHub("s3cret", body, signature.Sign("s3cret", body)))
+	require.False(t, githost.VerifyGitHub("s3cret", body, signature.Sign("other", body)))
+	require.False(t, githost.VerifyGitHub("", body, signature.Sign("", body)))
+
+	require.True(t, githost.VerifyGitLab("token", "token"))
+	require.False(t, githost.VerifyGitLab("token", "tokem"))
+	require.False(t, githost.VerifyGitLab("", ""))
+}
@@ -0,0 +1,72 @@
+package githost
+
+import (
+	"encoding/json"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number  int64  `json:"number"`
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+		Merged  bool   `json:"merged"`
+		User    struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// ParseGitHub reads a delivery with the given X-GitHub-Event header. Only
+// "pull_request" events with the opened, closed and reopened actions are
+// returned; GitHub reports a merge as "closed" with merged set.
+func ParseGitHub(event string, body []byte) (*domain.HostPREvent, error) {
+	if event != "pull_request" {
+		return nil, nil
+	}
+
+	var p githubPullRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, ErrMalformedPayload
+	}
+
+	var action domain.HostPRAction
+	switch p.Action {
+	case "opened":
+		action = domain.HostPROpened
+	case "reopened":
+		action = domain.HostPRReopened
+	case "closed":
+		action = domain.HostPRClosed
+		if p.PullRequest.Merged {
+			action = domain.HostPRMerged
+		}
+	default:
+		return nil, nil
+	}
+
+	if p.Repository.FullName == "" || p.PullRequest.Number <= 0 || p.PullRequest.User.Login == "" {
+		return nil, ErrMalformedPayload
+	}
+
+	return &domain.HostPREvent{
+		Action: action,
+		Source: domain.PRSource{
+			Provider:   domain.ProviderGitHub,
+			Repository: p.Repository.FullName,
+			Number:     p.PullRequest.Number,
+			URL:        p.PullRequest.HTMLURL,
+		},
+		Title:       p.PullRequest.Title,
+		AuthorLogin: p.PullRequest.User.Login,
+		SenderLogin: p.Sender.Login,
+	}, nil
+}
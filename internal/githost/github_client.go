@@ -0,0 +1,55 @@
+package githost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubClient requests reviews through the GitHub REST API. The token needs
+// write access to pull requests of the repository.
+type GitHubClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewGitHubClient(baseURL string, token string, client *http.Client) *GitHubClient {
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIURL
+	}
+	return &GitHubClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  client,
+	}
+}
+
+type githubReviewersRequest struct {
+	Reviewers []string `json:"reviewers"`
+}
+
+func (c *GitHubClient) RequestReviewers(ctx context.Context, src domain.PRSource, logins []string) error {
+	return doJSON(ctx, c.client, http.MethodPost, c.reviewersURL(src), c.header(), githubReviewersRequest{Reviewers: logins}, nil)
+}
+
+func (c *GitHubClient) RemoveReviewers(ctx context.Context, src domain.PRSource, logins []string) error {
+	return doJSON(ctx, c.client, http.MethodDelete, c.reviewersURL(src), c.header(), githubReviewersRequest{Reviewers: logins}, nil)
+}
+
+func (c *GitHubClient) reviewersURL(src domain.PRSource) string {
+	return fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.baseURL, src.Repository, src.Number)
+}
+
+func (c *GitHubClient) header() http.Header {
+	h := http.Header{}
+	h.Set("Accept", "application/vnd.github+json")
+	h.Set("Authorization", "Bearer "+c.token)
+	h.Set("X-GitHub-Api-Version", "2022-11-28")
+	return h
+}
@@ -0,0 +1,73 @@
+package githost
+
+import (
+	"encoding/json"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type gitlabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int64  `json:"iid"`
+		Title  string `json:"title"`
+		URL    string `json:"url"`
+		Action string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+// ParseGitLab reads a delivery with the given X-Gitlab-Event header. Only
+// "Merge Request Hook" events with the open, close, reopen and merge actions
+// are returned.
+//
+// GitLab identifies the author by a numeric id only, so the user who
+// triggered the event is taken as the author. That holds for "open"; for
+// the other actions the author is used only when the merge request is not
+// known yet.
+func ParseGitLab(event string, body []byte) (*domain.HostPREvent, error) {
+	if event != "Merge Request Hook" {
+		return nil, nil
+	}
+
+	var p gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil || p.ObjectKind != "merge_request" {
+		return nil, ErrMalformedPayload
+	}
+
+	var action domain.HostPRAction
+	switch p.ObjectAttributes.Action {
+	case "open":
+		action = domain.HostPROpened
+	case "reopen":
+		action = domain.HostPRReopened
+	case "close":
+		action = domain.HostPRClosed
+	case "merge":
+		action = domain.HostPRMerged
+	default:
+		return nil, nil
+	}
+
+	if p.Project.PathWithNamespace == "" || p.ObjectAttributes.IID <= 0 || p.User.Username == "" {
+		return nil, ErrMalformedPayload
+	}
+
+	return &domain.HostPREvent{
+		Action: action,
+		Source: domain.PRSource{
+			Provider:   domain.ProviderGitLab,
+			Repository: p.Project.PathWithNamespace,
+			Number:     p.ObjectAttributes.IID,
+			URL:        p.ObjectAttributes.URL,
+		},
+		Title:       p.ObjectAttributes.Title,
+		AuthorLogin: p.User.Username,
+		SenderLogin: p.User.Username,
+	}, nil
+}
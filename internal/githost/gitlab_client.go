@@ -0,0 +1,114 @@
+package githost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+const DefaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
+// GitLabClient requests reviews through the GitLab REST API. GitLab only
+// accepts the full list of reviewer ids, so every change reads the current
+// reviewers of the merge request first.
+type GitLabClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewGitLabClient(baseURL string, token string, client *http.Client) *GitLabClient {
+	if baseURL == "" {
+		baseURL = DefaultGitLabAPIURL
+	}
+	return &GitLabClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  client,
+	}
+}
+
+type gitlabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type gitlabMergeRequest struct {
+	Reviewers []gitlabUser `json:"reviewers"`
+}
+
+type gitlabUpdateReviewersRequest struct {
+	ReviewerIDs []int64 `json:"reviewer_ids"`
+}
+
+func (c *GitLabClient) RequestReviewers(ctx context.Context, src domain.PRSource, logins []string) error {
+	return c.updateReviewers(ctx, src, logins, true)
+}
+
+func (c *GitLabClient) RemoveReviewers(ctx context.Context, src domain.PRSource, logins []string) error {
+	return c.updateReviewers(ctx, src, logins, false)
+}
+
+func (c *GitLabClient) updateReviewers(ctx context.Context, src domain.PRSource, logins []string, add bool) error {
+	var mr gitlabMergeRequest
+	if err := doJSON(ctx, c.client, http.MethodGet, c.mergeRequestURL(src), c.header(), nil, &mr); err != nil {
+		return err
+	}
+
+	ids := make(map[int64]bool, len(mr.Reviewers)+len(logins))
+	for _, r := range mr.Reviewers {
+		ids[r.ID] = true
+	}
+
+	changed := false
+	for _, login := range logins {
+		id, err := c.userID(ctx, login)
+		if err != nil {
+			return err
+		}
+		if ids[id] != add {
+			ids[id] = add
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	req := gitlabUpdateReviewersRequest{ReviewerIDs: []int64{}}
+	for id, assigned := range ids {
+		if assigned {
+			req.ReviewerIDs = append(req.ReviewerIDs, id)
+		}
+	}
+	slices.Sort(req.ReviewerIDs)
+
+	return doJSON(ctx, c.client, http.MethodPut, c.mergeRequestURL(src), c.header(), req, nil)
+}
+
+func (c *GitLabClient) userID(ctx context.Context, login string) (int64, error) {
+	var users []gitlabUser
+	u := c.baseURL + "/users?username=" + url.QueryEscape(login)
+	if err := doJSON(ctx, c.client, http.MethodGet, u, c.header(), nil, &users); err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, &APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("gitlab user %q not found", login)}
+	}
+	return users[0].ID, nil
+}
+
+func (c *GitLabClient) mergeRequestURL(src domain.PRSource) string {
+	return fmt.Sprintf("%s/projects/%s/merge_requests/%d", c.baseURL, url.PathEscape(src.Repository), src.Number)
+}
+
+func (c *GitLabClient) header() http.Header {
+	h := http.Header{}
+	h.Set("PRIVATE-TOKEN", c.token)
+	return h
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// business reads the business gauges from the database on every scrape.
+// Every replica reports the same values.
+type business struct {
+	repo    repository.MetricsRepository
+	timeout time.Duration
+
+	openPRs        *prometheus.Desc
+	pendingReviews *prometheus.Desc
+}
+
+// RegisterBusiness adds the gauges read from repo, each read bounded by
+// timeout, replacing the ones registered before.
+func RegisterBusiness(repo repository.MetricsRepository, timeout time.Duration) error {
+	return replace(&bizGauge, &business{
+		repo:    repo,
+		timeout: timeout,
+		openPRs: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_pull_requests"),
+			"Open pull requests by team.",
+			[]string{"team_name"}, nil,
+		),
+		pendingReviews: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "pending_reviews"),
+			"Open pull requests awaiting the verdict of a reviewer, by reviewer.",
+			[]string{"user_id"}, nil,
+		),
+	})
+}
+
+func (b *business) Describe(ch chan<- *prometheus.Desc) {
+	ch <- b.openPRs
+	ch <- b.pendingReviews
+}
+
+func (b *business) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	for _, g := range []struct {
+		desc *prometheus.Desc
+		read func(ctx context.Context) (map[string]int, error)
+	}{
+		{b.openPRs, b.repo.OpenPRsByTeam},
+		{b.pendingReviews, b.repo.PendingReviewsByUser},
+	} {
+		counts, err := g.read(ctx)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(g.desc, err)
+			continue
+		}
+		for label, n := range counts {
+			ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, float64(n), label)
+		}
+	}
+}
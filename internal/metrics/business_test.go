@@ -0,0 +1,53 @@
+package metrics_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/metrics"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+)
+
+func TestBusiness_ReadsGaugesOnScrape(t *testing.T) {
+	repo := mocks.NewMetricsRepository(t)
+	repo.On("OpenPRsByTeam", mock.Anything).Return(map[string]int{"backend": 3, "web": 1}, nil).Once()
+	repo.On("PendingReviewsByUser", mock.Anything).Return(map[string]int{"u1": 2}, nil).Once()
+
+	require.NoError(t, metrics.RegisterBusiness(repo, time.Second))
+
+	err := testutil.GatherAndCompare(metrics.Registry, strings.NewReader(`
+# HELP pr_service_open_pull_requests Open pull requests by team.
+# TYPE pr_service_open_pull_requests gauge
+pr_service_open_pull_requests{team_name="backend"} 3
+pr_service_open_pull_requests{team_name="web"} 1
+# HELP pr_service_pending_reviews Open pull requests awaiting the verdict of a reviewer, by reviewer.
+# TYPE pr_service_pending_reviews gauge
+pr_service_pending_reviews{user_id="u1"} 2
+`), "pr_service_open_pull_requests", "pr_service_pending_reviews")
+	require.NoError(t, err)
+}
+
+func TestBusiness_FailedReadLeavesGaugeOut(t *testing.T) {
+	repo := mocks.NewMetricsRepository(t)
+	repo.On("OpenPRsByTeam", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	repo.On("PendingReviewsByUser", mock.Anything).Return(map[string]int{"u1": 2}, nil).Once()
+
+	// Registering again replaces the collector of the previous test.
+	require.NoError(t, metrics.RegisterBusiness(repo, time.Second))
+
+	families, err := metrics.Registry.Gather()
+	require.Error(t, err)
+
+	names := make([]string, 0, len(families))
+	for _, f := range families {
+		names = append(names, f.GetName())
+	}
+	require.Contains(t, names, "pr_service_pending_reviews")
+	require.NotContains(t, names, "pr_service_open_pull_requests")
+}
@@ -0,0 +1,98 @@
+// Package metrics holds the Prometheus metrics of the service and serves
+// them on /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_service"
+
+// Registry holds every metric of the service along with the Go runtime and
+// process metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestDuration is labelled with the route pattern rather than
+	// the path so that ids do not make up new series.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of database statements by repository and method.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	NoCandidate = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "review_no_candidate_total",
+		Help:      "Reviewer replacements that found no candidate, by team.",
+	}, []string{"team_name"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		DBQueryDuration,
+		NoCandidate,
+	)
+}
+
+var (
+	mu       sync.Mutex
+	dbStats  prometheus.Collector
+	bizGauge prometheus.Collector
+)
+
+// RegisterDB adds the connection pool statistics of db, replacing the ones
+// of a database registered before.
+func RegisterDB(db *sql.DB) error {
+	return replace(&dbStats, collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// replace registers c in place of the collector held in slot.
+func replace(slot *prometheus.Collector, c prometheus.Collector) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if *slot != nil {
+		Registry.Unregister(*slot)
+	}
+	if err := Registry.Register(c); err != nil {
+		return err
+	}
+	*slot = c
+	return nil
+}
+
+// Handler serves the metrics. A collector that fails leaves its metrics
+// out of the scrape rather than failing it.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		ErrorLog:      errorLog{},
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+type errorLog struct{}
+
+func (errorLog) Println(v ...any) {
+	logger.L().Error("failed to collect metrics", slog.String("err", fmt.Sprint(v...)))
+}
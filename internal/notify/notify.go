@@ -0,0 +1,87 @@
+// Package notify renders notification letters and sends them by email.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+// Sender delivers a rendered letter to a single address.
+type Sender interface {
+	Send(ctx context.Context, to string, subject string, body string) error
+}
+
+// Item is one notification of a letter.
+type Item struct {
+	Kind                 domain.NotificationKind
+	PRID                 string
+	PRName               string
+	AuthorName           string
+	PreviousReviewerName string
+	// ReviewerName is the reviewer an escalation is about.
+	ReviewerName string
+	// OpenDays is how long a stale pull request has been open.
+	OpenDays int
+}
+
+// Letter is what is sent to a recipient at once: a single notification or a
+// digest of several.
+type Letter struct {
+	RecipientName string
+	Items         []Item
+}
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = map[string]*template.Template{
+	domain.LanguageEN: template.Must(template.ParseFS(templateFS, "templates/en.tmpl")),
+	domain.LanguageRU: template.Must(template.ParseFS(templateFS, "templates/ru.tmpl")),
+}
+
+// Render renders the subject and body of a letter in lang, falling back to
+// English for languages without templates. A letter with a single item uses
+// the template of its kind, anything else is rendered as a digest.
+func Render(lang string, letter Letter) (subject string, body string, err error) {
+	if len(letter.Items) == 0 {
+		return "", "", fmt.Errorf("letter has no items")
+	}
+
+	tmpl, ok := templates[lang]
+	if !ok {
+		tmpl = templates[domain.LanguageEN]
+	}
+
+	name := "digest"
+	data := any(letter)
+	if len(letter.Items) == 1 {
+		name = string(letter.Items[0].Kind)
+		data = struct {
+			RecipientName string
+			Item
+		}{letter.RecipientName, letter.Items[0]}
+	}
+
+	if subject, err = execute(tmpl, name+".subject", data); err != nil {
+		return "", "", err
+	}
+	if body, err = execute(tmpl, name+".body", data); err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(subject), strings.TrimSpace(body) + "\n", nil
+}
+
+func execute(tmpl *template.Template, name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
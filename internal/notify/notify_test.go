@@ -0,0 +1,101 @@
+package notify_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify/smtptest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_Single(t *testing.T) {
+	letter := notify.Letter{
+		RecipientName: "Bob",
+		Items: []notify.Item{{
+			Kind:                 domain.NotificationReviewReassigned,
+			PRID:                 "pr-1",
+			PRName:               "Add search",
+			AuthorName:           "Alice",
+			PreviousReviewerName: "Carol",
+		}},
+	}
+
+	subject, body, err := notify.Render(domain.LanguageEN, letter)
+	require.NoError(t, err)
+	require.Equal(t, "Review handed over to you: Add search", subject)
+	require.Contains(t, body, "Hello, Bob!")
+	require.Contains(t, body, `You replaced Carol as a reviewer of the pull request "Add search" (pr-1) by Alice.`)
+
+	subject, body, err = notify.Render(domain.LanguageRU, letter)
+	require.NoError(t, err)
+	require.Equal(t, "Вам передано ревью: Add search", subject)
+	require.Contains(t, body, "Здравствуйте, Bob!")
+	require.Contains(t, body, "Вы заменили ревьюера Carol")
+}
+
+func TestRender_Digest(t *testing.T) {
+	letter := notify.Letter{
+		RecipientName: "Bob",
+		Items: []notify.Item{
+			{Kind: domain.NotificationReviewAssigned, PRID: "pr-1", PRName: "Add search", AuthorName: "Alice"},
+			{Kind: domain.NotificationReviewReminder, PRID: "pr-2", PRName: "Fix login", AuthorName: "Dave"},
+		},
+	}
+
+	subject, body, err := notify.Render(domain.LanguageEN, letter)
+	require.NoError(t, err)
+	require.Equal(t, "Pull requests waiting for your review: 2", subject)
+	require.Contains(t, body, "- Add search (pr-1) by Alice: you were assigned as a reviewer\n")
+	require.Contains(t, body, "- Fix login (pr-2) by Dave: still waiting for your review\n")
+}
+
+func TestRender_UnknownLanguageFallsBackToEnglish(t *testing.T) {
+	subject, _, err := notify.Render("de", notify.Letter{Items: []notify.Item{
+		{Kind: domain.NotificationReviewReminder, PRID: "pr-1", PRName: "Add search", AuthorName: "Alice"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "Reminder: Add search is waiting for your review", subject)
+}
+
+func TestRender_Empty(t *testing.T) {
+	_, _, err := notify.Render(domain.LanguageEN, notify.Letter{})
+	require.Error(t, err)
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	srv, err := smtptest.NewServer()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	host, port := srv.Addr()
+	sender := notify.NewSMTPSender(notify.SMTPConfig{
+		Host:    host,
+		Port:    port,
+		From:    "reviews@example.com",
+		Timeout: 5 * time.Second,
+	})
+
+	body := "Здравствуйте!\n\n.A line starting with a dot and a long tail " +
+		"that has to be wrapped by the quoted-printable encoder somewhere.\n"
+	require.NoError(t, sender.Send(context.Background(), "bob@example.com", "Запрошено ревью: Add search", body))
+
+	msgs := srv.Messages()
+	require.Len(t, msgs, 1)
+	require.Equal(t, "reviews@example.com", msgs[0].From)
+	require.Equal(t, []string{"bob@example.com"}, msgs[0].To)
+	require.Equal(t, "Запрошено ревью: Add search", msgs[0].Subject)
+	require.Equal(t, body, msgs[0].Body)
+}
+
+func TestSMTPSender_Unreachable(t *testing.T) {
+	srv, err := smtptest.NewServer()
+	require.NoError(t, err)
+	host, port := srv.Addr()
+	require.NoError(t, srv.Close())
+
+	sender := notify.NewSMTPSender(notify.SMTPConfig{Host: host, Port: port, From: "reviews@example.com", Timeout: time.Second})
+	require.Error(t, sender.Send(context.Background(), "bob@example.com", "subject", "body"))
+}
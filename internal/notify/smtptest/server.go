@@ -0,0 +1,190 @@
+// Package smtptest provides an in-process SMTP server for tests.
+package smtptest
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+)
+
+// Message is a letter received by the server with its subject and body
+// decoded.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Server accepts every letter submitted to it and keeps it in memory. It
+// speaks just enough SMTP for net/smtp clients and offers neither STARTTLS
+// nor authentication.
+type Server struct {
+	ln net.Listener
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewServer starts a server on a random local port.
+func NewServer() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{ln: ln}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// Addr returns the host and port the server listens on.
+func (s *Server) Addr() (host string, port int) {
+	addr := s.ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+// Messages returns the letters received so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+// Close stops the server and waits for open sessions to end.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.session(conn)
+		}()
+	}
+}
+
+func (s *Server) session(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	reply := func(line string) bool {
+		_, err := io.WriteString(conn, line+"\r\n")
+		return err == nil
+	}
+
+	if !reply("220 smtptest ready") {
+		return
+	}
+
+	var (
+		from string
+		to   []string
+	)
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250 smtptest")
+		case "MAIL":
+			from = address(line)
+			to = nil
+			reply("250 OK")
+		case "RCPT":
+			to = append(to, address(line))
+			reply("250 OK")
+		case "DATA":
+			if !reply("354 end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := readData(r)
+			if err != nil {
+				return
+			}
+			s.store(from, to, data)
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func (s *Server) store(from string, to []string, data string) {
+	msg := Message{From: from, To: to, Body: data}
+
+	if parsed, err := mail.ReadMessage(strings.NewReader(data)); err == nil {
+		var dec mime.WordDecoder
+		if subject, err := dec.DecodeHeader(parsed.Header.Get("Subject")); err == nil {
+			msg.Subject = subject
+		}
+
+		body := parsed.Body
+		if strings.EqualFold(parsed.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+			body = quotedprintable.NewReader(body)
+		}
+		if b, err := io.ReadAll(body); err == nil {
+			msg.Body = strings.ReplaceAll(string(b), "\r\n", "\n")
+		}
+	}
+
+	s.mu.Lock()
+	s.messages = append(s.messages, msg)
+	s.mu.Unlock()
+}
+
+// readData reads a DATA section up to the terminating dot, undoing dot
+// stuffing.
+func readData(r *bufio.Reader) (string, error) {
+	var b strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" || line == ".\n" {
+			return b.String(), nil
+		}
+		b.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
+
+// address extracts the address from "MAIL FROM:<a@b>" or "RCPT TO:<a@b>".
+func address(line string) string {
+	start := strings.IndexByte(line, '<')
+	end := strings.IndexByte(line, '>')
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
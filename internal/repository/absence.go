@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type AbsenceRepository interface {
+	Create(ctx context.Context, absence *domain.Absence) error
+
+	// Delete returns domain.ErrAbsenceNotFound when there is no such
+	// absence.
+	Delete(ctx context.Context, id int64) error
+
+	// ListByUser returns the absences of a user, latest first.
+	ListByUser(ctx context.Context, userID string) ([]domain.Absence, error)
+
+	// ListOverlapping returns the absences of the users that overlap
+	// [from, to), keyed by user.
+	ListOverlapping(ctx context.Context, userIDs []string, from, to time.Time) (map[string][]domain.Absence, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type AuditRepository interface {
+	Append(ctx context.Context, event *domain.AuditEvent) error
+
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+
+	// RedactEntity drops the snapshots of every event about the entity. It
+	// is the only change ever made to stored events and exists for erasure.
+	RedactEntity(ctx context.Context, entityType string, entityID string) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type ErasureRepository interface {
+	Create(ctx context.Context, erasure *domain.UserErasure) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores rec unless an unexpired record with the same key,
+	// method and path exists. A record of the same request whose lease ran
+	// out before completing is replaced. It reports whether rec was stored.
+	Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (bool, error)
+
+	// Renew extends the lease of a record that is not completed yet.
+	Renew(ctx context.Context, key, method, path string, lockedUntil time.Time) error
+
+	Get(ctx context.Context, key, method, path string) (*domain.IdempotencyRecord, error)
+
+	Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+
+	Delete(ctx context.Context, key, method, path string) error
+
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
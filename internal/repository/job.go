@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+// JobLocker elects the instance that runs a background job.
+type JobLocker interface {
+	// TryLock takes the lock of a job without waiting for it. It reports
+	// false when another instance holds the lock. The lock is held until
+	// release is called or the instance loses its database connection.
+	TryLock(ctx context.Context, jobName string) (release func(), locked bool, err error)
+}
+
+type JobRunRepository interface {
+	// Start records a run that has begun. It reports false when the job
+	// already ran for the same scheduled time.
+	Start(ctx context.Context, run *domain.JobRun) (bool, error)
+
+	Finish(ctx context.Context, run *domain.JobRun) error
+
+	// DeleteBefore removes the runs started before the given time,
+	// including runs that never finished because their instance died, and
+	// returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
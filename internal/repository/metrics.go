@@ -0,0 +1,14 @@
+package repository
+
+import "context"
+
+// MetricsRepository reads the business gauges exposed on /metrics.
+type MetricsRepository interface {
+	// OpenPRsByTeam counts the open pull requests of every team that has
+	// any, keyed by team.
+	OpenPRsByTeam(ctx context.Context) (map[string]int, error)
+
+	// PendingReviewsByUser counts the open pull requests every reviewer has
+	// not given a verdict on yet, keyed by user.
+	PendingReviewsByUser(ctx context.Context) (map[string]int, error)
+}
@@ -0,0 +1,127 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AbsenceRepository is an autogenerated mock type for the AbsenceRepository type
+type AbsenceRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, absence
+func (_m *AbsenceRepository) Create(ctx context.Context, absence *domain.Absence) error {
+	ret := _m.Called(ctx, absence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Absence) error); ok {
+		r0 = rf(ctx, absence)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *AbsenceRepository) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *AbsenceRepository) ListByUser(ctx context.Context, userID string) ([]domain.Absence, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []domain.Absence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Absence, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Absence); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Absence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOverlapping provides a mock function with given fields: ctx, userIDs, from, to
+func (_m *AbsenceRepository) ListOverlapping(ctx context.Context, userIDs []string, from time.Time, to time.Time) (map[string][]domain.Absence, error) {
+	ret := _m.Called(ctx, userIDs, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverlapping")
+	}
+
+	var r0 map[string][]domain.Absence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) (map[string][]domain.Absence, error)); ok {
+		return rf(ctx, userIDs, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) map[string][]domain.Absence); ok {
+		r0 = rf(ctx, userIDs, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]domain.Absence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userIDs, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAbsenceRepository creates a new instance of AbsenceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAbsenceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AbsenceRepository {
+	mock := &AbsenceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ErasureRepository is an autogenerated mock type for the ErasureRepository type
+type ErasureRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, erasure
+func (_m *ErasureRepository) Create(ctx context.Context, erasure *domain.UserErasure) error {
+	ret := _m.Called(ctx, erasure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserErasure) error); ok {
+		r0 = rf(ctx, erasure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewErasureRepository creates a new instance of ErasureRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewErasureRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ErasureRepository {
+	mock := &ErasureRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
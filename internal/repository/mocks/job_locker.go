@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobLocker is an autogenerated mock type for the JobLocker type
+type JobLocker struct {
+	mock.Mock
+}
+
+// TryLock provides a mock function with given fields: ctx, jobName
+func (_m *JobLocker) TryLock(ctx context.Context, jobName string) (func(), bool, error) {
+	ret := _m.Called(ctx, jobName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryLock")
+	}
+
+	var r0 func()
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (func(), bool, error)); ok {
+		return rf(ctx, jobName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) func()); ok {
+		r0 = rf(ctx, jobName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, jobName)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, jobName)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewJobLocker creates a new instance of JobLocker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJobLocker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JobLocker {
+	mock := &JobLocker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PREventNotifier is an autogenerated mock type for the PREventNotifier type
+type PREventNotifier struct {
+	mock.Mock
+}
+
+// Listen provides a mock function with given fields: ctx
+func (_m *PREventNotifier) Listen(ctx context.Context) (<-chan int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Listen")
+	}
+
+	var r0 <-chan int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (<-chan int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPREventNotifier creates a new instance of PREventNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPREventNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PREventNotifier {
+	mock := &PREventNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PREventRepository is an autogenerated mock type for the PREventRepository type
+type PREventRepository struct {
+	mock.Mock
+}
+
+// Append provides a mock function with given fields: ctx, events
+func (_m *PREventRepository) Append(ctx context.Context, events []domain.PREvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Append")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.PREvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LastStreamPosition provides a mock function with given fields: ctx
+func (_m *PREventRepository) LastStreamPosition(ctx context.Context) (domain.PRStreamPosition, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastStreamPosition")
+	}
+
+	var r0 domain.PRStreamPosition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.PRStreamPosition, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.PRStreamPosition); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.PRStreamPosition)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByPR provides a mock function with given fields: ctx, prID
+func (_m *PREventRepository) ListByPR(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByPR")
+	}
+
+	var r0 []domain.PREvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.PREvent, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.PREvent); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PREvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStreamAfter provides a mock function with given fields: ctx, after, filter, limit
+func (_m *PREventRepository) ListStreamAfter(ctx context.Context, after domain.PRStreamPosition, filter domain.PRStreamFilter, limit int) ([]domain.PRStreamEvent, error) {
+	ret := _m.Called(ctx, after, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStreamAfter")
+	}
+
+	var r0 []domain.PRStreamEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRStreamPosition, domain.PRStreamFilter, int) ([]domain.PRStreamEvent, error)); ok {
+		return rf(ctx, after, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PRStreamPosition, domain.PRStreamFilter, int) []domain.PRStreamEvent); ok {
+		r0 = rf(ctx, after, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PRStreamEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PRStreamPosition, domain.PRStreamFilter, int) error); ok {
+		r1 = rf(ctx, after, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPREventRepository creates a new instance of PREventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPREventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PREventRepository {
+	mock := &PREventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -92,6 +92,66 @@ func (_m *PRRepository) GetByID(ctx context.Context, id string) (*domain.PullReq
 	return r0, r1
 }
 
+// GetByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *PRRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.PullRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 *domain.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.PullRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PullRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSource provides a mock function with given fields: ctx, id
+func (_m *PRRepository) GetSource(ctx context.Context, id string) (*domain.PRSource, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSource")
+	}
+
+	var r0 *domain.PRSource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.PRSource, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PRSource); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PRSource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListByReviewer provides a mock function with given fields: ctx, reviewerID
 func (_m *PRRepository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
 	ret := _m.Called(ctx, reviewerID)
@@ -122,6 +182,78 @@ func (_m *PRRepository) ListByReviewer(ctx context.Context, reviewerID string) (
 	return r0, r1
 }
 
+// RemoveReviewer provides a mock function with given fields: ctx, id, reviewerID
+func (_m *PRRepository) RemoveReviewer(ctx context.Context, id string, reviewerID string) error {
+	ret := _m.Called(ctx, id, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReviewer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, reviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceReviewer provides a mock function with given fields: ctx, id, oldReviewerID, newReviewerID
+func (_m *PRRepository) ReplaceReviewer(ctx context.Context, id string, oldReviewerID string, newReviewerID string) error {
+	ret := _m.Called(ctx, id, oldReviewerID, newReviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceReviewer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, id, oldReviewerID, newReviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSource provides a mock function with given fields: ctx, id, source
+func (_m *PRRepository) SetSource(ctx context.Context, id string, source domain.PRSource) error {
+	ret := _m.Called(ctx, id, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSource")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PRSource) error); ok {
+		r0 = rf(ctx, id, source)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetVerdict provides a mock function with given fields: ctx, id, reviewerID, verdict, at
+func (_m *PRRepository) SetVerdict(ctx context.Context, id string, reviewerID string, verdict domain.ReviewVerdict, at time.Time) error {
+	ret := _m.Called(ctx, id, reviewerID, verdict, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetVerdict")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ReviewVerdict, time.Time) error); ok {
+		r0 = rf(ctx, id, reviewerID, verdict, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateReviewers provides a mock function with given fields: ctx, id, reviewers
 func (_m *PRRepository) UpdateReviewers(ctx context.Context, id string, reviewers []string) error {
 	ret := _m.Called(ctx, id, reviewers)
@@ -140,6 +272,24 @@ func (_m *PRRepository) UpdateReviewers(ctx context.Context, id string, reviewer
 	return r0
 }
 
+// UpdateStatusAndClosedAt provides a mock function with given fields: ctx, id, status, closedAt
+func (_m *PRRepository) UpdateStatusAndClosedAt(ctx context.Context, id string, status domain.PRStatus, closedAt *time.Time) error {
+	ret := _m.Called(ctx, id, status, closedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatusAndClosedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PRStatus, *time.Time) error); ok {
+		r0 = rf(ctx, id, status, closedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateStatusAndMergedAt provides a mock function with given fields: ctx, id, status, mergedAt
 func (_m *PRRepository) UpdateStatusAndMergedAt(ctx context.Context, id string, status domain.PRStatus, mergedAt *time.Time) error {
 	ret := _m.Called(ctx, id, status, mergedAt)
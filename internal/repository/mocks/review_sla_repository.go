@@ -0,0 +1,147 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReviewSLARepository is an autogenerated mock type for the ReviewSLARepository type
+type ReviewSLARepository struct {
+	mock.Mock
+}
+
+// ListAwaiting provides a mock function with given fields: ctx, now
+func (_m *ReviewSLARepository) ListAwaiting(ctx context.Context, now time.Time) ([]domain.PendingReview, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAwaiting")
+	}
+
+	var r0 []domain.PendingReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.PendingReview, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.PendingReview); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOverdue provides a mock function with given fields: ctx, teamName
+func (_m *ReviewSLARepository) ListOverdue(ctx context.Context, teamName string) ([]domain.PendingReview, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverdue")
+	}
+
+	var r0 []domain.PendingReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.PendingReview, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.PendingReview); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkBreached provides a mock function with given fields: ctx, prID, reviewerID, assignedAt, at
+func (_m *ReviewSLARepository) MarkBreached(ctx context.Context, prID string, reviewerID string, assignedAt time.Time, at time.Time) (bool, error) {
+	ret := _m.Called(ctx, prID, reviewerID, assignedAt, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkBreached")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, prID, reviewerID, assignedAt, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, prID, reviewerID, assignedAt, at)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, prID, reviewerID, assignedAt, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkEscalated provides a mock function with given fields: ctx, prID, reviewerID, assignedAt, at
+func (_m *ReviewSLARepository) MarkEscalated(ctx context.Context, prID string, reviewerID string, assignedAt time.Time, at time.Time) (bool, error) {
+	ret := _m.Called(ctx, prID, reviewerID, assignedAt, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEscalated")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, prID, reviewerID, assignedAt, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, prID, reviewerID, assignedAt, at)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, prID, reviewerID, assignedAt, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReviewSLARepository creates a new instance of ReviewSLARepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewSLARepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewSLARepository {
+	mock := &ReviewSLARepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// StalePRRepository is an autogenerated mock type for the StalePRRepository type
+type StalePRRepository struct {
+	mock.Mock
+}
+
+// ListStale provides a mock function with given fields: ctx, filter, openedBefore, activeBefore
+func (_m *StalePRRepository) ListStale(ctx context.Context, filter domain.StalePRFilter, openedBefore time.Time, activeBefore time.Time) ([]domain.StalePR, error) {
+	ret := _m.Called(ctx, filter, openedBefore, activeBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStale")
+	}
+
+	var r0 []domain.StalePR
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StalePRFilter, time.Time, time.Time) ([]domain.StalePR, error)); ok {
+		return rf(ctx, filter, openedBefore, activeBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StalePRFilter, time.Time, time.Time) []domain.StalePR); ok {
+		r0 = rf(ctx, filter, openedBefore, activeBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.StalePR)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StalePRFilter, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, filter, openedBefore, activeBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStalePRRepository creates a new instance of StalePRRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStalePRRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StalePRRepository {
+	mock := &StalePRRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
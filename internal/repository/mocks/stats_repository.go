@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // StatsRepository is an autogenerated mock type for the StatsRepository type
@@ -14,24 +16,290 @@ type StatsRepository struct {
 	mock.Mock
 }
 
-// CountAssignmentsByUser provides a mock function with given fields: ctx
-func (_m *StatsRepository) CountAssignmentsByUser(ctx context.Context) ([]domain.UserAssignmentStat, error) {
+// Activity provides a mock function with given fields: ctx, userIDs, from, to
+func (_m *StatsRepository) Activity(ctx context.Context, userIDs []string, from time.Time, to time.Time) (map[string]domain.Activity, error) {
+	ret := _m.Called(ctx, userIDs, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Activity")
+	}
+
+	var r0 map[string]domain.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) (map[string]domain.Activity, error)); ok {
+		return rf(ctx, userIDs, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time, time.Time) map[string]domain.Activity); ok {
+		r0 = rf(ctx, userIDs, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.Activity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userIDs, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckCounters provides a mock function with given fields: ctx
+func (_m *StatsRepository) CheckCounters(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for CheckCounters")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountAssignmentsByUser provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) CountAssignmentsByUser(ctx context.Context, filter domain.StatsFilter) ([]domain.UserAssignmentStat, error) {
+	ret := _m.Called(ctx, filter)
+
 	if len(ret) == 0 {
 		panic("no return value specified for CountAssignmentsByUser")
 	}
 
 	var r0 []domain.UserAssignmentStat
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.UserAssignmentStat, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsFilter) ([]domain.UserAssignmentStat, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsFilter) []domain.UserAssignmentStat); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserAssignmentStat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByTeam provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) CountByTeam(ctx context.Context, filter domain.StatsFilter) ([]domain.TeamStat, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByTeam")
+	}
+
+	var r0 []domain.TeamStat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsFilter) ([]domain.TeamStat, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsFilter) []domain.TeamStat); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TeamStat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountReviewersByPR provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) CountReviewersByPR(ctx context.Context, filter domain.StatsFilter) ([]domain.PRReviewerStat, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReviewersByPR")
+	}
+
+	var r0 []domain.PRReviewerStat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsFilter) ([]domain.PRReviewerStat, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsFilter) []domain.PRReviewerStat); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PRReviewerStat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CycleTimeByReviewer provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) CycleTimeByReviewer(ctx context.Context, filter domain.CycleTimeFilter) ([]domain.ReviewerCycleTime, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CycleTimeByReviewer")
+	}
+
+	var r0 []domain.ReviewerCycleTime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CycleTimeFilter) ([]domain.ReviewerCycleTime, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CycleTimeFilter) []domain.ReviewerCycleTime); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ReviewerCycleTime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CycleTimeFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CycleTimeByTeam provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) CycleTimeByTeam(ctx context.Context, filter domain.CycleTimeFilter) ([]domain.TeamCycleTime, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CycleTimeByTeam")
+	}
+
+	var r0 []domain.TeamCycleTime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CycleTimeFilter) ([]domain.TeamCycleTime, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CycleTimeFilter) []domain.TeamCycleTime); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TeamCycleTime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CycleTimeFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSnapshotsBefore provides a mock function with given fields: ctx, day
+func (_m *StatsRepository) DeleteSnapshotsBefore(ctx context.Context, day time.Time) (int64, error) {
+	ret := _m.Called(ctx, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSnapshotsBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, day)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, day)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FairnessMembers provides a mock function with given fields: ctx, teamName, from, to
+func (_m *StatsRepository) FairnessMembers(ctx context.Context, teamName string, from time.Time, to time.Time) ([]domain.FairnessMember, error) {
+	ret := _m.Called(ctx, teamName, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FairnessMembers")
+	}
+
+	var r0 []domain.FairnessMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]domain.FairnessMember, error)); ok {
+		return rf(ctx, teamName, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []domain.FairnessMember); ok {
+		r0 = rf(ctx, teamName, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FairnessMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, teamName, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FirstPRDay provides a mock function with given fields: ctx
+func (_m *StatsRepository) FirstPRDay(ctx context.Context) (*time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirstPRDay")
+	}
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*time.Time, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.UserAssignmentStat); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) *time.Time); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.UserAssignmentStat)
+			r0 = ret.Get(0).(*time.Time)
 		}
 	}
 
@@ -44,24 +312,24 @@ func (_m *StatsRepository) CountAssignmentsByUser(ctx context.Context) ([]domain
 	return r0, r1
 }
 
-// CountReviewersByPR provides a mock function with given fields: ctx
-func (_m *StatsRepository) CountReviewersByPR(ctx context.Context) ([]domain.PRReviewerStat, error) {
+// LastSnapshotDay provides a mock function with given fields: ctx
+func (_m *StatsRepository) LastSnapshotDay(ctx context.Context) (*time.Time, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountReviewersByPR")
+		panic("no return value specified for LastSnapshotDay")
 	}
 
-	var r0 []domain.PRReviewerStat
+	var r0 *time.Time
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PRReviewerStat, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (*time.Time, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.PRReviewerStat); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) *time.Time); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.PRReviewerStat)
+			r0 = ret.Get(0).(*time.Time)
 		}
 	}
 
@@ -74,6 +342,132 @@ func (_m *StatsRepository) CountReviewersByPR(ctx context.Context) ([]domain.PRR
 	return r0, r1
 }
 
+// OpenAgeByTeam provides a mock function with given fields: ctx, teamName, asOf, bounds
+func (_m *StatsRepository) OpenAgeByTeam(ctx context.Context, teamName string, asOf time.Time, bounds []time.Duration) (map[string][]int, error) {
+	ret := _m.Called(ctx, teamName, asOf, bounds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenAgeByTeam")
+	}
+
+	var r0 map[string][]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, []time.Duration) (map[string][]int, error)); ok {
+		return rf(ctx, teamName, asOf, bounds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, []time.Duration) map[string][]int); ok {
+		r0 = rf(ctx, teamName, asOf, bounds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, []time.Duration) error); ok {
+		r1 = rf(ctx, teamName, asOf, bounds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RebuildCounters provides a mock function with given fields: ctx
+func (_m *StatsRepository) RebuildCounters(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildCounters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSnapshots provides a mock function with given fields: ctx, from, to
+func (_m *StatsRepository) SaveSnapshots(ctx context.Context, from time.Time, to time.Time) error {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSnapshots")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TeamSnapshots provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) TeamSnapshots(ctx context.Context, filter domain.StatsHistoryFilter) ([]domain.TeamSnapshot, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamSnapshots")
+	}
+
+	var r0 []domain.TeamSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsHistoryFilter) ([]domain.TeamSnapshot, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsHistoryFilter) []domain.TeamSnapshot); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TeamSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StatsHistoryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserSnapshots provides a mock function with given fields: ctx, filter
+func (_m *StatsRepository) UserSnapshots(ctx context.Context, filter domain.StatsHistoryFilter) ([]domain.UserSnapshot, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserSnapshots")
+	}
+
+	var r0 []domain.UserSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsHistoryFilter) ([]domain.UserSnapshot, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StatsHistoryFilter) []domain.UserSnapshot); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StatsHistoryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewStatsRepository creates a new instance of StatsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStatsRepository(t interface {
@@ -90,6 +90,24 @@ func (_m *TeamRepository) GetByName(ctx context.Context, name string) (*domain.T
 	return r0, r1
 }
 
+// UpdateSettings provides a mock function with given fields: ctx, team
+func (_m *TeamRepository) UpdateSettings(ctx context.Context, team *domain.Team) error {
+	ret := _m.Called(ctx, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Team) error); ok {
+		r0 = rf(ctx, team)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTeamRepository creates a new instance of TeamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamRepository(t interface {
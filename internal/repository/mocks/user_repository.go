@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -14,6 +16,100 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// AddMembership provides a mock function with given fields: ctx, userID, teamName, isPrimary
+func (_m *UserRepository) AddMembership(ctx context.Context, userID string, teamName string, isPrimary bool) error {
+	ret := _m.Called(ctx, userID, teamName, isPrimary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMembership")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, userID, teamName, isPrimary)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Anonymize provides a mock function with given fields: ctx, id, pseudonym, erasedAt
+func (_m *UserRepository) Anonymize(ctx context.Context, id string, pseudonym string, erasedAt time.Time) error {
+	ret := _m.Called(ctx, id, pseudonym, erasedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, pseudonym, erasedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Count provides a mock function with given fields: ctx, filter
+func (_m *UserRepository) Count(ctx context.Context, filter domain.UserFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByExternalLogin provides a mock function with given fields: ctx, provider, login
+func (_m *UserRepository) GetByExternalLogin(ctx context.Context, provider string, login string) (*domain.User, error) {
+	ret := _m.Called(ctx, provider, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByExternalLogin")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return rf(ctx, provider, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = rf(ctx, provider, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	ret := _m.Called(ctx, id)
@@ -44,6 +140,36 @@ func (_m *UserRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	return r0, r1
 }
 
+// List provides a mock function with given fields: ctx, filter
+func (_m *UserRepository) List(ctx context.Context, filter domain.UserFilter) ([]domain.UserListItem, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.UserListItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) ([]domain.UserListItem, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) []domain.UserListItem); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserListItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListActiveByTeam provides a mock function with given fields: ctx, teamName
 func (_m *UserRepository) ListActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
 	ret := _m.Called(ctx, teamName)
@@ -104,6 +230,24 @@ func (_m *UserRepository) ListByTeam(ctx context.Context, teamName string) ([]do
 	return r0, r1
 }
 
+// SetExternalAccounts provides a mock function with given fields: ctx, userID, accounts
+func (_m *UserRepository) SetExternalAccounts(ctx context.Context, userID string, accounts map[string]string) error {
+	ret := _m.Called(ctx, userID, accounts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetExternalAccounts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) error); ok {
+		r0 = rf(ctx, userID, accounts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetIsActive provides a mock function with given fields: ctx, id, isActive
 func (_m *UserRepository) SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error) {
 	ret := _m.Called(ctx, id, isActive)
@@ -135,7 +279,7 @@ func (_m *UserRepository) SetIsActive(ctx context.Context, id string, isActive b
 }
 
 // Upsert provides a mock function with given fields: ctx, user
-func (_m *UserRepository) Upsert(ctx context.Context, user *domain.User) error {
+func (_m *UserRepository) Upsert(ctx context.Context, user *domain.UserUpsert) error {
 	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
@@ -143,7 +287,7 @@ func (_m *UserRepository) Upsert(ctx context.Context, user *domain.User) error {
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserUpsert) error); ok {
 		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
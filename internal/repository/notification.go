@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type NotificationRepository interface {
+	// GetPreferences returns nil when the user never saved preferences.
+	GetPreferences(ctx context.Context, userID string) (*domain.NotificationPreferences, error)
+
+	SavePreferences(ctx context.Context, prefs *domain.NotificationPreferences) error
+
+	Enqueue(ctx context.Context, notifications []domain.Notification) error
+
+	// EnqueueReminders queues a reminder for every reviewer of an open pull
+	// request created before notifiedBefore who has not submitted a verdict
+	// and was not notified about it since. It returns the number of
+	// reminders queued.
+	EnqueueReminders(ctx context.Context, notifiedBefore time.Time, now time.Time) (int, error)
+
+	// EnqueueMissing queues the notifications whose recipient has no
+	// notification of the same kind about the same pull request pending or
+	// created after since. It returns the number of notifications queued.
+	EnqueueMissing(ctx context.Context, notifications []domain.Notification, since time.Time) (int, error)
+
+	// ClaimDue returns pending notifications that are due and hides them
+	// from other dispatchers until leaseUntil.
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.Notification, error)
+
+	SaveAttempt(ctx context.Context, n *domain.Notification) error
+}
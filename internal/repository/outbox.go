@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type OutboxRepository interface {
+	Append(ctx context.Context, event *domain.OutboxEvent) error
+
+	// RedactUser replaces the username in the user events of userID with
+	// pseudonym and drops their deliveries that are still pending.
+	RedactUser(ctx context.Context, userID string, pseudonym string) error
+}
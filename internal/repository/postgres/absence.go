@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type AbsencePostgres struct {
+	db *sql.DB
+}
+
+func NewAbsencePostgres(db *sql.DB) repository.AbsenceRepository {
+	return &AbsencePostgres{db: db}
+}
+
+func (r *AbsencePostgres) Create(ctx context.Context, a *domain.Absence) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO user_absences (user_id, starts_at, ends_at, reason, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		a.UserID,
+		a.From,
+		a.To,
+		a.Reason,
+		a.CreatedAt,
+	).Scan(&a.ID)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *AbsencePostgres) Delete(ctx context.Context, id int64) error {
+	log := logger.L()
+
+	q := `
+        DELETE FROM user_absences WHERE id = $1
+    `
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, id)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+func (r *AbsencePostgres) ListByUser(ctx context.Context, userID string) ([]domain.Absence, error) {
+	log := logger.L()
+
+	q := `
+        SELECT id, user_id, starts_at, ends_at, reason, created_at
+        FROM user_absences
+        WHERE user_id = $1
+        ORDER BY starts_at DESC, id DESC
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, userID)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAbsences(rows)
+}
+
+func (r *AbsencePostgres) ListOverlapping(
+	ctx context.Context,
+	userIDs []string,
+	from, to time.Time,
+) (map[string][]domain.Absence, error) {
+	log := logger.L()
+
+	q := `
+        SELECT id, user_id, starts_at, ends_at, reason, created_at
+        FROM user_absences
+        WHERE user_id = ANY($1)
+          AND starts_at < $3
+          AND ends_at > $2
+        ORDER BY user_id, starts_at
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, pq.Array(userIDs), from, to)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	list, err := scanAbsences(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := map[string][]domain.Absence{}
+	for _, a := range list {
+		byUser[a.UserID] = append(byUser[a.UserID], a)
+	}
+
+	return byUser, nil
+}
+
+func scanAbsences(rows *sql.Rows) ([]domain.Absence, error) {
+	var list []domain.Absence
+
+	for rows.Next() {
+		var a domain.Absence
+		if err := rows.Scan(&a.ID, &a.UserID, &a.From, &a.To, &a.Reason, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+
+	return list, rows.Err()
+}
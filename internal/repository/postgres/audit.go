@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type AuditPostgres struct {
+	db *sql.DB
+}
+
+func NewAuditPostgres(db *sql.DB) repository.AuditRepository {
+	return &AuditPostgres{db: db}
+}
+
+func (r *AuditPostgres) Append(ctx context.Context, event *domain.AuditEvent) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO audit_events (occurred_at, actor_id, action, entity_type, entity_id, before, after)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		event.OccurredAt,
+		event.ActorID,
+		event.Action,
+		event.EntityType,
+		event.EntityID,
+		nullJSON(event.Before),
+		nullJSON(event.After),
+	).Scan(&event.ID)
+
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+
+	return err
+}
+
+func (r *AuditPostgres) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	log := logger.L()
+
+	q := `
+        SELECT id, occurred_at, actor_id, action, entity_type, entity_id, before, after
+        FROM audit_events
+        WHERE ($1 = '' OR entity_type = $1)
+            AND ($2 = '' OR entity_id = $2)
+            AND ($3 = '' OR actor_id = $3)
+            AND ($4 = '' OR action = $4)
+            AND ($5::timestamptz IS NULL OR occurred_at >= $5)
+            AND ($6::timestamptz IS NULL OR occurred_at < $6)
+            AND ($7::bigint = 0 OR id < $7)
+        ORDER BY id DESC
+        LIMIT $8
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q,
+		filter.EntityType,
+		filter.EntityID,
+		filter.ActorID,
+		filter.Action,
+		filter.From,
+		filter.To,
+		filter.BeforeID,
+		filter.Limit,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.AuditEvent
+
+	for rows.Next() {
+		var (
+			event         domain.AuditEvent
+			before, after []byte
+		)
+		if err := rows.Scan(
+			&event.ID,
+			&event.OccurredAt,
+			&event.ActorID,
+			&event.Action,
+			&event.EntityType,
+			&event.EntityID,
+			&before,
+			&after,
+		); err != nil {
+			return nil, err
+		}
+		event.Before = before
+		event.After = after
+		list = append(list, event)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *AuditPostgres) RedactEntity(ctx context.Context, entityType string, entityID string) error {
+	log := logger.L()
+
+	q := `
+        UPDATE audit_events
+        SET before = NULL, after = NULL
+        WHERE entity_type = $1 AND entity_id = $2
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, entityType, entityID)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func nullJSON(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
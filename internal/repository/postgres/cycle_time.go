@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+// mergedPRs selects the pull requests p merged within the window of a
+// cycle time filter passed with cycleTimeArgs, with the seconds they took
+// to merge.
+const mergedPRs = `
+        SELECT p.team_name,
+               GREATEST(EXTRACT(EPOCH FROM p.merged_at - p.created_at), 0) AS seconds
+        FROM pull_requests p
+        WHERE p.status = 'MERGED'
+          AND p.merged_at IS NOT NULL
+          AND ($1::timestamptz IS NULL OR p.merged_at >= $1)
+          AND ($2::timestamptz IS NULL OR p.merged_at < $2)
+          AND ($3 = '' OR p.team_name = $3)
+`
+
+// approvals selects the approvals given within the window of a cycle time
+// filter passed with cycleTimeArgs, with the seconds they took since the
+// reviewer was assigned.
+const approvals = `
+        SELECT p.team_name,
+               r.reviewer_id,
+               GREATEST(EXTRACT(EPOCH FROM r.verdict_at - r.assigned_at), 0) AS seconds
+        FROM pull_request_reviewers r
+        JOIN pull_requests p ON p.id = r.pr_id
+        WHERE r.verdict = 'APPROVED'
+          AND r.verdict_at IS NOT NULL
+          AND ($1::timestamptz IS NULL OR r.verdict_at >= $1)
+          AND ($2::timestamptz IS NULL OR r.verdict_at < $2)
+          AND ($3 = '' OR p.team_name = $3)
+`
+
+// durationStat sums up the seconds column of a set of rows.
+const durationStat = `
+               COUNT(*) AS n,
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds) AS p50,
+               percentile_cont(0.9) WITHIN GROUP (ORDER BY seconds) AS p90
+`
+
+func cycleTimeArgs(filter domain.CycleTimeFilter) []any {
+	return []any{filter.From, filter.To, filter.TeamName}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Second)
+}
+
+func (r *StatsPostgres) CycleTimeByTeam(
+	ctx context.Context,
+	filter domain.CycleTimeFilter,
+) ([]domain.TeamCycleTime, error) {
+	log := logger.L()
+
+	q := `
+        WITH merged AS (
+            SELECT team_name,` + durationStat + `
+            FROM (` + mergedPRs + `) m
+            WHERE team_name IS NOT NULL
+            GROUP BY team_name
+        ), approved AS (
+            SELECT team_name,` + durationStat + `
+            FROM (` + approvals + `) a
+            WHERE team_name IS NOT NULL
+            GROUP BY team_name
+        )
+        SELECT COALESCE(m.team_name, a.team_name) AS team_name,
+               COALESCE(m.n, 0), COALESCE(m.p50, 0), COALESCE(m.p90, 0),
+               COALESCE(a.n, 0), COALESCE(a.p50, 0), COALESCE(a.p90, 0)
+        FROM merged m
+        FULL JOIN approved a ON a.team_name = m.team_name
+        ORDER BY team_name
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, cycleTimeArgs(filter)...)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []domain.TeamCycleTime
+	for rows.Next() {
+		var (
+			s                  domain.TeamCycleTime
+			mergeP50, mergeP90 float64
+			apprP50, apprP90   float64
+		)
+		if err := rows.Scan(
+			&s.TeamName,
+			&s.TimeToMerge.Count, &mergeP50, &mergeP90,
+			&s.TimeToApproval.Count, &apprP50, &apprP90,
+		); err != nil {
+			return nil, err
+		}
+		s.TimeToMerge.Median, s.TimeToMerge.P90 = seconds(mergeP50), seconds(mergeP90)
+		s.TimeToApproval.Median, s.TimeToApproval.P90 = seconds(apprP50), seconds(apprP90)
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *StatsPostgres) CycleTimeByReviewer(
+	ctx context.Context,
+	filter domain.CycleTimeFilter,
+) ([]domain.ReviewerCycleTime, error) {
+	log := logger.L()
+
+	q := `
+        SELECT reviewer_id,` + durationStat + `
+        FROM (` + approvals + `) a
+        GROUP BY reviewer_id
+        ORDER BY reviewer_id
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, cycleTimeArgs(filter)...)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []domain.ReviewerCycleTime
+	for rows.Next() {
+		var (
+			s        domain.ReviewerCycleTime
+			p50, p90 float64
+		)
+		if err := rows.Scan(&s.UserID, &s.TimeToApproval.Count, &p50, &p90); err != nil {
+			return nil, err
+		}
+		s.TimeToApproval.Median, s.TimeToApproval.P90 = seconds(p50), seconds(p90)
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *StatsPostgres) OpenAgeByTeam(
+	ctx context.Context,
+	teamName string,
+	asOf time.Time,
+	bounds []time.Duration,
+) (map[string][]int, error) {
+	log := logger.L()
+
+	thresholds := make([]float64, len(bounds))
+	for i, b := range bounds {
+		thresholds[i] = b.Seconds()
+	}
+
+	// width_bucket numbers the buckets from 0, for the ages below the
+	// first threshold, to len(bounds).
+	q := `
+        SELECT p.team_name,
+               width_bucket(EXTRACT(EPOCH FROM $1::timestamptz - p.created_at)::float8, $3::float8[]) AS bucket,
+               COUNT(*)
+        FROM pull_requests p
+        WHERE p.team_name IS NOT NULL
+          AND p.created_at <= $1
+          AND (p.merged_at IS NULL OR p.merged_at > $1)
+          AND (p.closed_at IS NULL OR p.closed_at > $1)
+          AND ($2 = '' OR p.team_name = $2)
+        GROUP BY p.team_name, bucket
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, asOf, teamName, pq.Array(thresholds))
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string][]int{}
+	for rows.Next() {
+		var (
+			team          string
+			bucket, count int
+		)
+		if err := rows.Scan(&team, &bucket, &count); err != nil {
+			return nil, err
+		}
+		if counts[team] == nil {
+			counts[team] = make([]int, len(bounds)+1)
+		}
+		counts[team][bucket] = count
+	}
+
+	return counts, rows.Err()
+}
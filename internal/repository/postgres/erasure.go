@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type ErasurePostgres struct {
+	db *sql.DB
+}
+
+func NewErasurePostgres(db *sql.DB) repository.ErasureRepository {
+	return &ErasurePostgres{db: db}
+}
+
+func (r *ErasurePostgres) Create(ctx context.Context, erasure *domain.UserErasure) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO user_erasures (user_id, requested_by, reassigned_reviews, removed_reviews, erased_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		erasure.UserID,
+		erasure.RequestedBy,
+		erasure.ReassignedReviews,
+		erasure.RemovedReviews,
+		erasure.ErasedAt,
+	).Scan(&erasure.ID)
+
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+
+	return err
+}
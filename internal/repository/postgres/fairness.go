@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+func (r *StatsPostgres) FairnessMembers(
+	ctx context.Context,
+	teamName string,
+	from, to time.Time,
+) ([]domain.FairnessMember, error) {
+	log := logger.L()
+
+	q := `
+        SELECT tm.team_name, u.id, u.username, COUNT(a.pr_id)
+        FROM team_members tm
+        JOIN users u ON u.id = tm.user_id
+        LEFT JOIN (
+            SELECT r.reviewer_id, r.pr_id, p.team_name
+            FROM pull_request_reviewers r
+            JOIN pull_requests p ON p.id = r.pr_id
+            WHERE r.assigned_at >= $2 AND r.assigned_at < $3
+        ) a ON a.reviewer_id = tm.user_id AND a.team_name = tm.team_name
+        WHERE ($1 = '' OR tm.team_name = $1)
+          AND u.erased_at IS NULL
+        GROUP BY tm.team_name, u.id, u.username
+        ORDER BY tm.team_name, u.id
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, teamName, from, to)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []domain.FairnessMember
+	for rows.Next() {
+		var m domain.FairnessMember
+		if err := rows.Scan(&m.TeamName, &m.UserID, &m.Username, &m.Assignments); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+// Activity reads the activity of users from the is_active field of their
+// audit snapshots. A user without a snapshot before from was in the state
+// the first later snapshot changed, or, without any, is taken to have been
+// in their current state all along.
+func (r *StatsPostgres) Activity(
+	ctx context.Context,
+	userIDs []string,
+	from, to time.Time,
+) (map[string]domain.Activity, error) {
+	log := logger.L()
+
+	q := `
+        SELECT u.id,
+               COALESCE(
+                   (SELECT (a.after->>'is_active')::bool
+                    FROM audit_events a
+                    WHERE a.entity_type = 'user'
+                      AND a.entity_id = u.id
+                      AND a.after->>'is_active' IS NOT NULL
+                      AND a.occurred_at <= $2
+                    ORDER BY a.id DESC
+                    LIMIT 1),
+                   (SELECT COALESCE((a.before->>'is_active')::bool, FALSE)
+                    FROM audit_events a
+                    WHERE a.entity_type = 'user'
+                      AND a.entity_id = u.id
+                      AND a.after->>'is_active' IS NOT NULL
+                      AND a.occurred_at > $2
+                    ORDER BY a.id
+                    LIMIT 1),
+                   u.is_active
+               )
+        FROM users u
+        WHERE u.id = ANY($1)
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, pq.Array(userIDs), from)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	activity := make(map[string]domain.Activity, len(userIDs))
+	for rows.Next() {
+		var (
+			userID string
+			a      domain.Activity
+		)
+		if err := rows.Scan(&userID, &a.ActiveAtStart); err != nil {
+			return nil, err
+		}
+		activity[userID] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	q = `
+        SELECT a.entity_id, a.occurred_at, (a.after->>'is_active')::bool
+        FROM audit_events a
+        WHERE a.entity_type = 'user'
+          AND a.entity_id = ANY($1)
+          AND a.after->>'is_active' IS NOT NULL
+          AND a.occurred_at > $2
+          AND a.occurred_at < $3
+        ORDER BY a.id
+    `
+	rows, err = conn(ctx, r.db).QueryContext(ctx, q, pq.Array(userIDs), from, to)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			userID string
+			c      domain.ActivityChange
+		)
+		if err := rows.Scan(&userID, &c.At, &c.Active); err != nil {
+			return nil, err
+		}
+		a := activity[userID]
+		a.Changes = append(a.Changes, c)
+		activity[userID] = a
+	}
+
+	return activity, rows.Err()
+}
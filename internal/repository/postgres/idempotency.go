@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type IdempotencyPostgres struct {
+	db *sql.DB
+}
+
+func NewIdempotencyPostgres(db *sql.DB) repository.IdempotencyRepository {
+	return &IdempotencyPostgres{db: db}
+}
+
+func (r *IdempotencyPostgres) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (bool, error) {
+	log := logger.L()
+
+	// An expired record is taken over in place so that the key can be
+	// reused once its TTL has passed, even before the cleanup job runs. So
+	// is the reservation of a request that stopped renewing its lease,
+	// unless it was made for a different request.
+	q := `
+        INSERT INTO idempotency_keys (key, method, path, request_hash, created_at, expires_at, locked_until)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (key, method, path) DO UPDATE
+        SET request_hash = EXCLUDED.request_hash,
+            status_code = NULL,
+            headers = '{}'::jsonb,
+            response_body = NULL,
+            created_at = EXCLUDED.created_at,
+            expires_at = EXCLUDED.expires_at,
+            locked_until = EXCLUDED.locked_until
+        WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+           OR (idempotency_keys.status_code IS NULL
+               AND idempotency_keys.locked_until <= EXCLUDED.created_at
+               AND idempotency_keys.request_hash = EXCLUDED.request_hash)
+    `
+	res, err := conn(ctx, r.db).ExecContext(ctx, q,
+		rec.Key, rec.Method, rec.Path, rec.RequestHash, rec.CreatedAt, rec.ExpiresAt, rec.LockedUntil,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+func (r *IdempotencyPostgres) Get(ctx context.Context, key, method, path string) (*domain.IdempotencyRecord, error) {
+	log := logger.L()
+
+	q := `
+        SELECT key, method, path, request_hash, COALESCE(status_code, 0), headers,
+               response_body, created_at, expires_at, locked_until
+        FROM idempotency_keys
+        WHERE key = $1 AND method = $2 AND path = $3
+    `
+	var (
+		rec         domain.IdempotencyRecord
+		headers     []byte
+		lockedUntil sql.NullTime
+	)
+	err := conn(ctx, r.db).QueryRowContext(ctx, q, key, method, path).Scan(
+		&rec.Key,
+		&rec.Method,
+		&rec.Path,
+		&rec.RequestHash,
+		&rec.StatusCode,
+		&headers,
+		&rec.Body,
+		&rec.CreatedAt,
+		&rec.ExpiresAt,
+		&lockedUntil,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	if err := json.Unmarshal(headers, &rec.Headers); err != nil {
+		return nil, err
+	}
+	rec.LockedUntil = lockedUntil.Time
+
+	return &rec, nil
+}
+
+func (r *IdempotencyPostgres) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	log := logger.L()
+
+	headers, err := json.Marshal(rec.Headers)
+	if err != nil {
+		return err
+	}
+
+	q := `
+        UPDATE idempotency_keys
+        SET status_code = $4, headers = $5, response_body = $6, locked_until = NULL
+        WHERE key = $1 AND method = $2 AND path = $3
+    `
+	_, err = conn(ctx, r.db).ExecContext(ctx, q,
+		rec.Key, rec.Method, rec.Path, rec.StatusCode, string(headers), rec.Body,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *IdempotencyPostgres) Renew(ctx context.Context, key, method, path string, lockedUntil time.Time) error {
+	log := logger.L()
+
+	q := `
+        UPDATE idempotency_keys
+        SET locked_until = $4
+        WHERE key = $1 AND method = $2 AND path = $3 AND status_code IS NULL
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, key, method, path, lockedUntil)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *IdempotencyPostgres) Delete(ctx context.Context, key, method, path string) error {
+	log := logger.L()
+
+	q := `
+        DELETE FROM idempotency_keys WHERE key = $1 AND method = $2 AND path = $3
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, key, method, path)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *IdempotencyPostgres) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	log := logger.L()
+
+	q := `
+        DELETE FROM idempotency_keys WHERE expires_at <= $1
+    `
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, now)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
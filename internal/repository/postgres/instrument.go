@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// timedQuerier records how long every statement takes under the repository
+// method that issued it.
+type timedQuerier struct {
+	q        querier
+	observer prometheus.Observer
+}
+
+func (t timedQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	defer t.observe(time.Now())
+	return t.q.ExecContext(ctx, query, args...)
+}
+
+func (t timedQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	defer t.observe(time.Now())
+	return t.q.QueryContext(ctx, query, args...)
+}
+
+func (t timedQuerier) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	defer t.observe(time.Now())
+	return t.q.QueryRowContext(ctx, query, args...)
+}
+
+func (t timedQuerier) observe(start time.Time) {
+	t.observer.Observe(time.Since(start).Seconds())
+}
+
+// observers caches the observer of every call site.
+var observers sync.Map
+
+// callerObserver returns the observer of the repository method skip frames
+// above the caller of callerObserver.
+func callerObserver(skip int) prometheus.Observer {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return metrics.DBQueryDuration.WithLabelValues("unknown", "unknown")
+	}
+
+	if o, ok := observers.Load(pc); ok {
+		return o.(prometheus.Observer)
+	}
+
+	repo, method := "unknown", "unknown"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		repo, method = repositoryMethod(fn.Name())
+	}
+	o := metrics.DBQueryDuration.WithLabelValues(repo, method)
+	observers.Store(pc, o)
+
+	return o
+}
+
+// repositoryMethod splits a function name such as
+// "github.com/.../postgres.(*PRPostgres).Merge.func1" into the repository
+// and the method. Plain functions count under the package.
+func repositoryMethod(fn string) (string, string) {
+	fn = fn[strings.LastIndex(fn, "/")+1:]
+	pkg, fn, _ := strings.Cut(fn, ".")
+
+	parts := strings.Split(fn, ".")
+	if len(parts) > 1 && strings.HasPrefix(parts[0], "(") {
+		return strings.Trim(parts[0], "(*)"), parts[1]
+	}
+	return pkg, parts[0]
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"hash/fnv"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// AdvisoryLocker elects job runners with session-level advisory locks. A
+// lock is held on a connection taken out of the pool for as long as the job
+// runs, so it is released by Postgres when the instance dies.
+type AdvisoryLocker struct {
+	db *sql.DB
+}
+
+func NewAdvisoryLocker(db *sql.DB) repository.JobLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+func (l *AdvisoryLocker) TryLock(ctx context.Context, jobName string) (func(), bool, error) {
+	log := logger.L()
+
+	c, err := l.db.Conn(ctx)
+	if err != nil {
+		log.Error("failed to get connection for advisory lock", slog.Any("err", err))
+		return nil, false, err
+	}
+
+	key := jobLockKey(jobName)
+
+	q := `
+        SELECT pg_try_advisory_lock($1)
+    `
+	var locked bool
+	if err := c.QueryRowContext(ctx, q, key).Scan(&locked); err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		_ = c.Close()
+		return nil, false, err
+	}
+	if !locked {
+		_ = c.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		q := `
+            SELECT pg_advisory_unlock($1)
+        `
+		if _, err := c.ExecContext(context.Background(), q, key); err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			// A connection that may still hold the lock must not go back
+			// to the pool; closing it releases the lock.
+			_ = c.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		_ = c.Close()
+	}
+
+	return release, true, nil
+}
+
+// jobLockKey maps a job name onto the bigint key space of advisory locks.
+func jobLockKey(jobName string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("scheduler:" + jobName))
+	return int64(h.Sum64())
+}
+
+type JobRunPostgres struct {
+	db *sql.DB
+}
+
+func NewJobRunPostgres(db *sql.DB) repository.JobRunRepository {
+	return &JobRunPostgres{db: db}
+}
+
+func (r *JobRunPostgres) Start(ctx context.Context, run *domain.JobRun) (bool, error) {
+	log := logger.L()
+
+	q := `
+        INSERT INTO job_runs (job_name, scheduled_at, instance, status, started_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (job_name, scheduled_at) DO NOTHING
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		run.JobName,
+		run.ScheduledAt,
+		run.Instance,
+		run.Status,
+		run.StartedAt,
+	).Scan(&run.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *JobRunPostgres) Finish(ctx context.Context, run *domain.JobRun) error {
+	log := logger.L()
+
+	q := `
+        UPDATE job_runs
+        SET status = $2,
+            finished_at = $3,
+            error = $4
+        WHERE id = $1
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		run.ID,
+		run.Status,
+		run.FinishedAt,
+		run.Error,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *JobRunPostgres) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	log := logger.L()
+
+	q := `
+        DELETE FROM job_runs WHERE started_at < $1
+    `
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, before)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
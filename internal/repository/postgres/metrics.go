@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type MetricsPostgres struct {
+	db *sql.DB
+}
+
+func NewMetricsPostgres(db *sql.DB) repository.MetricsRepository {
+	return &MetricsPostgres{db: db}
+}
+
+// OpenPRsByTeam reads the counters kept for /stats.
+func (r *MetricsPostgres) OpenPRsByTeam(ctx context.Context) (map[string]int, error) {
+	q := `
+        SELECT team_name, open
+        FROM stats_team_counts
+        WHERE open > 0
+    `
+	return countBy(ctx, conn(ctx, r.db), q)
+}
+
+func (r *MetricsPostgres) PendingReviewsByUser(ctx context.Context) (map[string]int, error) {
+	q := `
+        SELECT r.reviewer_id, COUNT(*)
+        FROM pull_request_reviewers r
+        JOIN pull_requests p ON p.id = r.pr_id
+        WHERE p.status = 'OPEN'
+          AND r.verdict IS NULL
+        GROUP BY r.reviewer_id
+    `
+	return countBy(ctx, conn(ctx, r.db), q)
+}
+
+// countBy reads the rows of a key and a count into a map.
+func countBy(ctx context.Context, db querier, q string) (map[string]int, error) {
+	log := logger.L()
+
+	rows, err := db.QueryContext(ctx, q)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var (
+			key string
+			n   int
+		)
+		if err := rows.Scan(&key, &n); err != nil {
+			return nil, err
+		}
+		counts[key] = n
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type NotificationPostgres struct {
+	db *sql.DB
+}
+
+func NewNotificationPostgres(db *sql.DB) repository.NotificationRepository {
+	return &NotificationPostgres{db: db}
+}
+
+func (r *NotificationPostgres) GetPreferences(ctx context.Context, userID string) (*domain.NotificationPreferences, error) {
+	log := logger.L()
+
+	q := `
+        SELECT user_id, channels, language, mode, quiet_hours_start, quiet_hours_end, timezone, updated_at
+        FROM notification_preferences
+        WHERE user_id = $1
+    `
+	var (
+		p          domain.NotificationPreferences
+		channels   []string
+		quietStart sql.NullInt64
+		quietEnd   sql.NullInt64
+	)
+	err := conn(ctx, r.db).QueryRowContext(ctx, q, userID).Scan(
+		&p.UserID,
+		pq.Array(&channels),
+		&p.Language,
+		&p.Mode,
+		&quietStart,
+		&quietEnd,
+		&p.Timezone,
+		&p.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	p.Channels = make([]domain.NotificationChannel, 0, len(channels))
+	for _, c := range channels {
+		p.Channels = append(p.Channels, domain.NotificationChannel(c))
+	}
+	if quietStart.Valid && quietEnd.Valid {
+		p.QuietHours = &domain.QuietHours{Start: int(quietStart.Int64), End: int(quietEnd.Int64)}
+	}
+
+	return &p, nil
+}
+
+func (r *NotificationPostgres) SavePreferences(ctx context.Context, p *domain.NotificationPreferences) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO notification_preferences (
+            user_id, channels, language, mode, quiet_hours_start, quiet_hours_end, timezone, updated_at
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (user_id) DO UPDATE
+        SET channels = EXCLUDED.channels,
+            language = EXCLUDED.language,
+            mode = EXCLUDED.mode,
+            quiet_hours_start = EXCLUDED.quiet_hours_start,
+            quiet_hours_end = EXCLUDED.quiet_hours_end,
+            timezone = EXCLUDED.timezone,
+            updated_at = EXCLUDED.updated_at
+    `
+	channels := make([]string, 0, len(p.Channels))
+	for _, c := range p.Channels {
+		channels = append(channels, string(c))
+	}
+
+	var quietStart, quietEnd *int
+	if p.QuietHours != nil {
+		quietStart, quietEnd = &p.QuietHours.Start, &p.QuietHours.End
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		p.UserID,
+		pq.Array(channels),
+		p.Language,
+		p.Mode,
+		quietStart,
+		quietEnd,
+		p.Timezone,
+		p.UpdatedAt,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *NotificationPostgres) Enqueue(ctx context.Context, notifications []domain.Notification) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            INSERT INTO notifications (user_id, kind, pr_id, previous_reviewer_id, status, next_attempt_at, created_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7)
+            RETURNING id
+        `
+		for i := range notifications {
+			n := &notifications[i]
+			err := tx.QueryRowContext(ctx, q,
+				n.UserID,
+				n.Kind,
+				n.PRID,
+				n.PreviousReviewerID,
+				n.Status,
+				n.NextAttemptAt,
+				n.CreatedAt,
+			).Scan(&n.ID)
+			if err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *NotificationPostgres) EnqueueReminders(ctx context.Context, notifiedBefore time.Time, now time.Time) (int, error) {
+	log := logger.L()
+
+	q := `
+        INSERT INTO notifications (user_id, kind, pr_id, status, next_attempt_at, created_at)
+        SELECT r.reviewer_id, $1, r.pr_id, 'PENDING', $3, $3
+        FROM pull_request_reviewers r
+        JOIN pull_requests p ON p.id = r.pr_id
+        WHERE p.status = 'OPEN'
+          AND p.created_at <= $2
+          AND r.verdict IS NULL
+          AND NOT EXISTS (
+              SELECT 1
+              FROM notifications n
+              WHERE n.user_id = r.reviewer_id
+                AND n.pr_id = r.pr_id
+                AND n.created_at > $2
+          )
+    `
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, domain.NotificationReviewReminder, notifiedBefore, now)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *NotificationPostgres) EnqueueMissing(
+	ctx context.Context,
+	notifications []domain.Notification,
+	since time.Time,
+) (int, error) {
+	log := logger.L()
+
+	queued := 0
+	err := runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            INSERT INTO notifications (user_id, kind, pr_id, previous_reviewer_id, status, next_attempt_at, created_at)
+            SELECT $1::text, $2::text, $3::text, $4::text, $5::text, $6::timestamptz, $7::timestamptz
+            WHERE NOT EXISTS (
+                SELECT 1
+                FROM notifications n
+                WHERE n.user_id = $1
+                  AND n.kind = $2
+                  AND n.pr_id = $3
+                  AND (n.status = 'PENDING' OR n.created_at > $8)
+            )
+            RETURNING id
+        `
+		for i := range notifications {
+			n := &notifications[i]
+			err := tx.QueryRowContext(ctx, q,
+				n.UserID,
+				n.Kind,
+				n.PRID,
+				n.PreviousReviewerID,
+				n.Status,
+				n.NextAttemptAt,
+				n.CreatedAt,
+				since,
+			).Scan(&n.ID)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+			queued++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return queued, nil
+}
+
+func (r *NotificationPostgres) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+) ([]domain.Notification, error) {
+	log := logger.L()
+
+	q := `
+        WITH due AS (
+            SELECT id
+            FROM notifications
+            WHERE status = 'PENDING' AND next_attempt_at <= $1
+            ORDER BY next_attempt_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE notifications n
+        SET next_attempt_at = $2
+        FROM due
+        WHERE n.id = due.id
+        RETURNING n.id, n.user_id, n.kind, n.pr_id, n.previous_reviewer_id, n.status,
+                  n.attempts, n.next_attempt_at, n.sent_at, n.last_error, n.created_at
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, now, leaseUntil, limit)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Notification
+
+	for rows.Next() {
+		var n domain.Notification
+		if err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.Kind,
+			&n.PRID,
+			&n.PreviousReviewerID,
+			&n.Status,
+			&n.Attempts,
+			&n.NextAttemptAt,
+			&n.SentAt,
+			&n.LastError,
+			&n.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *NotificationPostgres) SaveAttempt(ctx context.Context, n *domain.Notification) error {
+	log := logger.L()
+
+	q := `
+        UPDATE notifications
+        SET status = $2,
+            attempts = $3,
+            next_attempt_at = $4,
+            sent_at = $5,
+            last_error = $6
+        WHERE id = $1
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		n.ID,
+		n.Status,
+		n.Attempts,
+		n.NextAttemptAt,
+		n.SentAt,
+		n.LastError,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type OutboxPostgres struct {
+	db *sql.DB
+}
+
+func NewOutboxPostgres(db *sql.DB) repository.OutboxRepository {
+	return &OutboxPostgres{db: db}
+}
+
+func (r *OutboxPostgres) Append(ctx context.Context, event *domain.OutboxEvent) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO outbox_events (event_type, payload, created_at)
+        VALUES ($1, $2, $3)
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		event.Type,
+		string(event.Payload),
+		event.CreatedAt,
+	).Scan(&event.ID)
+
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+
+	return err
+}
+
+func (r *OutboxPostgres) RedactUser(ctx context.Context, userID string, pseudonym string) error {
+	log := logger.L()
+
+	q := `
+        WITH events AS (
+            UPDATE outbox_events
+            SET payload = jsonb_set(payload, '{username}', to_jsonb($2::text))
+            WHERE event_type LIKE 'user.%' AND payload->>'id' = $1 AND payload ? 'username'
+            RETURNING id
+        )
+        DELETE FROM webhook_deliveries d
+        USING events e
+        WHERE d.event_id = e.id AND d.status = 'PENDING'
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, userID, pseudonym)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
@@ -23,45 +23,42 @@ func NewPRPostgres(db *sql.DB) repository.PRRepository {
 func (r *PRPostgres) Create(ctx context.Context, pr *domain.PullRequest) error {
 	log := logger.L()
 
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		log.Error("failed to begin transaction", slog.Any("err", err))
-		return err
-	}
-
-	q := `
-        INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at)
-        VALUES ($1, $2, $3, $4, $5, $6)
-    `
-	_, err = tx.ExecContext(ctx, q,
-		pr.ID, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt,
-	)
-	if err != nil {
-		tx.Rollback()
-		log.Error("failed to execute SQL",
-			slog.String("query", q),
-			slog.Any("err", err),
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            INSERT INTO pull_requests (id, name, author_id, team_name, status, created_at, merged_at)
+            VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7)
+        `
+		_, err := tx.ExecContext(ctx, q,
+			pr.ID, pr.Name, pr.AuthorID, pr.TeamName, pr.Status, pr.CreatedAt, pr.MergedAt,
 		)
-		return err
-	}
-
-	q = `
-        INSERT INTO pull_request_reviewers (pr_id, reviewer_id)
-        VALUES ($1, $2)
-	`
-	for _, reviewer := range pr.AssignedReviewers {
-		_, err = tx.ExecContext(ctx, q, pr.ID, reviewer)
+		if isUniqueViolation(err) {
+			return domain.ErrPRExists
+		}
 		if err != nil {
-			tx.Rollback()
 			log.Error("failed to execute SQL",
 				slog.String("query", q),
 				slog.Any("err", err),
 			)
 			return err
 		}
-	}
 
-	return tx.Commit()
+		q = `
+            INSERT INTO pull_request_reviewers (pr_id, reviewer_id)
+            VALUES ($1, $2)
+        `
+		for _, reviewer := range pr.AssignedReviewers {
+			_, err = tx.ExecContext(ctx, q, pr.ID, reviewer)
+			if err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 func (r *PRPostgres) Exists(ctx context.Context, id string) (bool, error) {
@@ -70,7 +67,7 @@ func (r *PRPostgres) Exists(ctx context.Context, id string) (bool, error) {
 	q := `
         SELECT 1 FROM pull_requests WHERE id = $1
     `
-	row := r.db.QueryRowContext(ctx, q, id)
+	row := conn(ctx, r.db).QueryRowContext(ctx, q, id)
 
 	var dummy int
 	err := row.Scan(&dummy)
@@ -88,23 +85,36 @@ func (r *PRPostgres) Exists(ctx context.Context, id string) (bool, error) {
 }
 
 func (r *PRPostgres) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate locks the pull request row until the surrounding
+// transaction ends, so concurrent merges and reassignments are serialized.
+func (r *PRPostgres) GetByIDForUpdate(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return r.getByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *PRPostgres) getByID(ctx context.Context, id string, lock string) (*domain.PullRequest, error) {
 	log := logger.L()
 
 	q := `
-        SELECT id, name, author_id, status, created_at, merged_at
+        SELECT id, name, author_id, COALESCE(team_name, ''), status, created_at, merged_at, closed_at, version
         FROM pull_requests
         WHERE id = $1
-    `
-	row := r.db.QueryRowContext(ctx, q, id)
+    ` + lock
+	row := conn(ctx, r.db).QueryRowContext(ctx, q, id)
 
 	var pr domain.PullRequest
 	if err := row.Scan(
 		&pr.ID,
 		&pr.Name,
 		&pr.AuthorID,
+		&pr.TeamName,
 		&pr.Status,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.ClosedAt,
+		&pr.Version,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrPRNotFound
@@ -116,30 +126,13 @@ func (r *PRPostgres) GetByID(ctx context.Context, id string) (*domain.PullReques
 		return nil, err
 	}
 
-	q = `
-        SELECT reviewer_id FROM pull_request_reviewers
-        WHERE pr_id = $1
-    `
-	rows, err := r.db.QueryContext(ctx, q, id)
+	reviewers, verdicts, err := r.fetchReviewers(ctx, pr.ID)
 	if err != nil {
-		log.Error("failed to execute SQL",
-			slog.String("query", q),
-			slog.Any("err", err),
-		)
 		return nil, err
 	}
-	defer rows.Close()
-
-	var reviewers []string
-	for rows.Next() {
-		var rid string
-		if err := rows.Scan(&rid); err != nil {
-			return nil, err
-		}
-		reviewers = append(reviewers, rid)
-	}
 
 	pr.AssignedReviewers = reviewers
+	pr.Verdicts = verdicts
 	return &pr, nil
 }
 
@@ -147,12 +140,12 @@ func (r *PRPostgres) ListByReviewer(ctx context.Context, reviewerID string) ([]d
 	log := logger.L()
 
 	q := `
-        SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at, pr.merged_at
+        SELECT pr.id, pr.name, pr.author_id, COALESCE(pr.team_name, ''), pr.status, pr.created_at, pr.merged_at, pr.closed_at, pr.version
         FROM pull_requests pr
         JOIN pull_request_reviewers r ON pr.id = r.pr_id
         WHERE r.reviewer_id = $1
     `
-	rows, err := r.db.QueryContext(ctx, q, reviewerID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, reviewerID)
 	if err != nil {
 		log.Error("failed to execute SQL",
 			slog.String("query", q),
@@ -170,20 +163,29 @@ func (r *PRPostgres) ListByReviewer(ctx context.Context, reviewerID string) ([]d
 			&pr.ID,
 			&pr.Name,
 			&pr.AuthorID,
+			&pr.TeamName,
 			&pr.Status,
 			&pr.CreatedAt,
 			&pr.MergedAt,
+			&pr.ClosedAt,
+			&pr.Version,
 		); err != nil {
 			return nil, err
 		}
+		list = append(list, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
 
-		revs, err := r.fetchReviewers(ctx, pr.ID)
+	for i := range list {
+		revs, verdicts, err := r.fetchReviewers(ctx, list[i].ID)
 		if err != nil {
 			return nil, err
 		}
-		pr.AssignedReviewers = revs
-
-		list = append(list, pr)
+		list[i].AssignedReviewers = revs
+		list[i].Verdicts = verdicts
 	}
 
 	return list, nil
@@ -192,41 +194,123 @@ func (r *PRPostgres) ListByReviewer(ctx context.Context, reviewerID string) ([]d
 func (r *PRPostgres) UpdateReviewers(ctx context.Context, prID string, reviewers []string) error {
 	log := logger.L()
 
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            DELETE FROM pull_request_reviewers WHERE pr_id = $1
+        `
+		_, err := tx.ExecContext(ctx, q, prID)
+		if err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
 
-	q := `
-        DELETE FROM pull_request_reviewers WHERE pr_id = $1
-    `
-	_, err = tx.ExecContext(ctx, q, prID)
-	if err != nil {
-		tx.Rollback()
-		log.Error("failed to execute SQL",
-			slog.String("query", q),
-			slog.Any("err", err),
-		)
-		return err
-	}
+		for _, rid := range reviewers {
+			q = `
+                INSERT INTO pull_request_reviewers (pr_id, reviewer_id)
+                VALUES ($1, $2)
+            `
+			_, err = tx.ExecContext(ctx, q, prID, rid)
+			if err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
 
-	for _, rid := range reviewers {
-		q = `
-            INSERT INTO pull_request_reviewers (pr_id, reviewer_id)
-            VALUES ($1, $2)
+		return bumpVersion(ctx, tx, prID)
+	})
+}
+
+func (r *PRPostgres) RemoveReviewer(ctx context.Context, prID string, reviewerID string) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            DELETE FROM pull_request_reviewers WHERE pr_id = $1 AND reviewer_id = $2
         `
-		_, err = tx.ExecContext(ctx, q, prID, rid)
+		_, err := tx.ExecContext(ctx, q, prID, reviewerID)
 		if err != nil {
-			tx.Rollback()
 			log.Error("failed to execute SQL",
 				slog.String("query", q),
 				slog.Any("err", err),
 			)
 			return err
 		}
-	}
 
-	return tx.Commit()
+		return bumpVersion(ctx, tx, prID)
+	})
+}
+
+func (r *PRPostgres) ReplaceReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            UPDATE pull_request_reviewers
+            SET reviewer_id = $3, verdict = NULL, verdict_at = NULL,
+                assigned_at = now(), sla_breached_at = NULL, escalated_at = NULL
+            WHERE pr_id = $1 AND reviewer_id = $2
+        `
+		res, err := tx.ExecContext(ctx, q, prID, oldReviewerID, newReviewerID)
+		if err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return domain.ErrNotAssigned
+		}
+
+		return bumpVersion(ctx, tx, prID)
+	})
+}
+
+func (r *PRPostgres) SetVerdict(
+	ctx context.Context,
+	prID string,
+	reviewerID string,
+	verdict domain.ReviewVerdict,
+	at time.Time,
+) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            UPDATE pull_request_reviewers
+            SET verdict = $3, verdict_at = $4
+            WHERE pr_id = $1 AND reviewer_id = $2
+        `
+		res, err := tx.ExecContext(ctx, q, prID, reviewerID, verdict, at)
+		if err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return domain.ErrNotAssigned
+		}
+
+		return bumpVersion(ctx, tx, prID)
+	})
 }
 
 func (r *PRPostgres) UpdateStatusAndMergedAt(
@@ -239,10 +323,10 @@ func (r *PRPostgres) UpdateStatusAndMergedAt(
 
 	q := `
         UPDATE pull_requests
-        SET status = $2, merged_at = $3
+        SET status = $2, merged_at = $3, version = version + 1
         WHERE id = $1
     `
-	_, err := r.db.ExecContext(ctx, q,
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
 		id, status, mergedAt,
 	)
 	if err != nil {
@@ -254,14 +338,73 @@ func (r *PRPostgres) UpdateStatusAndMergedAt(
 	return err
 }
 
-func (r *PRPostgres) fetchReviewers(ctx context.Context, prID string) ([]string, error) {
+func (r *PRPostgres) UpdateStatusAndClosedAt(
+	ctx context.Context,
+	id string,
+	status domain.PRStatus,
+	closedAt *time.Time,
+) error {
 	log := logger.L()
 
 	q := `
-        SELECT reviewer_id FROM pull_request_reviewers
+        UPDATE pull_requests
+        SET status = $2, closed_at = $3, version = version + 1
+        WHERE id = $1
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		id, status, closedAt,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *PRPostgres) SetSource(ctx context.Context, id string, source domain.PRSource) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO pr_sources (pr_id, provider, repository, number, url)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (pr_id) DO UPDATE
+        SET provider = EXCLUDED.provider,
+            repository = EXCLUDED.repository,
+            number = EXCLUDED.number,
+            url = EXCLUDED.url
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		id, source.Provider, source.Repository, source.Number, source.URL,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *PRPostgres) GetSource(ctx context.Context, id string) (*domain.PRSource, error) {
+	log := logger.L()
+
+	q := `
+        SELECT provider, repository, number, url
+        FROM pr_sources
         WHERE pr_id = $1
     `
-	rows, err := r.db.QueryContext(ctx, q, prID)
+	var src domain.PRSource
+	err := conn(ctx, r.db).QueryRowContext(ctx, q, id).Scan(
+		&src.Provider,
+		&src.Repository,
+		&src.Number,
+		&src.URL,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
 		log.Error("failed to execute SQL",
 			slog.String("query", q),
@@ -269,16 +412,64 @@ func (r *PRPostgres) fetchReviewers(ctx context.Context, prID string) ([]string,
 		)
 		return nil, err
 	}
+
+	return &src, nil
+}
+
+func (r *PRPostgres) fetchReviewers(ctx context.Context, prID string) ([]string, map[string]domain.ReviewVerdict, error) {
+	log := logger.L()
+
+	q := `
+        SELECT reviewer_id, COALESCE(verdict, '') FROM pull_request_reviewers
+        WHERE pr_id = $1
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, prID)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, nil, err
+	}
 	defer rows.Close()
 
-	var reviewers []string
+	var (
+		reviewers []string
+		verdicts  map[string]domain.ReviewVerdict
+	)
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return nil, err
+		var (
+			id      string
+			verdict string
+		)
+		if err := rows.Scan(&id, &verdict); err != nil {
+			return nil, nil, err
 		}
 		reviewers = append(reviewers, id)
+		if verdict != "" {
+			if verdicts == nil {
+				verdicts = make(map[string]domain.ReviewVerdict)
+			}
+			verdicts[id] = domain.ReviewVerdict(verdict)
+		}
 	}
 
-	return reviewers, nil
+	return reviewers, verdicts, rows.Err()
+}
+
+// bumpVersion marks the pull request as changed. Every write to a pull
+// request or its reviewers goes through it, so clients holding an older
+// version get a precondition failure instead of overwriting newer state.
+func bumpVersion(ctx context.Context, q querier, prID string) error {
+	query := `
+        UPDATE pull_requests SET version = version + 1 WHERE id = $1
+    `
+	if _, err := q.ExecContext(ctx, query, prID); err != nil {
+		logger.L().Error("failed to execute SQL",
+			slog.String("query", query),
+			slog.Any("err", err),
+		)
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type PREventPostgres struct {
+	db *sql.DB
+}
+
+func NewPREventPostgres(db *sql.DB) repository.PREventRepository {
+	return &PREventPostgres{db: db}
+}
+
+func (r *PREventPostgres) Append(ctx context.Context, events []domain.PREvent) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            INSERT INTO pr_events (
+                pr_id, type, occurred_at, actor_id, reviewer_id,
+                previous_reviewer_id, strategy, reason, verdict
+            )
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            RETURNING id
+        `
+		for i := range events {
+			e := &events[i]
+			err := tx.QueryRowContext(ctx, q,
+				e.PRID,
+				e.Type,
+				e.OccurredAt,
+				e.ActorID,
+				e.ReviewerID,
+				e.PreviousReviewerID,
+				e.Strategy,
+				e.Reason,
+				e.Verdict,
+			).Scan(&e.ID)
+			if err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *PREventPostgres) ListByPR(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	log := logger.L()
+
+	q := `
+        SELECT id, pr_id, type, occurred_at, actor_id, reviewer_id,
+               previous_reviewer_id, strategy, reason, verdict
+        FROM pr_events
+        WHERE pr_id = $1
+        ORDER BY id
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, prID)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.PREvent
+
+	for rows.Next() {
+		var e domain.PREvent
+		if err := rows.Scan(
+			&e.ID,
+			&e.PRID,
+			&e.Type,
+			&e.OccurredAt,
+			&e.ActorID,
+			&e.ReviewerID,
+			&e.PreviousReviewerID,
+			&e.Strategy,
+			&e.Reason,
+			&e.Verdict,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+
+	return list, rows.Err()
+}
+
+// streamedTypes keeps the SQL filter in line with domain.IsStreamedPREvent.
+var streamedTypes = pq.Array([]string{
+	string(domain.PREventReviewerAssigned),
+	string(domain.PREventReviewerReassigned),
+	string(domain.PREventMerged),
+})
+
+// streamPositionColumns select the domain.PRStreamPosition of e. xid8 has no
+// integer type in Go, so the transaction id goes through text.
+const streamPositionColumns = `e.tx_id::text::bigint, e.id`
+
+const streamColumns = `
+        e.id, e.pr_id, e.type, e.occurred_at, e.actor_id, e.reviewer_id,
+        e.previous_reviewer_id, e.strategy, e.reason, e.verdict,
+        p.name, p.author_id, COALESCE(p.team_name, ''), ` + streamPositionColumns + `
+`
+
+// streamHorizon keeps out the events of transactions that may still be
+// preceded by an older one: all transactions below the xmin of the
+// statement's snapshot have finished.
+const streamHorizon = `e.tx_id < pg_snapshot_xmin(pg_current_snapshot())`
+
+func (r *PREventPostgres) ListStreamAfter(
+	ctx context.Context,
+	after domain.PRStreamPosition,
+	filter domain.PRStreamFilter,
+	limit int,
+) ([]domain.PRStreamEvent, error) {
+	args := []any{after.TxID, after.EventID, streamedTypes, limit}
+	var where []string
+
+	if filter.TeamName != "" {
+		args = append(args, filter.TeamName)
+		where = append(where, fmt.Sprintf("p.team_name = $%d", len(args)))
+	}
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		n := len(args)
+		where = append(where, fmt.Sprintf("(e.reviewer_id = $%d OR e.previous_reviewer_id = $%d OR p.author_id = $%d)", n, n, n))
+	}
+
+	cond := ""
+	if len(where) > 0 {
+		cond = " AND " + strings.Join(where, " AND ")
+	}
+
+	q := `
+        SELECT` + streamColumns + `
+        FROM pr_events e
+        JOIN pull_requests p ON p.id = e.pr_id
+        WHERE (e.tx_id, e.id) > ($1::text::xid8, $2)
+          AND ` + streamHorizon + `
+          AND e.type = ANY($3)` + cond + `
+        ORDER BY e.tx_id, e.id
+        LIMIT $4
+    `
+	return r.queryStream(ctx, q, args...)
+}
+
+func (r *PREventPostgres) LastStreamPosition(ctx context.Context) (domain.PRStreamPosition, error) {
+	log := logger.L()
+
+	q := `
+        SELECT ` + streamPositionColumns + `
+        FROM pr_events e
+        WHERE ` + streamHorizon + `
+        ORDER BY e.tx_id DESC, e.id DESC
+        LIMIT 1
+    `
+	var pos domain.PRStreamPosition
+	err := conn(ctx, r.db).QueryRowContext(ctx, q).Scan(&pos.TxID, &pos.EventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PRStreamPosition{}, nil
+	}
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return domain.PRStreamPosition{}, err
+	}
+
+	return pos, nil
+}
+
+func (r *PREventPostgres) queryStream(ctx context.Context, q string, args ...any) ([]domain.PRStreamEvent, error) {
+	log := logger.L()
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.PRStreamEvent
+
+	for rows.Next() {
+		var e domain.PRStreamEvent
+		if err := rows.Scan(
+			&e.ID,
+			&e.PRID,
+			&e.Type,
+			&e.OccurredAt,
+			&e.ActorID,
+			&e.ReviewerID,
+			&e.PreviousReviewerID,
+			&e.Strategy,
+			&e.Reason,
+			&e.Verdict,
+			&e.PRName,
+			&e.AuthorID,
+			&e.TeamName,
+			&e.Position.TxID,
+			&e.Position.EventID,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+
+	return list, rows.Err()
+}
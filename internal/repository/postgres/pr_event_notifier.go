@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+// prEventsChannel is notified by the pr_events_notify trigger.
+const prEventsChannel = "pr_events"
+
+// PREventNotifier listens on a dedicated connection, since LISTEN does not
+// work through the database/sql pool.
+type PREventNotifier struct {
+	dsn string
+}
+
+func NewPREventNotifier(dsn string) repository.PREventNotifier {
+	return &PREventNotifier{dsn: dsn}
+}
+
+func (n *PREventNotifier) Listen(ctx context.Context) (<-chan int64, error) {
+	log := logger.L()
+
+	listener := pq.NewListener(n.dsn, time.Second, 30*time.Second, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warn("pr events listener connection problem",
+				slog.Int("event", int(ev)),
+				slog.Any("err", err),
+			)
+		}
+	})
+
+	if err := listener.Listen(prEventsChannel); err != nil {
+		log.Error("failed to listen for pr events", slog.Any("err", err))
+		_ = listener.Close()
+		return nil, err
+	}
+
+	out := make(chan int64, 64)
+
+	go func() {
+		defer close(out)
+		defer listener.Close()
+
+		// Pinging an idle connection notices a dead one before the next
+		// notification is lost on it.
+		ping := time.NewTicker(time.Minute)
+		defer ping.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-ping.C:
+				go func() { _ = listener.Ping() }()
+
+			case notification, ok := <-listener.Notify:
+				if !ok {
+					return
+				}
+
+				// A nil notification follows a reconnect; anything sent
+				// meanwhile is lost.
+				var id int64
+				if notification != nil {
+					parsed, err := strconv.ParseInt(notification.Extra, 10, 64)
+					if err != nil {
+						log.Warn("malformed pr event notification", slog.String("payload", notification.Extra))
+						continue
+					}
+					id = parsed
+				}
+
+				select {
+				case out <- id:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type ReviewSLAPostgres struct {
+	db *sql.DB
+}
+
+func NewReviewSLAPostgres(db *sql.DB) repository.ReviewSLARepository {
+	return &ReviewSLAPostgres{db: db}
+}
+
+const pendingReviewColumns = `
+            r.pr_id, p.name, t.name, r.reviewer_id, r.assigned_at, r.sla_breached_at, r.escalated_at,
+            t.sla_response_hours, t.sla_escalation_hours, t.sla_escalation
+`
+
+func (r *ReviewSLAPostgres) ListAwaiting(ctx context.Context, now time.Time) ([]domain.PendingReview, error) {
+	// Working hours never pass faster than wall-clock hours, so reviews
+	// assigned less than the response time ago can not be overdue yet.
+	q := `
+        SELECT ` + pendingReviewColumns + `
+        FROM pull_request_reviewers r
+        JOIN pull_requests p ON p.id = r.pr_id
+        JOIN teams t ON t.name = p.team_name
+        WHERE p.status = 'OPEN'
+          AND r.verdict IS NULL
+          AND r.escalated_at IS NULL
+          AND t.sla_response_hours > 0
+          AND r.assigned_at <= $1 - make_interval(hours => t.sla_response_hours)
+        ORDER BY r.assigned_at, r.pr_id, r.reviewer_id
+    `
+	return r.list(ctx, q, now)
+}
+
+func (r *ReviewSLAPostgres) ListOverdue(ctx context.Context, teamName string) ([]domain.PendingReview, error) {
+	q := `
+        SELECT ` + pendingReviewColumns + `
+        FROM pull_request_reviewers r
+        JOIN pull_requests p ON p.id = r.pr_id
+        JOIN teams t ON t.name = p.team_name
+        WHERE p.status = 'OPEN'
+          AND r.verdict IS NULL
+          AND r.sla_breached_at IS NOT NULL
+          AND ($1 = '' OR t.name = $1)
+        ORDER BY r.assigned_at, r.pr_id, r.reviewer_id
+    `
+	return r.list(ctx, q, teamName)
+}
+
+func (r *ReviewSLAPostgres) list(ctx context.Context, q string, args ...any) ([]domain.PendingReview, error) {
+	log := logger.L()
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.PendingReview
+
+	for rows.Next() {
+		var pr domain.PendingReview
+		if err := rows.Scan(
+			&pr.PRID,
+			&pr.PRName,
+			&pr.TeamName,
+			&pr.ReviewerID,
+			&pr.AssignedAt,
+			&pr.BreachedAt,
+			&pr.EscalatedAt,
+			&pr.SLA.ResponseHours,
+			&pr.SLA.EscalationHours,
+			&pr.SLA.Escalation,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, pr)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *ReviewSLAPostgres) MarkBreached(
+	ctx context.Context,
+	prID string,
+	reviewerID string,
+	assignedAt time.Time,
+	at time.Time,
+) (bool, error) {
+	q := `
+        UPDATE pull_request_reviewers
+        SET sla_breached_at = $4
+        WHERE pr_id = $1 AND reviewer_id = $2 AND assigned_at = $3
+          AND verdict IS NULL AND sla_breached_at IS NULL
+    `
+	return r.mark(ctx, q, prID, reviewerID, assignedAt, at)
+}
+
+func (r *ReviewSLAPostgres) MarkEscalated(
+	ctx context.Context,
+	prID string,
+	reviewerID string,
+	assignedAt time.Time,
+	at time.Time,
+) (bool, error) {
+	q := `
+        UPDATE pull_request_reviewers
+        SET escalated_at = $4
+        WHERE pr_id = $1 AND reviewer_id = $2 AND assigned_at = $3
+          AND verdict IS NULL AND escalated_at IS NULL
+    `
+	return r.mark(ctx, q, prID, reviewerID, assignedAt, at)
+}
+
+func (r *ReviewSLAPostgres) mark(ctx context.Context, q string, args ...any) (bool, error) {
+	log := logger.L()
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type ReviewerSyncPostgres struct {
+	db *sql.DB
+}
+
+func NewReviewerSyncPostgres(db *sql.DB) repository.ReviewerSyncRepository {
+	return &ReviewerSyncPostgres{db: db}
+}
+
+func (r *ReviewerSyncPostgres) Enqueue(ctx context.Context, job *domain.ReviewerSyncJob) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO reviewer_sync_jobs (pr_id, add_reviewers, remove_reviewers, status, next_attempt_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		job.PRID,
+		pq.Array(job.Add),
+		pq.Array(job.Remove),
+		job.Status,
+		job.NextAttemptAt,
+		job.CreatedAt,
+	).Scan(&job.ID)
+
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+
+	return err
+}
+
+func (r *ReviewerSyncPostgres) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+) ([]domain.ReviewerSyncJob, error) {
+	q := `
+        WITH due AS (
+            SELECT id
+            FROM reviewer_sync_jobs
+            WHERE status = 'PENDING' AND next_attempt_at <= $1
+            ORDER BY next_attempt_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE reviewer_sync_jobs j
+        SET next_attempt_at = $2
+        FROM due
+        WHERE j.id = due.id
+        RETURNING j.id, j.pr_id, j.add_reviewers, j.remove_reviewers, j.status,
+                  j.attempts, j.next_attempt_at, j.last_attempt_at, j.last_error, j.created_at
+    `
+	return r.query(ctx, q, now, leaseUntil, limit)
+}
+
+func (r *ReviewerSyncPostgres) SaveAttempt(ctx context.Context, job *domain.ReviewerSyncJob) error {
+	log := logger.L()
+
+	q := `
+        UPDATE reviewer_sync_jobs
+        SET status = $2,
+            attempts = $3,
+            next_attempt_at = $4,
+            last_attempt_at = $5,
+            last_error = $6
+        WHERE id = $1
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		job.ID,
+		job.Status,
+		job.Attempts,
+		job.NextAttemptAt,
+		job.LastAttemptAt,
+		job.LastError,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *ReviewerSyncPostgres) ListByPR(ctx context.Context, prID string) ([]domain.ReviewerSyncJob, error) {
+	q := `
+        SELECT id, pr_id, add_reviewers, remove_reviewers, status,
+               attempts, next_attempt_at, last_attempt_at, last_error, created_at
+        FROM reviewer_sync_jobs
+        WHERE pr_id = $1
+        ORDER BY id
+    `
+	return r.query(ctx, q, prID)
+}
+
+func (r *ReviewerSyncPostgres) query(ctx context.Context, q string, args ...any) ([]domain.ReviewerSyncJob, error) {
+	log := logger.L()
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.ReviewerSyncJob
+
+	for rows.Next() {
+		var job domain.ReviewerSyncJob
+		if err := rows.Scan(
+			&job.ID,
+			&job.PRID,
+			pq.Array(&job.Add),
+			pq.Array(&job.Remove),
+			&job.Status,
+			&job.Attempts,
+			&job.NextAttemptAt,
+			&job.LastAttemptAt,
+			&job.LastError,
+			&job.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, job)
+	}
+
+	return list, rows.Err()
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type StalePRPostgres struct {
+	db *sql.DB
+}
+
+func NewStalePRPostgres(db *sql.DB) repository.StalePRRepository {
+	return &StalePRPostgres{db: db}
+}
+
+func (r *StalePRPostgres) ListStale(
+	ctx context.Context,
+	filter domain.StalePRFilter,
+	openedBefore time.Time,
+	activeBefore time.Time,
+) ([]domain.StalePR, error) {
+	log := logger.L()
+
+	q := `
+        SELECT p.id, p.name, p.author_id, COALESCE(p.team_name, ''), p.created_at,
+               GREATEST(p.created_at, rv.last_verdict_at),
+               COALESCE(rv.pending, '{}')
+        FROM pull_requests p
+        LEFT JOIN LATERAL (
+            SELECT max(r.verdict_at) AS last_verdict_at,
+                   array_agg(r.reviewer_id ORDER BY r.reviewer_id) FILTER (WHERE r.verdict IS NULL) AS pending
+            FROM pull_request_reviewers r
+            WHERE r.pr_id = p.id
+        ) rv ON TRUE
+        WHERE p.status = 'OPEN'
+          AND (p.created_at <= $1 OR GREATEST(p.created_at, rv.last_verdict_at) <= $2)
+          AND ($3 = '' OR p.team_name = $3)
+          AND ($4 = '' OR $4 = ANY(rv.pending))
+        ORDER BY p.created_at, p.id
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, openedBefore, activeBefore, filter.TeamName, filter.ReviewerID)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.StalePR
+
+	for rows.Next() {
+		var pr domain.StalePR
+		if err := rows.Scan(
+			&pr.PRID,
+			&pr.PRName,
+			&pr.AuthorID,
+			&pr.TeamName,
+			&pr.CreatedAt,
+			&pr.LastActivityAt,
+			pq.Array(&pr.PendingReviewers),
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, pr)
+	}
+
+	return list, rows.Err()
+}
@@ -18,16 +18,53 @@ func NewStatsPostgres(db *sql.DB) repository.StatsRepository {
 	return &StatsPostgres{db: db}
 }
 
-func (r *StatsPostgres) CountAssignmentsByUser(ctx context.Context) ([]domain.UserAssignmentStat, error) {
+// statsFilterClause selects the pull requests p matching a filter passed
+// with statsFilterArgs.
+const statsFilterClause = `
+          ($1::timestamptz IS NULL OR p.created_at >= $1)
+          AND ($2::timestamptz IS NULL OR p.created_at < $2)
+          AND ($3 = '' OR p.team_name = $3)
+          AND ($4 = '' OR p.status = $4)
+`
+
+func statsFilterArgs(filter domain.StatsFilter) []any {
+	return []any{filter.From, filter.To, filter.TeamName, string(filter.Status)}
+}
+
+// countersCover tells whether the counters maintained by the triggers of
+// migration 021 can answer a filter; the ones with a time window or a
+// status are counted from the pull requests.
+func countersCover(filter domain.StatsFilter) bool {
+	return filter.From == nil && filter.To == nil && filter.Status == ""
+}
+
+func (r *StatsPostgres) CountAssignmentsByUser(
+	ctx context.Context,
+	filter domain.StatsFilter,
+) ([]domain.UserAssignmentStat, error) {
 	log := logger.L()
 
 	q := `
-        SELECT reviewer_id, COUNT(*) AS assignments
-        FROM pull_request_reviewers
-        GROUP BY reviewer_id
-        ORDER BY assignments DESC
+        SELECT r.reviewer_id, COUNT(*) AS assignments
+        FROM pull_request_reviewers r
+        JOIN pull_requests p ON p.id = r.pr_id
+        WHERE ` + statsFilterClause + `
+        GROUP BY r.reviewer_id
+        ORDER BY assignments DESC, r.reviewer_id
     `
-	rows, err := r.db.QueryContext(ctx, q)
+	args := statsFilterArgs(filter)
+	if countersCover(filter) {
+		q = `
+            SELECT reviewer_id, SUM(assignments) AS assignments
+            FROM stats_reviewer_counts
+            WHERE $1 = '' OR team_name = $1
+            GROUP BY reviewer_id
+            HAVING SUM(assignments) > 0
+            ORDER BY assignments DESC, reviewer_id
+        `
+		args = []any{filter.TeamName}
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, args...)
 	if err != nil {
 		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
 		return nil, err
@@ -43,19 +80,49 @@ func (r *StatsPostgres) CountAssignmentsByUser(ctx context.Context) ([]domain.Us
 		stats = append(stats, s)
 	}
 
-	return stats, nil
+	return stats, rows.Err()
 }
 
-func (r *StatsPostgres) CountReviewersByPR(ctx context.Context) ([]domain.PRReviewerStat, error) {
+// prLimit is the LIMIT argument for filter.PRLimit; LIMIT NULL returns all
+// rows.
+func prLimit(filter domain.StatsFilter) any {
+	if filter.PRLimit <= 0 {
+		return nil
+	}
+	return filter.PRLimit
+}
+
+func (r *StatsPostgres) CountReviewersByPR(
+	ctx context.Context,
+	filter domain.StatsFilter,
+) ([]domain.PRReviewerStat, error) {
 	log := logger.L()
 
 	q := `
-        SELECT pr_id, COUNT(*) AS reviewers
-        FROM pull_request_reviewers
-        GROUP BY pr_id
-        ORDER BY reviewers DESC
+        SELECT r.pr_id, COUNT(*) AS reviewers
+        FROM pull_request_reviewers r
+        JOIN pull_requests p ON p.id = r.pr_id
+        WHERE ` + statsFilterClause + `
+        GROUP BY r.pr_id
+        ORDER BY reviewers DESC, r.pr_id
+        LIMIT $5
     `
-	rows, err := r.db.QueryContext(ctx, q)
+	args := append(statsFilterArgs(filter), prLimit(filter))
+	if countersCover(filter) {
+		// The limit lets the scan stop early on idx_stats_pr_counts_reviewers.
+		q = `
+            SELECT c.pr_id, c.reviewers
+            FROM stats_pr_counts c
+            WHERE c.reviewers > 0
+              AND ($1 = '' OR EXISTS (
+                  SELECT 1 FROM pull_requests p WHERE p.id = c.pr_id AND p.team_name = $1
+              ))
+            ORDER BY c.reviewers DESC, c.pr_id
+            LIMIT $2
+        `
+		args = []any{filter.TeamName, prLimit(filter)}
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, args...)
 	if err != nil {
 		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
 		return nil, err
@@ -71,5 +138,132 @@ func (r *StatsPostgres) CountReviewersByPR(ctx context.Context) ([]domain.PRRevi
 		stats = append(stats, s)
 	}
 
-	return stats, nil
+	return stats, rows.Err()
+}
+
+func (r *StatsPostgres) CountByTeam(ctx context.Context, filter domain.StatsFilter) ([]domain.TeamStat, error) {
+	log := logger.L()
+
+	q := `
+        SELECT p.team_name,
+               COUNT(*),
+               COUNT(*) FILTER (WHERE p.status = 'OPEN'),
+               COUNT(*) FILTER (WHERE p.status = 'MERGED'),
+               COUNT(*) FILTER (WHERE p.status = 'CLOSED'),
+               COALESCE(SUM(rv.reviewers), 0)
+        FROM pull_requests p
+        LEFT JOIN LATERAL (
+            SELECT COUNT(*) AS reviewers
+            FROM pull_request_reviewers r
+            WHERE r.pr_id = p.id
+        ) rv ON TRUE
+        WHERE p.team_name IS NOT NULL
+          AND ` + statsFilterClause + `
+        GROUP BY p.team_name
+        ORDER BY p.team_name
+    `
+	args := statsFilterArgs(filter)
+	if countersCover(filter) {
+		q = `
+            SELECT team_name, pull_requests, open, merged, closed, assignments
+            FROM stats_team_counts
+            WHERE pull_requests > 0
+              AND ($1 = '' OR team_name = $1)
+            ORDER BY team_name
+        `
+		args = []any{filter.TeamName}
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, args...)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []domain.TeamStat
+	for rows.Next() {
+		var s domain.TeamStat
+		if err := rows.Scan(&s.TeamName, &s.PullRequests, &s.Open, &s.Merged, &s.Closed, &s.Assignments); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// expectedCounters recomputes the counters the way stats_rebuild_counters
+// does.
+const expectedCounters = `
+        WITH reviewers AS (
+            SELECT COALESCE(p.team_name, '') AS team_name, r.reviewer_id, COUNT(*) AS assignments
+            FROM pull_request_reviewers r
+            JOIN pull_requests p ON p.id = r.pr_id
+            GROUP BY 1, 2
+        ), prs AS (
+            SELECT pr_id, COUNT(*) AS reviewers
+            FROM pull_request_reviewers
+            GROUP BY pr_id
+        ), teams AS (
+            SELECT p.team_name,
+                   COUNT(*) AS pull_requests,
+                   COUNT(*) FILTER (WHERE p.status = 'OPEN') AS open,
+                   COUNT(*) FILTER (WHERE p.status = 'MERGED') AS merged,
+                   COUNT(*) FILTER (WHERE p.status = 'CLOSED') AS closed,
+                   COALESCE(SUM(prs.reviewers), 0) AS assignments
+            FROM pull_requests p
+            LEFT JOIN prs ON prs.pr_id = p.id
+            WHERE p.team_name IS NOT NULL
+            GROUP BY p.team_name
+        )
+`
+
+func (r *StatsPostgres) CheckCounters(ctx context.Context) (int, error) {
+	log := logger.L()
+
+	// Counters are changed with the rows they count, so a single statement
+	// sees them agree unless they drifted.
+	q := expectedCounters + `
+        SELECT
+            (SELECT COUNT(*)
+             FROM reviewers e
+             FULL JOIN stats_reviewer_counts c USING (team_name, reviewer_id)
+             WHERE COALESCE(e.assignments, 0) <> COALESCE(c.assignments, 0))
+          + (SELECT COUNT(*)
+             FROM prs e
+             FULL JOIN stats_pr_counts c USING (pr_id)
+             WHERE COALESCE(e.reviewers, 0) <> COALESCE(c.reviewers, 0))
+          + (SELECT COUNT(*)
+             FROM teams e
+             FULL JOIN stats_team_counts c USING (team_name)
+             WHERE (COALESCE(e.pull_requests, 0), COALESCE(e.open, 0), COALESCE(e.merged, 0),
+                    COALESCE(e.closed, 0), COALESCE(e.assignments, 0))
+                <> (COALESCE(c.pull_requests, 0), COALESCE(c.open, 0), COALESCE(c.merged, 0),
+                    COALESCE(c.closed, 0), COALESCE(c.assignments, 0)))
+    `
+	var drift int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, q).Scan(&drift); err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return 0, err
+	}
+
+	return drift, nil
+}
+
+func (r *StatsPostgres) RebuildCounters(ctx context.Context) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            SELECT stats_rebuild_counters()
+        `
+		if _, err := tx.ExecContext(ctx, q); err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+		return nil
+	})
 }
@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// snapshotDays lists the days [$1, $2) with the bounds of each. Days are
+// counted in UTC whatever the time zone of the session.
+const snapshotDays = `
+        WITH days AS (
+            SELECT g::date AS day,
+                   g AT TIME ZONE 'UTC' AS start,
+                   (g + interval '1 day') AT TIME ZONE 'UTC' AS stop
+            FROM generate_series(
+                $1::timestamptz AT TIME ZONE 'UTC',
+                $2::timestamptz AT TIME ZONE 'UTC' - interval '1 day',
+                interval '1 day'
+            ) g
+        )
+`
+
+func (r *StatsPostgres) LastSnapshotDay(ctx context.Context) (*time.Time, error) {
+	log := logger.L()
+
+	q := `
+        SELECT MAX(day) FROM stats_team_snapshots
+    `
+	var day sql.NullTime
+	if err := conn(ctx, r.db).QueryRowContext(ctx, q).Scan(&day); err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	if !day.Valid {
+		return nil, nil
+	}
+
+	d := domain.StartOfDay(day.Time)
+	return &d, nil
+}
+
+func (r *StatsPostgres) FirstPRDay(ctx context.Context) (*time.Time, error) {
+	log := logger.L()
+
+	q := `
+        SELECT MIN(created_at) FROM pull_requests
+    `
+	var createdAt sql.NullTime
+	if err := conn(ctx, r.db).QueryRowContext(ctx, q).Scan(&createdAt); err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	if !createdAt.Valid {
+		return nil, nil
+	}
+
+	d := domain.StartOfDay(createdAt.Time)
+	return &d, nil
+}
+
+// SaveSnapshots replaces the snapshots of the days [from, to) with ones
+// computed from the pull requests and their timeline. Assignments are taken
+// from the timeline because pull_request_reviewers only keeps the current
+// reviewers: a reassignment overwrites the replaced one.
+func (r *StatsPostgres) SaveSnapshots(ctx context.Context, from, to time.Time) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		queries := []string{
+			`
+            DELETE FROM stats_team_snapshots
+            WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date
+              AND day < ($2::timestamptz AT TIME ZONE 'UTC')::date
+            `,
+			`
+            DELETE FROM stats_user_snapshots
+            WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date
+              AND day < ($2::timestamptz AT TIME ZONE 'UTC')::date
+            `,
+			// A pull request counts for the days it was open at some time.
+			snapshotDays + `
+            , prs AS (
+                SELECT d.day, p.team_name,
+                       COUNT(*) FILTER (
+                           WHERE (p.merged_at IS NULL OR p.merged_at >= d.stop)
+                             AND (p.closed_at IS NULL OR p.closed_at >= d.stop)
+                       ) AS open,
+                       COUNT(*) FILTER (WHERE p.created_at >= d.start) AS opened,
+                       COUNT(*) FILTER (WHERE p.merged_at < d.stop) AS merged,
+                       COUNT(*) FILTER (WHERE p.closed_at < d.stop) AS closed
+                FROM days d
+                JOIN pull_requests p
+                  ON p.created_at < d.stop
+                 AND (p.merged_at IS NULL OR p.merged_at >= d.start)
+                 AND (p.closed_at IS NULL OR p.closed_at >= d.start)
+                WHERE p.team_name IS NOT NULL
+                GROUP BY d.day, p.team_name
+            ), assigned AS (
+                SELECT d.day, p.team_name, COUNT(*) AS assignments
+                FROM days d
+                JOIN pr_events e
+                  ON e.occurred_at >= d.start AND e.occurred_at < d.stop
+                 AND e.type IN ('reviewer_assigned', 'reviewer_reassigned')
+                JOIN pull_requests p ON p.id = e.pr_id
+                WHERE p.team_name IS NOT NULL
+                GROUP BY d.day, p.team_name
+            )
+            INSERT INTO stats_team_snapshots (day, team_name, open, opened, merged, closed, assignments)
+            SELECT COALESCE(p.day, a.day),
+                   COALESCE(p.team_name, a.team_name),
+                   COALESCE(p.open, 0),
+                   COALESCE(p.opened, 0),
+                   COALESCE(p.merged, 0),
+                   COALESCE(p.closed, 0),
+                   COALESCE(a.assignments, 0)
+            FROM prs p
+            FULL JOIN assigned a ON a.day = p.day AND a.team_name = p.team_name
+            `,
+			// A reviewer holds an assignment until they are replaced or
+			// removed.
+			snapshotDays + `
+            , held AS (
+                SELECT e.pr_id, e.reviewer_id, e.occurred_at AS assigned_at,
+                       COALESCE(rel.released_at, 'infinity') AS released_at
+                FROM pr_events e
+                LEFT JOIN LATERAL (
+                    SELECT MIN(x.occurred_at) AS released_at
+                    FROM pr_events x
+                    WHERE x.pr_id = e.pr_id
+                      AND x.id > e.id
+                      AND ((x.type = 'reviewer_reassigned' AND x.previous_reviewer_id = e.reviewer_id)
+                        OR (x.type = 'reviewer_removed' AND x.reviewer_id = e.reviewer_id))
+                ) rel ON TRUE
+                WHERE e.type IN ('reviewer_assigned', 'reviewer_reassigned')
+            ), assigned AS (
+                SELECT d.day, COALESCE(p.team_name, '') AS team_name, h.reviewer_id, COUNT(*) AS assignments
+                FROM days d
+                JOIN held h ON h.assigned_at >= d.start AND h.assigned_at < d.stop
+                JOIN pull_requests p ON p.id = h.pr_id
+                GROUP BY 1, 2, 3
+            ), pending AS (
+                SELECT d.day, COALESCE(p.team_name, '') AS team_name, h.reviewer_id, COUNT(*) AS open_reviews
+                FROM days d
+                JOIN held h ON h.assigned_at < d.stop AND h.released_at >= d.stop
+                JOIN pull_requests p
+                  ON p.id = h.pr_id
+                 AND (p.merged_at IS NULL OR p.merged_at >= d.stop)
+                 AND (p.closed_at IS NULL OR p.closed_at >= d.stop)
+                GROUP BY 1, 2, 3
+            )
+            INSERT INTO stats_user_snapshots (day, team_name, user_id, assignments, open_reviews)
+            SELECT COALESCE(a.day, o.day),
+                   COALESCE(a.team_name, o.team_name),
+                   COALESCE(a.reviewer_id, o.reviewer_id),
+                   COALESCE(a.assignments, 0),
+                   COALESCE(o.open_reviews, 0)
+            FROM assigned a
+            FULL JOIN pending o
+              ON o.day = a.day AND o.team_name = a.team_name AND o.reviewer_id = a.reviewer_id
+            `,
+		}
+
+		for _, q := range queries {
+			if _, err := tx.ExecContext(ctx, q, from, to); err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *StatsPostgres) DeleteSnapshotsBefore(ctx context.Context, day time.Time) (int64, error) {
+	log := logger.L()
+
+	var deleted int64
+	err := runInTx(ctx, r.db, func(tx querier) error {
+		for _, q := range []string{
+			`
+            DELETE FROM stats_team_snapshots WHERE day < ($1::timestamptz AT TIME ZONE 'UTC')::date
+            `,
+			`
+            DELETE FROM stats_user_snapshots WHERE day < ($1::timestamptz AT TIME ZONE 'UTC')::date
+            `,
+		} {
+			res, err := tx.ExecContext(ctx, q, day)
+			if err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			deleted += n
+		}
+		return nil
+	})
+
+	return deleted, err
+}
+
+func (r *StatsPostgres) TeamSnapshots(
+	ctx context.Context,
+	filter domain.StatsHistoryFilter,
+) ([]domain.TeamSnapshot, error) {
+	log := logger.L()
+
+	q := `
+        SELECT day, team_name, open, opened, merged, closed, assignments
+        FROM stats_team_snapshots
+        WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date
+          AND day < ($2::timestamptz AT TIME ZONE 'UTC')::date
+          AND ($3 = '' OR team_name = $3)
+        ORDER BY team_name, day
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, filter.From, filter.To, filter.TeamName)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []domain.TeamSnapshot
+	for rows.Next() {
+		var s domain.TeamSnapshot
+		if err := rows.Scan(&s.Day, &s.TeamName, &s.Open, &s.Opened, &s.Merged, &s.Closed, &s.Assignments); err != nil {
+			return nil, err
+		}
+		s.Day = domain.StartOfDay(s.Day)
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// UserSnapshots sums the snapshots of a user over the teams of the pull
+// requests unless the filter selects a team.
+func (r *StatsPostgres) UserSnapshots(
+	ctx context.Context,
+	filter domain.StatsHistoryFilter,
+) ([]domain.UserSnapshot, error) {
+	log := logger.L()
+
+	q := `
+        SELECT day, user_id, SUM(assignments), SUM(open_reviews)
+        FROM stats_user_snapshots
+        WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date
+          AND day < ($2::timestamptz AT TIME ZONE 'UTC')::date
+          AND ($3 = '' OR team_name = $3)
+          AND ($4 = '' OR user_id = $4)
+        GROUP BY user_id, day
+        ORDER BY user_id, day
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, filter.From, filter.To, filter.TeamName, filter.UserID)
+	if err != nil {
+		log.Error("failed stats query", slog.String("query", q), slog.Any("err", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []domain.UserSnapshot
+	for rows.Next() {
+		var s domain.UserSnapshot
+		if err := rows.Scan(&s.Day, &s.UserID, &s.Assignments, &s.OpenReviews); err != nil {
+			return nil, err
+		}
+		s.Day = domain.StartOfDay(s.Day)
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, rows.Err()
+}
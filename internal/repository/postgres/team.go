@@ -9,6 +9,7 @@ import (
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
 )
 
 type TeamPostgres struct {
@@ -23,9 +24,22 @@ func (r *TeamPostgres) Create(ctx context.Context, team *domain.Team) error {
 	log := logger.L()
 
 	q := `
-        INSERT INTO teams (name) VALUES ($1)
+        INSERT INTO teams (
+            name, description, slack_channel_id, reviewers_count, exclude_leads,
+            sla_response_hours, sla_escalation_hours, sla_escalation
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
-	_, err := r.db.ExecContext(ctx, q, team.Name)
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		team.Name,
+		team.Description,
+		team.SlackChannelID,
+		team.ReviewerPolicy.ReviewersCount,
+		team.ReviewerPolicy.ExcludeLeads,
+		team.ReviewSLA.ResponseHours,
+		team.ReviewSLA.EscalationHours,
+		team.ReviewSLA.Escalation,
+	)
 
 	if err != nil {
 		log.Error("failed to execute SQL",
@@ -43,7 +57,7 @@ func (r *TeamPostgres) ExistsByName(ctx context.Context, name string) (bool, err
 	q := `
         SELECT 1 FROM teams WHERE name = $1
     `
-	row := r.db.QueryRowContext(ctx, q, name)
+	row := conn(ctx, r.db).QueryRowContext(ctx, q, name)
 
 	var dummy int
 	err := row.Scan(&dummy)
@@ -64,12 +78,38 @@ func (r *TeamPostgres) GetByName(ctx context.Context, name string) (*domain.Team
 	log := logger.L()
 
 	q := `
-        SELECT name FROM teams WHERE name = $1
+        SELECT
+            t.name,
+            t.description,
+            t.slack_channel_id,
+            t.reviewers_count,
+            t.exclude_leads,
+            t.sla_response_hours,
+            t.sla_escalation_hours,
+            t.sla_escalation,
+            COALESCE(
+                (SELECT array_agg(l.user_id ORDER BY l.user_id)
+                 FROM team_leads l
+                 WHERE l.team_name = t.name),
+                '{}'
+            )
+        FROM teams t
+        WHERE t.name = $1
     `
-	row := r.db.QueryRowContext(ctx, q, name)
+	row := conn(ctx, r.db).QueryRowContext(ctx, q, name)
 
 	var t domain.Team
-	if err := row.Scan(&t.Name); err != nil {
+	if err := row.Scan(
+		&t.Name,
+		&t.Description,
+		&t.SlackChannelID,
+		&t.ReviewerPolicy.ReviewersCount,
+		&t.ReviewerPolicy.ExcludeLeads,
+		&t.ReviewSLA.ResponseHours,
+		&t.ReviewSLA.EscalationHours,
+		&t.ReviewSLA.Escalation,
+		pq.Array(&t.Leads),
+	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrTeamNotFound
 		}
@@ -82,3 +122,68 @@ func (r *TeamPostgres) GetByName(ctx context.Context, name string) (*domain.Team
 
 	return &t, nil
 }
+
+func (r *TeamPostgres) UpdateSettings(ctx context.Context, team *domain.Team) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            UPDATE teams
+            SET description = $2,
+                slack_channel_id = $3,
+                reviewers_count = $4,
+                exclude_leads = $5,
+                sla_response_hours = $6,
+                sla_escalation_hours = $7,
+                sla_escalation = $8
+            WHERE name = $1
+        `
+		res, err := tx.ExecContext(ctx, q,
+			team.Name,
+			team.Description,
+			team.SlackChannelID,
+			team.ReviewerPolicy.ReviewersCount,
+			team.ReviewerPolicy.ExcludeLeads,
+			team.ReviewSLA.ResponseHours,
+			team.ReviewSLA.EscalationHours,
+			team.ReviewSLA.Escalation,
+		)
+		if err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return domain.ErrTeamNotFound
+		}
+
+		q = `
+            DELETE FROM team_leads WHERE team_name = $1
+        `
+		if _, err = tx.ExecContext(ctx, q, team.Name); err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		q = `
+            INSERT INTO team_leads (team_name, user_id)
+            VALUES ($1, $2)
+        `
+		for _, lead := range team.Leads {
+			if _, err = tx.ExecContext(ctx, q, team.Name, lead); err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type txKey struct{}
+
+// querier is the subset of *sql.DB and *sql.Tx used by repositories.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type TxManager struct {
+	db *sql.DB
+}
+
+func NewTxManager(db *sql.DB) repository.TxManager {
+	return &TxManager{db: db}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.L().Error("failed to begin transaction", slog.Any("err", err))
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// conn returns the transaction bound to ctx or falls back to the pool.
+// Statements are timed under the calling repository method.
+func conn(ctx context.Context, db *sql.DB) querier {
+	var q querier = db
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		q = tx
+	}
+	return timedQuerier{q: q, observer: callerObserver(1)}
+}
+
+// runInTx runs fn in the transaction bound to ctx, or in a new one when the
+// caller did not start a transaction.
+func runInTx(ctx context.Context, db *sql.DB, fn func(q querier) error) error {
+	observer := callerObserver(1)
+
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(timedQuerier{q: tx, observer: observer})
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.L().Error("failed to begin transaction", slog.Any("err", err))
+		return err
+	}
+
+	if err = fn(timedQuerier{q: tx, observer: observer}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -3,12 +3,16 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
 )
 
 type UserPostgres struct {
@@ -19,18 +23,74 @@ func NewUserPostgres(db *sql.DB) repository.UserRepository {
 	return &UserPostgres{db: db}
 }
 
+// userColumns selects a user together with all of their team memberships and
+// external accounts. The primary team is exposed as team_name and is empty
+// when the user has none.
+const userColumns = `
+        u.id,
+        u.username,
+        COALESCE(u.team_name, ''),
+        u.is_active,
+        COALESCE(
+            (SELECT array_agg(tm.team_name ORDER BY tm.team_name)
+             FROM team_members tm
+             WHERE tm.user_id = u.id),
+            '{}'
+        ),
+        u.email,
+        u.display_name,
+        COALESCE(
+            (SELECT json_object_agg(a.provider, a.login)
+             FROM user_external_accounts a
+             WHERE a.user_id = u.id),
+            '{}'
+        ),
+        u.erased_at
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanUser reads userColumns followed by any extra columns of the query.
+func scanUser(row rowScanner, extra ...any) (domain.User, error) {
+	var (
+		u        domain.User
+		accounts []byte
+	)
+
+	dest := []any{
+		&u.ID,
+		&u.Username,
+		&u.TeamName,
+		&u.IsActive,
+		pq.Array(&u.Teams),
+		&u.Email,
+		&u.DisplayName,
+		&accounts,
+		&u.ErasedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return u, err
+	}
+
+	if err := json.Unmarshal(accounts, &u.ExternalAccounts); err != nil {
+		return u, err
+	}
+
+	return u, nil
+}
+
 func (r *UserPostgres) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	log := logger.L()
 
 	q := `
-        SELECT id, username, team_name, is_active
-        FROM users
-        WHERE id = $1
+        SELECT ` + userColumns + `
+        FROM users u
+        WHERE u.id = $1
     `
-	row := r.db.QueryRowContext(ctx, q, id)
-
-	var u domain.User
-	if err := row.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+	u, err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, q, id))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrUserNotFound
 		}
@@ -48,11 +108,13 @@ func (r *UserPostgres) ListActiveByTeam(ctx context.Context, teamName string) ([
 	log := logger.L()
 
 	q := `
-        SELECT id, username, team_name, is_active
-        FROM users
-        WHERE team_name = $1 AND is_active = TRUE
+        SELECT ` + userColumns + `
+        FROM users u
+        JOIN team_members m ON m.user_id = u.id
+        WHERE m.team_name = $1 AND u.is_active = TRUE
+        ORDER BY u.id
     `
-	rows, err := r.db.QueryContext(ctx, q, teamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, teamName)
 	if err != nil {
 		log.Error("failed to execute SQL",
 			slog.String("query", q),
@@ -62,28 +124,65 @@ func (r *UserPostgres) ListActiveByTeam(ctx context.Context, teamName string) ([
 	}
 	defer rows.Close()
 
-	var list []domain.User
+	return scanUsers(rows)
+}
 
-	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
-			return nil, err
-		}
-		list = append(list, u)
+func (r *UserPostgres) ListByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
+	log := logger.L()
+
+	q := `
+        SELECT ` + userColumns + `
+        FROM users u
+        JOIN team_members m ON m.user_id = u.id
+        WHERE m.team_name = $1
+        ORDER BY u.id
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, teamName)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
 	}
+	defer rows.Close()
 
-	return list, nil
+	return scanUsers(rows)
 }
 
-func (r *UserPostgres) ListByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
+// userFilterCondition matches users against domain.UserFilter. Parameters:
+// $1 team name, $2 activity flag, $3 lower-cased LIKE pattern for the username.
+const userFilterCondition = `
+        ($1 = '' OR EXISTS (
+            SELECT 1 FROM team_members m
+            WHERE m.user_id = u.id AND m.team_name = $1
+        ))
+        AND ($2::boolean IS NULL OR u.is_active = $2)
+        AND ($3 = '' OR lower(u.username) LIKE $3 ESCAPE '\')
+`
+
+func (r *UserPostgres) List(ctx context.Context, filter domain.UserFilter) ([]domain.UserListItem, error) {
 	log := logger.L()
 
 	q := `
-        SELECT id, username, team_name, is_active
-        FROM users
-        WHERE team_name = $1
+        SELECT ` + userColumns + `,
+            (SELECT COUNT(*)
+             FROM pull_request_reviewers rv
+             JOIN pull_requests pr ON pr.id = rv.pr_id
+             WHERE rv.reviewer_id = u.id AND pr.status = 'OPEN')
+        FROM users u
+        WHERE ` + userFilterCondition + `
+            AND u.id > $4
+        ORDER BY u.id
+        LIMIT $5
     `
-	rows, err := r.db.QueryContext(ctx, q, teamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q,
+		filter.TeamName,
+		nullBool(filter.IsActive),
+		usernamePattern(filter.UsernamePrefix),
+		filter.AfterID,
+		filter.Limit,
+	)
 	if err != nil {
 		log.Error("failed to execute SQL",
 			slog.String("query", q),
@@ -93,17 +192,44 @@ func (r *UserPostgres) ListByTeam(ctx context.Context, teamName string) ([]domai
 	}
 	defer rows.Close()
 
-	var list []domain.User
+	var list []domain.UserListItem
 
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		var item domain.UserListItem
+		u, err := scanUser(rows, &item.OpenReviews)
+		if err != nil {
 			return nil, err
 		}
-		list = append(list, u)
+		item.User = u
+		list = append(list, item)
 	}
 
-	return list, nil
+	return list, rows.Err()
+}
+
+func (r *UserPostgres) Count(ctx context.Context, filter domain.UserFilter) (int, error) {
+	log := logger.L()
+
+	q := `
+        SELECT COUNT(*)
+        FROM users u
+        WHERE ` + userFilterCondition
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		filter.TeamName,
+		nullBool(filter.IsActive),
+		usernamePattern(filter.UsernamePrefix),
+	).Scan(&total)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return 0, err
+	}
+
+	return total, nil
 }
 
 func (r *UserPostgres) SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error) {
@@ -112,7 +238,7 @@ func (r *UserPostgres) SetIsActive(ctx context.Context, id string, isActive bool
 	q := `
         UPDATE users SET is_active = $2 WHERE id = $1
     `
-	_, err := r.db.ExecContext(ctx, q, id, isActive)
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, id, isActive)
 	if err != nil {
 		log.Error("failed to execute SQL",
 			slog.String("query", q),
@@ -124,19 +250,24 @@ func (r *UserPostgres) SetIsActive(ctx context.Context, id string, isActive bool
 	return r.GetByID(ctx, id)
 }
 
-func (r *UserPostgres) Upsert(ctx context.Context, user *domain.User) error {
+func (r *UserPostgres) Upsert(ctx context.Context, user *domain.UserUpsert) error {
 	log := logger.L()
 
 	q := `
-        INSERT INTO users (id, username, team_name, is_active)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO users (id, username, is_active, email, display_name)
+        VALUES ($1, $2, $3, COALESCE($4, ''), COALESCE($5, ''))
         ON CONFLICT (id) DO UPDATE SET
             username = EXCLUDED.username,
-            team_name = EXCLUDED.team_name,
-            is_active = EXCLUDED.is_active
+            is_active = EXCLUDED.is_active,
+            email = COALESCE($4, users.email),
+            display_name = COALESCE($5, users.display_name)
     `
-	_, err := r.db.ExecContext(ctx, q,
-		user.ID, user.Username, user.TeamName, user.IsActive,
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		user.ID,
+		user.Username,
+		user.IsActive,
+		nullString(user.Email),
+		nullString(user.DisplayName),
 	)
 
 	if err != nil {
@@ -148,3 +279,220 @@ func (r *UserPostgres) Upsert(ctx context.Context, user *domain.User) error {
 
 	return err
 }
+
+// SetExternalAccounts links or unlinks the given provider logins.
+// Providers that are not mentioned are left untouched.
+func (r *UserPostgres) SetExternalAccounts(ctx context.Context, userID string, accounts map[string]string) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		for provider, login := range accounts {
+			var (
+				q   string
+				err error
+			)
+			if login == "" {
+				q = `
+                    DELETE FROM user_external_accounts
+                    WHERE user_id = $1 AND provider = $2
+                `
+				_, err = tx.ExecContext(ctx, q, userID, provider)
+			} else {
+				q = `
+                    INSERT INTO user_external_accounts (user_id, provider, login)
+                    VALUES ($1, $2, $3)
+                    ON CONFLICT (user_id, provider) DO UPDATE SET login = EXCLUDED.login
+                `
+				_, err = tx.ExecContext(ctx, q, userID, provider, login)
+			}
+			if err != nil {
+				if isUniqueViolation(err) {
+					return domain.ErrExternalAccountTaken
+				}
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *UserPostgres) GetByExternalLogin(ctx context.Context, provider string, login string) (*domain.User, error) {
+	log := logger.L()
+
+	q := `
+        SELECT ` + userColumns + `
+        FROM users u
+        JOIN user_external_accounts a ON a.user_id = u.id
+        WHERE a.provider = $1 AND lower(a.login) = lower($2)
+    `
+	u, err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, q, provider, login))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// AddMembership adds the user to the team. The team becomes the primary one
+// when isPrimary is set or when the user has no primary team yet.
+func (r *UserPostgres) AddMembership(ctx context.Context, userID string, teamName string, isPrimary bool) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            SELECT NOT EXISTS (
+                SELECT 1 FROM team_members
+                WHERE user_id = $1 AND is_primary AND team_name <> $2
+            )
+        `
+		var noOtherPrimary bool
+		if err := tx.QueryRowContext(ctx, q, userID, teamName).Scan(&noOtherPrimary); err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		makePrimary := isPrimary || noOtherPrimary
+
+		if makePrimary {
+			q = `
+                UPDATE team_members SET is_primary = FALSE
+                WHERE user_id = $1 AND team_name <> $2 AND is_primary
+            `
+			if _, err := tx.ExecContext(ctx, q, userID, teamName); err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+
+			q = `
+                UPDATE users SET team_name = $2 WHERE id = $1
+            `
+			if _, err := tx.ExecContext(ctx, q, userID, teamName); err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		q = `
+            INSERT INTO team_members (user_id, team_name, is_primary)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (user_id, team_name) DO UPDATE SET
+                is_primary = team_members.is_primary OR EXCLUDED.is_primary
+        `
+		if _, err := tx.ExecContext(ctx, q, userID, teamName, makePrimary); err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Anonymize replaces personal data of the user while keeping the row, so that
+// pull requests and review history stay referentially intact. The user is
+// deactivated and removed from teams, team leads and external accounts.
+func (r *UserPostgres) Anonymize(ctx context.Context, id string, pseudonym string, erasedAt time.Time) error {
+	log := logger.L()
+
+	return runInTx(ctx, r.db, func(tx querier) error {
+		q := `
+            UPDATE users
+            SET username = $2,
+                email = '',
+                display_name = '',
+                team_name = NULL,
+                is_active = FALSE,
+                erased_at = $3
+            WHERE id = $1
+        `
+		res, err := tx.ExecContext(ctx, q, id, pseudonym, erasedAt)
+		if err != nil {
+			log.Error("failed to execute SQL",
+				slog.String("query", q),
+				slog.Any("err", err),
+			)
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		for _, q := range []string{
+			`DELETE FROM user_external_accounts WHERE user_id = $1`,
+			`DELETE FROM team_leads WHERE user_id = $1`,
+			`DELETE FROM team_members WHERE user_id = $1`,
+			`DELETE FROM user_absences WHERE user_id = $1`,
+		} {
+			if _, err := tx.ExecContext(ctx, q, id); err != nil {
+				log.Error("failed to execute SQL",
+					slog.String("query", q),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func scanUsers(rows *sql.Rows) ([]domain.User, error) {
+	var list []domain.User
+
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+
+	return list, rows.Err()
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+// usernamePattern turns a username prefix into a case-insensitive LIKE pattern.
+func usernamePattern(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(prefix))
+	return escaped + "%"
+}
@@ -0,0 +1,391 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type WebhookPostgres struct {
+	db *sql.DB
+}
+
+func NewWebhookPostgres(db *sql.DB) repository.WebhookRepository {
+	return &WebhookPostgres{db: db}
+}
+
+func (r *WebhookPostgres) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO webhook_subscriptions (url, secret, event_types, is_active, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		sub.URL,
+		sub.Secret,
+		pq.Array(eventTypesToStrings(sub.EventTypes)),
+		sub.IsActive,
+		sub.CreatedAt,
+	).Scan(&sub.ID)
+
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+
+	return err
+}
+
+func (r *WebhookPostgres) GetSubscription(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	log := logger.L()
+
+	q := `
+        SELECT id, url, secret, event_types, is_active, created_at
+        FROM webhook_subscriptions
+        WHERE id = $1
+    `
+	sub, err := scanSubscription(conn(ctx, r.db).QueryRowContext(ctx, q, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (r *WebhookPostgres) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	log := logger.L()
+
+	q := `
+        SELECT id, url, secret, event_types, is_active, created_at
+        FROM webhook_subscriptions
+        ORDER BY id
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.WebhookSubscription
+
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *sub)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *WebhookPostgres) FanOut(ctx context.Context, limit int) (int64, error) {
+	log := logger.L()
+
+	// SKIP LOCKED lets several dispatchers work through the outbox without
+	// delivering the same event twice.
+	q := `
+        WITH batch AS (
+            SELECT id, event_type
+            FROM outbox_events
+            WHERE dispatched_at IS NULL
+            ORDER BY id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        ), deliveries AS (
+            INSERT INTO webhook_deliveries (subscription_id, event_id)
+            SELECT s.id, b.id
+            FROM batch b
+            JOIN webhook_subscriptions s
+                ON s.is_active
+                AND (cardinality(s.event_types) = 0 OR b.event_type = ANY(s.event_types))
+        )
+        UPDATE outbox_events o
+        SET dispatched_at = now()
+        FROM batch b
+        WHERE o.id = b.id
+    `
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, limit)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *WebhookPostgres) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+) ([]domain.WebhookDispatch, error) {
+	log := logger.L()
+
+	q := `
+        WITH due AS (
+            SELECT id
+            FROM webhook_deliveries
+            WHERE status = 'PENDING' AND next_attempt_at <= $1
+            ORDER BY next_attempt_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE webhook_deliveries d
+        SET next_attempt_at = $2
+        FROM due, webhook_subscriptions s, outbox_events o
+        WHERE d.id = due.id AND s.id = d.subscription_id AND o.id = d.event_id
+        RETURNING d.id, d.subscription_id, d.event_id, d.redelivery_of, d.status,
+                  d.attempts, d.next_attempt_at, d.last_attempt_at,
+                  d.last_status_code, d.last_error, d.created_at,
+                  s.url, s.secret, o.event_type, o.payload, o.created_at
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, now, leaseUntil, limit)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.WebhookDispatch
+
+	for rows.Next() {
+		var (
+			dispatch domain.WebhookDispatch
+			d        = &dispatch.Delivery
+			payload  []byte
+		)
+		if err := rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.EventID,
+			&d.RedeliveryOf,
+			&d.Status,
+			&d.Attempts,
+			&d.NextAttemptAt,
+			&d.LastAttemptAt,
+			&d.LastStatusCode,
+			&d.LastError,
+			&d.CreatedAt,
+			&dispatch.URL,
+			&dispatch.Secret,
+			&dispatch.Event.Type,
+			&payload,
+			&dispatch.Event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dispatch.Event.ID = d.EventID
+		dispatch.Event.Payload = payload
+		d.EventType = dispatch.Event.Type
+		list = append(list, dispatch)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *WebhookPostgres) SaveAttempt(ctx context.Context, d *domain.WebhookDelivery) error {
+	log := logger.L()
+
+	q := `
+        UPDATE webhook_deliveries
+        SET status = $2,
+            attempts = $3,
+            next_attempt_at = $4,
+            last_attempt_at = $5,
+            last_status_code = $6,
+            last_error = $7
+        WHERE id = $1
+    `
+	_, err := conn(ctx, r.db).ExecContext(ctx, q,
+		d.ID,
+		d.Status,
+		d.Attempts,
+		d.NextAttemptAt,
+		d.LastAttemptAt,
+		d.LastStatusCode,
+		d.LastError,
+	)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+	return err
+}
+
+func (r *WebhookPostgres) GetDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	log := logger.L()
+
+	q := `
+        SELECT d.id, d.subscription_id, d.event_id, o.event_type, d.redelivery_of,
+               d.status, d.attempts, d.next_attempt_at, d.last_attempt_at,
+               d.last_status_code, d.last_error, d.created_at
+        FROM webhook_deliveries d
+        JOIN outbox_events o ON o.id = d.event_id
+        WHERE d.id = $1
+    `
+	d, err := scanDelivery(conn(ctx, r.db).QueryRowContext(ctx, q, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (r *WebhookPostgres) ListDeliveries(
+	ctx context.Context,
+	subscriptionID int64,
+	beforeID int64,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	log := logger.L()
+
+	q := `
+        SELECT d.id, d.subscription_id, d.event_id, o.event_type, d.redelivery_of,
+               d.status, d.attempts, d.next_attempt_at, d.last_attempt_at,
+               d.last_status_code, d.last_error, d.created_at
+        FROM webhook_deliveries d
+        JOIN outbox_events o ON o.id = d.event_id
+        WHERE d.subscription_id = $1
+            AND ($2::bigint = 0 OR d.id < $2)
+        ORDER BY d.id DESC
+        LIMIT $3
+    `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, q, subscriptionID, beforeID, limit)
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.WebhookDelivery
+
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *d)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *WebhookPostgres) CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	log := logger.L()
+
+	q := `
+        INSERT INTO webhook_deliveries (subscription_id, event_id, redelivery_of, status, next_attempt_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id
+    `
+	err := conn(ctx, r.db).QueryRowContext(ctx, q,
+		d.SubscriptionID,
+		d.EventID,
+		d.RedeliveryOf,
+		d.Status,
+		d.NextAttemptAt,
+		d.CreatedAt,
+	).Scan(&d.ID)
+
+	if err != nil {
+		log.Error("failed to execute SQL",
+			slog.String("query", q),
+			slog.Any("err", err),
+		)
+	}
+
+	return err
+}
+
+func scanSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var (
+		sub        domain.WebhookSubscription
+		eventTypes []string
+	)
+	if err := row.Scan(
+		&sub.ID,
+		&sub.URL,
+		&sub.Secret,
+		pq.Array(&eventTypes),
+		&sub.IsActive,
+		&sub.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, t := range eventTypes {
+		sub.EventTypes = append(sub.EventTypes, domain.EventType(t))
+	}
+
+	return &sub, nil
+}
+
+func scanDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	if err := row.Scan(
+		&d.ID,
+		&d.SubscriptionID,
+		&d.EventID,
+		&d.EventType,
+		&d.RedeliveryOf,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastAttemptAt,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func eventTypesToStrings(types []domain.EventType) []string {
+	out := make([]string, 0, len(types))
+	for _, t := range types {
+		out = append(out, string(t))
+	}
+	return out
+}
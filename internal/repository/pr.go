@@ -14,9 +14,25 @@ type PRRepository interface {
 
 	GetByID(ctx context.Context, id string) (*domain.PullRequest, error)
 
+	GetByIDForUpdate(ctx context.Context, id string) (*domain.PullRequest, error)
+
 	ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error)
 
 	UpdateReviewers(ctx context.Context, id string, reviewers []string) error
 
+	RemoveReviewer(ctx context.Context, id string, reviewerID string) error
+
+	ReplaceReviewer(ctx context.Context, id string, oldReviewerID string, newReviewerID string) error
+
+	SetVerdict(ctx context.Context, id string, reviewerID string, verdict domain.ReviewVerdict, at time.Time) error
+
 	UpdateStatusAndMergedAt(ctx context.Context, id string, status domain.PRStatus, mergedAt *time.Time) error
+
+	UpdateStatusAndClosedAt(ctx context.Context, id string, status domain.PRStatus, closedAt *time.Time) error
+
+	SetSource(ctx context.Context, id string, source domain.PRSource) error
+
+	// GetSource returns nil when the pull request was not created from a
+	// Git hosting provider.
+	GetSource(ctx context.Context, id string) (*domain.PRSource, error)
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type PREventRepository interface {
+	Append(ctx context.Context, events []domain.PREvent) error
+
+	ListByPR(ctx context.Context, prID string) ([]domain.PREvent, error)
+
+	// ListStreamAfter returns up to limit streamed events matching filter
+	// positioned after the given one, in stream order. Events that an older
+	// transaction still running could precede are left for a later call.
+	ListStreamAfter(ctx context.Context, after domain.PRStreamPosition, filter domain.PRStreamFilter, limit int) ([]domain.PRStreamEvent, error)
+
+	// LastStreamPosition returns the position of the newest event that can
+	// be streamed, or the zero position when there is none.
+	LastStreamPosition(ctx context.Context) (domain.PRStreamPosition, error)
+}
+
+// PREventNotifier reports the ids of timeline events as the transactions
+// that append them commit. The channel is closed when ctx is done or the
+// notifier fails; an id of 0 means notifications may have been lost, for
+// example while reconnecting to the database.
+type PREventNotifier interface {
+	Listen(ctx context.Context) (<-chan int64, error)
+}
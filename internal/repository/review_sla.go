@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type ReviewSLARepository interface {
+	// ListAwaiting returns the unanswered, not yet escalated reviews of open
+	// pull requests that were assigned at least the team's response time
+	// before now. Teams with SLA tracking turned off are left out.
+	ListAwaiting(ctx context.Context, now time.Time) ([]domain.PendingReview, error)
+
+	// ListOverdue returns the unanswered reviews of open pull requests that
+	// were flagged as overdue, oldest assignment first. An empty teamName
+	// matches all teams.
+	ListOverdue(ctx context.Context, teamName string) ([]domain.PendingReview, error)
+
+	// MarkBreached flags a review as overdue. It reports false when the
+	// review was already flagged, answered or handed to someone else since
+	// assignedAt.
+	MarkBreached(ctx context.Context, prID string, reviewerID string, assignedAt time.Time, at time.Time) (bool, error)
+
+	// MarkEscalated works like MarkBreached for escalations.
+	MarkEscalated(ctx context.Context, prID string, reviewerID string, assignedAt time.Time, at time.Time) (bool, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type ReviewerSyncRepository interface {
+	Enqueue(ctx context.Context, job *domain.ReviewerSyncJob) error
+
+	// ClaimDue returns pending jobs whose next attempt is due and hides them
+	// from other workers until leaseUntil.
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.ReviewerSyncJob, error)
+
+	SaveAttempt(ctx context.Context, job *domain.ReviewerSyncJob) error
+
+	ListByPR(ctx context.Context, prID string) ([]domain.ReviewerSyncJob, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type StalePRRepository interface {
+	// ListStale returns the open pull requests created before openedBefore
+	// or without activity since activeBefore, oldest first. A zero time
+	// matches nothing. Reasons are left for the caller to fill in.
+	ListStale(
+		ctx context.Context,
+		filter domain.StalePRFilter,
+		openedBefore time.Time,
+		activeBefore time.Time,
+	) ([]domain.StalePR, error)
+}
@@ -2,11 +2,71 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 )
 
+// StatsRepository answers the filters without a time window or a status
+// from counters kept up to date by triggers.
 type StatsRepository interface {
-	CountAssignmentsByUser(ctx context.Context) ([]domain.UserAssignmentStat, error)
-	CountReviewersByPR(ctx context.Context) ([]domain.PRReviewerStat, error)
+	CountAssignmentsByUser(ctx context.Context, filter domain.StatsFilter) ([]domain.UserAssignmentStat, error)
+
+	// CountReviewersByPR returns the pull requests by number of reviewers,
+	// most first, at most filter.PRLimit of them when it is set.
+	CountReviewersByPR(ctx context.Context, filter domain.StatsFilter) ([]domain.PRReviewerStat, error)
+
+	// CountByTeam leaves out pull requests without a team.
+	CountByTeam(ctx context.Context, filter domain.StatsFilter) ([]domain.TeamStat, error)
+
+	// CycleTimeByTeam returns the time to merge and the time to approval of
+	// the teams; OpenAge is left empty.
+	CycleTimeByTeam(ctx context.Context, filter domain.CycleTimeFilter) ([]domain.TeamCycleTime, error)
+	CycleTimeByReviewer(ctx context.Context, filter domain.CycleTimeFilter) ([]domain.ReviewerCycleTime, error)
+
+	// OpenAgeByTeam counts the pull requests open at asOf by team and by
+	// age, split by bounds into len(bounds)+1 buckets.
+	OpenAgeByTeam(
+		ctx context.Context,
+		teamName string,
+		asOf time.Time,
+		bounds []time.Duration,
+	) (map[string][]int, error)
+
+	// FairnessMembers returns the members of the teams with the number of
+	// reviews they were assigned within [from, to) on pull requests of the
+	// team; Available, the shares and Load are left empty.
+	FairnessMembers(ctx context.Context, teamName string, from, to time.Time) ([]domain.FairnessMember, error)
+
+	// Activity tells from the audit log whether the users were active over
+	// [from, to), keyed by user.
+	Activity(ctx context.Context, userIDs []string, from, to time.Time) (map[string]domain.Activity, error)
+
+	// CheckCounters compares the counters with the rows they count and
+	// returns the number of counter rows that disagree.
+	CheckCounters(ctx context.Context) (int, error)
+
+	// RebuildCounters recomputes the counters, holding off writers of pull
+	// requests and reviewers meanwhile.
+	RebuildCounters(ctx context.Context) error
+
+	// LastSnapshotDay returns the latest day with a snapshot, nil when
+	// there is none.
+	LastSnapshotDay(ctx context.Context) (*time.Time, error)
+
+	// FirstPRDay returns the day the first pull request was created, nil
+	// when there is none.
+	FirstPRDay(ctx context.Context) (*time.Time, error)
+
+	// SaveSnapshots replaces the snapshots of the days [from, to).
+	SaveSnapshots(ctx context.Context, from, to time.Time) error
+
+	// DeleteSnapshotsBefore removes the snapshots of the days before day
+	// and returns the number of rows removed.
+	DeleteSnapshotsBefore(ctx context.Context, day time.Time) (int64, error)
+
+	// TeamSnapshots and UserSnapshots return the snapshots of the filter
+	// ordered by team or user, then by day.
+	TeamSnapshots(ctx context.Context, filter domain.StatsHistoryFilter) ([]domain.TeamSnapshot, error)
+	UserSnapshots(ctx context.Context, filter domain.StatsHistoryFilter) ([]domain.UserSnapshot, error)
 }
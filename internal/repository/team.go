@@ -12,4 +12,6 @@ type TeamRepository interface {
 	ExistsByName(ctx context.Context, name string) (bool, error)
 
 	GetByName(ctx context.Context, name string) (*domain.Team, error)
+
+	UpdateSettings(ctx context.Context, team *domain.Team) error
 }
@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// TxManager runs fn inside a database transaction. Repository calls made with
+// the context passed to fn take part in that transaction; nested calls reuse it.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 )
@@ -13,7 +14,19 @@ type UserRepository interface {
 
 	ListByTeam(ctx context.Context, teamName string) ([]domain.User, error)
 
+	List(ctx context.Context, filter domain.UserFilter) ([]domain.UserListItem, error)
+
+	Count(ctx context.Context, filter domain.UserFilter) (int, error)
+
 	SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error)
 
-	Upsert(ctx context.Context, user *domain.User) error
+	Upsert(ctx context.Context, user *domain.UserUpsert) error
+
+	SetExternalAccounts(ctx context.Context, userID string, accounts map[string]string) error
+
+	GetByExternalLogin(ctx context.Context, provider string, login string) (*domain.User, error)
+
+	AddMembership(ctx context.Context, userID string, teamName string, isPrimary bool) error
+
+	Anonymize(ctx context.Context, id string, pseudonym string, erasedAt time.Time) error
 }
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error
+
+	GetSubscription(ctx context.Context, id int64) (*domain.WebhookSubscription, error)
+
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+
+	// FanOut creates deliveries for up to limit undispatched outbox events
+	// and marks the events as dispatched. It returns the number of events.
+	FanOut(ctx context.Context, limit int) (int64, error)
+
+	// ClaimDue returns up to limit pending deliveries due at now and moves
+	// their next attempt to leaseUntil, so that other dispatchers skip them
+	// while they are being sent.
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.WebhookDispatch, error)
+
+	// SaveAttempt stores the status, attempt counters and last result of d.
+	SaveAttempt(ctx context.Context, d *domain.WebhookDelivery) error
+
+	GetDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error)
+
+	// ListDeliveries returns deliveries of the subscription, newest first,
+	// with ids below beforeID when it is not zero.
+	ListDeliveries(ctx context.Context, subscriptionID int64, beforeID int64, limit int) ([]domain.WebhookDelivery, error)
+
+	CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+}
@@ -0,0 +1,231 @@
+// Package scheduler runs periodic background jobs. Every job runs on one
+// instance at a time: the instance that takes the job's advisory lock in
+// Postgres runs it, and each scheduled time is recorded in the run history
+// so that it is never run twice.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// maxRunErrorLength caps the error stored with a failed run.
+const maxRunErrorLength = 1000
+
+// Job is a piece of periodic work.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	// Timeout bounds a single run. Zero means the default timeout of the
+	// scheduler.
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	locker         repository.JobLocker
+	runs           repository.JobRunRepository
+	defaultTimeout time.Duration
+	instance       string
+
+	jobs []Job
+}
+
+func New(locker repository.JobLocker, runs repository.JobRunRepository, defaultTimeout time.Duration) *Scheduler {
+	return &Scheduler{
+		locker:         locker,
+		runs:           runs,
+		defaultTimeout: defaultTimeout,
+		instance:       instanceName(),
+	}
+}
+
+// Register adds a job. Jobs must be registered before Run is called.
+func (s *Scheduler) Register(job Job) error {
+	switch {
+	case job.Name == "":
+		return errors.New("job has no name")
+	case job.Run == nil:
+		return fmt.Errorf("job %q has nothing to run", job.Name)
+	case job.Schedule == nil:
+		return fmt.Errorf("job %q has no schedule", job.Name)
+	}
+
+	now := time.Now().UTC()
+	if next := job.Schedule.Next(now); !next.After(now) {
+		return fmt.Errorf("job %q is never due", job.Name)
+	}
+
+	for _, j := range s.jobs {
+		if j.Name == job.Name {
+			return fmt.Errorf("job %q is already registered", job.Name)
+		}
+	}
+
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Run runs the registered jobs on their schedules until ctx is done and
+// waits for the runs in progress to stop.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, job)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// RunJob runs a job for the given scheduled time unless another instance
+// runs it or it already ran for that time. It reports whether the job ran
+// and returns the error of the run.
+func (s *Scheduler) RunJob(ctx context.Context, name string, scheduledAt time.Time) (bool, error) {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return s.execute(ctx, job, scheduledAt)
+		}
+	}
+	return false, fmt.Errorf("job %q is not registered", name)
+}
+
+// PruneHistory removes the runs started before the given time.
+func (s *Scheduler) PruneHistory(ctx context.Context, before time.Time) error {
+	log := logger.L()
+
+	n, err := s.runs.DeleteBefore(ctx, before)
+	if err != nil {
+		log.Error("failed to prune job run history", slog.Any("err", err))
+		return err
+	}
+
+	if n > 0 {
+		log.Info("job run history pruned", slog.Int64("count", n))
+	}
+
+	return nil
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	log := logger.L()
+
+	for {
+		next := job.Schedule.Next(time.Now().UTC())
+		if next.IsZero() {
+			log.Warn("job is never due again", slog.String("job", job.Name))
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		_, _ = s.execute(ctx, job, next)
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, job Job, scheduledAt time.Time) (bool, error) {
+	log := logger.L().With(slog.String("job", job.Name))
+
+	release, locked, err := s.locker.TryLock(ctx, job.Name)
+	if err != nil {
+		log.Error("failed to take job lock", slog.Any("err", err))
+		return false, err
+	}
+	if !locked {
+		log.Debug("job is running on another instance")
+		return false, nil
+	}
+	defer release()
+
+	run := &domain.JobRun{
+		JobName:     job.Name,
+		ScheduledAt: scheduledAt.UTC(),
+		Instance:    s.instance,
+		Status:      domain.JobRunRunning,
+		StartedAt:   time.Now().UTC(),
+	}
+	started, err := s.runs.Start(ctx, run)
+	if err != nil {
+		log.Error("failed to record job run", slog.Any("err", err))
+		return false, err
+	}
+	if !started {
+		log.Debug("job already ran", slog.Time("scheduledAt", run.ScheduledAt))
+		return false, nil
+	}
+
+	timeout := job.Timeout
+	if timeout <= 0 {
+		timeout = s.defaultTimeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	runErr := call(runCtx, job)
+	cancel()
+
+	finishedAt := time.Now().UTC()
+	run.FinishedAt = &finishedAt
+	duration := finishedAt.Sub(run.StartedAt)
+
+	if runErr != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			runErr = fmt.Errorf("timed out after %s: %w", timeout, runErr)
+		}
+		run.Status = domain.JobRunFailed
+		run.Error = runErr.Error()
+		if len(run.Error) > maxRunErrorLength {
+			run.Error = run.Error[:maxRunErrorLength]
+		}
+		log.Error("job failed",
+			slog.Duration("duration", duration),
+			slog.Any("err", runErr),
+		)
+	} else {
+		run.Status = domain.JobRunSucceeded
+		log.Info("job finished", slog.Duration("duration", duration))
+	}
+
+	// The outcome is recorded even when the scheduler is stopping.
+	if err := s.runs.Finish(context.WithoutCancel(ctx), run); err != nil {
+		log.Error("failed to record job outcome", slog.Any("err", err))
+	}
+
+	return true, runErr
+}
+
+// call runs a job, turning a panic into an error so that one broken job
+// does not take the instance down.
+func call(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+func instanceName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
@@ -0,0 +1,266 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/scheduler"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Setup("test")
+}
+
+func TestParse_Next(t *testing.T) {
+	// 2025-10-24 is a Friday.
+	from := time.Date(2025, 10, 24, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 10, 24, 10, 15, 0, 0, time.UTC)},
+		{"7 10 * * *", time.Date(2025, 10, 25, 10, 7, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2025, 10, 27, 9, 0, 0, 0, time.UTC)},
+		{"30 8,18 * * *", time.Date(2025, 10, 24, 18, 30, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week matches.
+		{"0 12 31 * 6", time.Date(2025, 10, 25, 12, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 10, 24, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 10m", time.Date(2025, 10, 24, 10, 10, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := scheduler.Parse(tt.spec)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every -1m",
+		"@yearly",
+	} {
+		_, err := scheduler.Parse(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestEvery_IsAligned(t *testing.T) {
+	s := scheduler.Every(5 * time.Minute)
+
+	// Instances started at different times agree on the runs.
+	a := s.Next(time.Date(2025, 10, 24, 10, 1, 0, 0, time.UTC))
+	b := s.Next(time.Date(2025, 10, 24, 10, 4, 59, 0, time.UTC))
+	require.Equal(t, time.Date(2025, 10, 24, 10, 5, 0, 0, time.UTC), a)
+	require.Equal(t, a, b)
+	require.Equal(t, time.Date(2025, 10, 24, 10, 10, 0, 0, time.UTC), s.Next(a))
+}
+
+type schedulerFixture struct {
+	s      *scheduler.Scheduler
+	locker *mocks.JobLocker
+	runs   *mocks.JobRunRepository
+}
+
+func newScheduler(t *testing.T) *schedulerFixture {
+	f := &schedulerFixture{
+		locker: mocks.NewJobLocker(t),
+		runs:   mocks.NewJobRunRepository(t),
+	}
+	f.s = scheduler.New(f.locker, f.runs, time.Minute)
+	return f
+}
+
+// lock makes TryLock succeed and reports whether the lock was released.
+func (f *schedulerFixture) lock(job string) *bool {
+	released := false
+	f.locker.
+		On("TryLock", mock.Anything, job).
+		Return(func() { released = true }, true, nil).
+		Once()
+	return &released
+}
+
+// finished collects what Finish was called with.
+func (f *schedulerFixture) finished() *[]domain.JobRun {
+	var runs []domain.JobRun
+	f.runs.
+		On("Finish", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			runs = append(runs, *args.Get(1).(*domain.JobRun))
+		}).
+		Return(nil)
+	return &runs
+}
+
+var scheduledAt = time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+
+func TestScheduler_RunJob(t *testing.T) {
+	f := newScheduler(t)
+
+	calls := 0
+	require.NoError(t, f.s.Register(scheduler.Job{
+		Name:     "cleanup",
+		Schedule: scheduler.Every(time.Hour),
+		Run: func(context.Context) error {
+			calls++
+			return nil
+		},
+	}))
+
+	released := f.lock("cleanup")
+	f.runs.
+		On("Start", mock.Anything, mock.MatchedBy(func(run *domain.JobRun) bool {
+			return run.JobName == "cleanup" && run.ScheduledAt.Equal(scheduledAt) && run.Status == domain.JobRunRunning
+		})).
+		Return(true, nil).
+		Once()
+	finished := f.finished()
+
+	ran, err := f.s.RunJob(context.Background(), "cleanup", scheduledAt)
+	require.NoError(t, err)
+	require.True(t, ran)
+	require.Equal(t, 1, calls)
+	require.True(t, *released)
+	require.Len(t, *finished, 1)
+	require.Equal(t, domain.JobRunSucceeded, (*finished)[0].Status)
+	require.NotNil(t, (*finished)[0].FinishedAt)
+}
+
+func TestScheduler_RunJob_Skips(t *testing.T) {
+	job := scheduler.Job{
+		Name:     "cleanup",
+		Schedule: scheduler.Every(time.Hour),
+		Run: func(context.Context) error {
+			t.Fatal("job must not run")
+			return nil
+		},
+	}
+
+	t.Run("locked by another instance", func(t *testing.T) {
+		f := newScheduler(t)
+		require.NoError(t, f.s.Register(job))
+
+		f.locker.
+			On("TryLock", mock.Anything, "cleanup").
+			Return(nil, false, nil).
+			Once()
+
+		ran, err := f.s.RunJob(context.Background(), "cleanup", scheduledAt)
+		require.NoError(t, err)
+		require.False(t, ran)
+	})
+
+	t.Run("already ran", func(t *testing.T) {
+		f := newScheduler(t)
+		require.NoError(t, f.s.Register(job))
+
+		released := f.lock("cleanup")
+		f.runs.
+			On("Start", mock.Anything, mock.Anything).
+			Return(false, nil).
+			Once()
+
+		ran, err := f.s.RunJob(context.Background(), "cleanup", scheduledAt)
+		require.NoError(t, err)
+		require.False(t, ran)
+		require.True(t, *released)
+	})
+}
+
+func TestScheduler_RunJob_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		run     func(ctx context.Context) error
+		wantErr string
+	}{
+		{
+			name:    "error",
+			run:     func(context.Context) error { return errors.New("boom") },
+			wantErr: "boom",
+		},
+		{
+			name:    "panic",
+			run:     func(context.Context) error { panic("boom") },
+			wantErr: "job panicked: boom",
+		},
+		{
+			name:    "timeout",
+			timeout: 10 * time.Millisecond,
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantErr: "timed out after 10ms: context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newScheduler(t)
+			require.NoError(t, f.s.Register(scheduler.Job{
+				Name:     "cleanup",
+				Schedule: scheduler.Every(time.Hour),
+				Timeout:  tt.timeout,
+				Run:      tt.run,
+			}))
+
+			f.lock("cleanup")
+			f.runs.
+				On("Start", mock.Anything, mock.Anything).
+				Return(true, nil).
+				Once()
+			finished := f.finished()
+
+			ran, err := f.s.RunJob(context.Background(), "cleanup", scheduledAt)
+			require.True(t, ran)
+			require.EqualError(t, err, tt.wantErr)
+			require.Len(t, *finished, 1)
+			require.Equal(t, domain.JobRunFailed, (*finished)[0].Status)
+			require.Equal(t, tt.wantErr, (*finished)[0].Error)
+		})
+	}
+}
+
+func TestScheduler_Register_Invalid(t *testing.T) {
+	f := newScheduler(t)
+	run := func(context.Context) error { return nil }
+
+	require.NoError(t, f.s.Register(scheduler.Job{Name: "a", Schedule: scheduler.Every(time.Minute), Run: run}))
+	require.Error(t, f.s.Register(scheduler.Job{Name: "a", Schedule: scheduler.Every(time.Minute), Run: run}))
+	require.Error(t, f.s.Register(scheduler.Job{Schedule: scheduler.Every(time.Minute), Run: run}))
+	require.Error(t, f.s.Register(scheduler.Job{Name: "b", Run: run}))
+	require.Error(t, f.s.Register(scheduler.Job{Name: "c", Schedule: scheduler.Every(time.Minute)}))
+	require.Error(t, f.s.Register(scheduler.Job{Name: "d", Schedule: scheduler.Every(0), Run: run}))
+
+	never, err := scheduler.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	require.Error(t, f.s.Register(scheduler.Job{Name: "e", Schedule: never, Run: run}))
+
+	_, err = f.s.RunJob(context.Background(), "unknown", scheduledAt)
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// AbsenceService keeps the out-of-office periods of users.
+type AbsenceService struct {
+	repo     repository.AbsenceRepository
+	userRepo repository.UserRepository
+}
+
+func NewAbsenceService(repo repository.AbsenceRepository, userRepo repository.UserRepository) *AbsenceService {
+	return &AbsenceService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *AbsenceService) Add(ctx context.Context, absence *domain.Absence) (*domain.Absence, error) {
+	log := logger.L()
+
+	log.Info("adding absence", slog.String("userID", absence.UserID))
+
+	if err := absence.Validate(); err != nil {
+		log.Warn("invalid absence",
+			slog.String("userID", absence.UserID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	if err := s.checkUser(ctx, absence.UserID); err != nil {
+		return nil, err
+	}
+
+	absence.From = absence.From.UTC()
+	absence.To = absence.To.UTC()
+	absence.CreatedAt = time.Now().UTC()
+	if err := s.repo.Create(ctx, absence); err != nil {
+		log.Error("failed to create absence",
+			slog.String("userID", absence.UserID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	log.Info("absence successfully added",
+		slog.String("userID", absence.UserID),
+		slog.Int64("absenceID", absence.ID),
+	)
+
+	return absence, nil
+}
+
+func (s *AbsenceService) Remove(ctx context.Context, id int64) error {
+	log := logger.L()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrAbsenceNotFound) {
+			log.Warn("absence not found", slog.Int64("absenceID", id))
+			return err
+		}
+		log.Error("failed to delete absence",
+			slog.Int64("absenceID", id),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	log.Info("absence successfully removed", slog.Int64("absenceID", id))
+
+	return nil
+}
+
+func (s *AbsenceService) List(ctx context.Context, userID string) ([]domain.Absence, error) {
+	log := logger.L()
+
+	if err := s.checkUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		log.Error("failed to list absences",
+			slog.String("userID", userID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *AbsenceService) checkUser(ctx context.Context, userID string) error {
+	log := logger.L()
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("userID", userID))
+			return err
+		}
+		log.Error("failed to get user",
+			slog.String("userID", userID),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	return nil
+}
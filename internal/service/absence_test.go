@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+)
+
+func TestAbsenceService_Add(t *testing.T) {
+	ctx := context.Background()
+
+	repo := mocks.NewAbsenceRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	svc := service.NewAbsenceService(repo, userRepo)
+
+	from := time.Date(2025, 11, 3, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	to := from.AddDate(0, 0, 5)
+
+	userRepo.On("GetByID", ctx, "u1").Return(&domain.User{ID: "u1"}, nil).Once()
+	repo.
+		On("Create", ctx, mock.MatchedBy(func(a *domain.Absence) bool {
+			return a.UserID == "u1" && a.From.Location() == time.UTC && a.From.Equal(from) && !a.CreatedAt.IsZero()
+		})).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Absence).ID = 7 }).
+		Return(nil).
+		Once()
+
+	absence, err := svc.Add(ctx, &domain.Absence{UserID: "u1", From: from, To: to, Reason: "vacation"})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(7), absence.ID)
+	require.Equal(t, "vacation", absence.Reason)
+}
+
+func TestAbsenceService_Add_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	from := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		absence domain.Absence
+	}{
+		{"no user", domain.Absence{From: from, To: from.Add(time.Hour)}},
+		{"no period", domain.Absence{UserID: "u1"}},
+		{"ends before it starts", domain.Absence{UserID: "u1", From: from, To: from.Add(-time.Hour)}},
+		{"long reason", domain.Absence{UserID: "u1", From: from, To: from.Add(time.Hour), Reason: string(make([]byte, 201))}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewAbsenceService(mocks.NewAbsenceRepository(t), mocks.NewUserRepository(t))
+
+			absence, err := svc.Add(ctx, &tt.absence)
+
+			require.ErrorIs(t, err, domain.ErrInvalidAbsence)
+			require.Nil(t, absence)
+		})
+	}
+}
+
+func TestAbsenceService_Add_UserNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := mocks.NewUserRepository(t)
+	svc := service.NewAbsenceService(mocks.NewAbsenceRepository(t), userRepo)
+
+	userRepo.On("GetByID", ctx, "ghost").Return(nil, domain.ErrUserNotFound).Once()
+
+	from := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	absence, err := svc.Add(ctx, &domain.Absence{UserID: "ghost", From: from, To: from.Add(time.Hour)})
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	require.Nil(t, absence)
+}
+
+func TestAbsenceService_Remove_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	repo := mocks.NewAbsenceRepository(t)
+	svc := service.NewAbsenceService(repo, mocks.NewUserRepository(t))
+
+	repo.On("Delete", ctx, int64(42)).Return(domain.ErrAbsenceNotFound).Once()
+
+	require.ErrorIs(t, svc.Remove(ctx, 42), domain.ErrAbsenceNotFound)
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/cursor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type AuditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// Record appends an event to the audit log. It must be called with the
+// context of the transaction that makes the change, so that the event and
+// the change are committed together. A nil AuditService records nothing.
+func (s *AuditService) Record(
+	ctx context.Context,
+	action domain.AuditAction,
+	entityType string,
+	entityID string,
+	before any,
+	after any,
+) error {
+	if s == nil {
+		return nil
+	}
+
+	log := logger.L()
+
+	event := &domain.AuditEvent{
+		OccurredAt: time.Now().UTC(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	event.ActorID, _ = actor.FromContext(ctx)
+
+	var err error
+	if event.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if event.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+
+	if err := s.auditRepo.Append(ctx, event); err != nil {
+		log.Error("failed to append audit event",
+			slog.String("action", string(action)),
+			slog.String("entityID", entityID),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// Redact drops the snapshots of all events about the entity. A nil
+// AuditService does nothing.
+func (s *AuditService) Redact(ctx context.Context, entityType string, entityID string) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := s.auditRepo.RedactEntity(ctx, entityType, entityID); err != nil {
+		logger.L().Error("failed to redact audit events",
+			slog.String("entityType", entityType),
+			slog.String("entityID", entityID),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// ListEvents returns a page of audit events, newest first. The cursor is the
+// opaque token returned as NextCursor by the previous page.
+func (s *AuditService) ListEvents(
+	ctx context.Context,
+	filter domain.AuditFilter,
+	pageCursor string,
+) (*domain.AuditPage, error) {
+	log := logger.L()
+
+	log.Info("listing audit events",
+		slog.String("entityType", filter.EntityType),
+		slog.String("entityID", filter.EntityID),
+		slog.String("actorID", filter.ActorID),
+		slog.String("action", string(filter.Action)),
+		slog.Int("limit", filter.Limit),
+	)
+
+	position, err := cursor.Decode(pageCursor)
+	if err != nil {
+		log.Warn("invalid audit cursor", slog.String("cursor", pageCursor))
+		return nil, domain.ErrInvalidCursor
+	}
+	if position != "" {
+		filter.BeforeID, err = strconv.ParseInt(position, 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			log.Warn("invalid audit cursor", slog.String("cursor", pageCursor))
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = domain.DefaultAuditPageSize
+	}
+	if filter.Limit > domain.MaxAuditPageSize {
+		filter.Limit = domain.MaxAuditPageSize
+	}
+
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	items, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		log.Error("failed to list audit events", slog.Any("err", err))
+		return nil, err
+	}
+
+	page := &domain.AuditPage{Items: items}
+
+	if len(items) > pageSize {
+		page.Items = items[:pageSize]
+		last := page.Items[pageSize-1].ID
+		page.NextCursor = cursor.Encode(strconv.FormatInt(last, 10))
+	}
+
+	log.Info("audit events successfully listed", slog.Int("count", len(page.Items)))
+
+	return page, nil
+}
+
+func auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// The snapshot types fix the shape of audit payloads independently of the
+// domain structs, so that renaming a field does not change the stored log.
+
+type teamSnapshot struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	SlackChannelID string   `json:"slack_channel_id"`
+	Leads          []string `json:"leads"`
+	ReviewersCount int      `json:"reviewers_count"`
+	ExcludeLeads   bool     `json:"exclude_leads"`
+
+	SLAResponseHours   int    `json:"sla_response_hours"`
+	SLAEscalationHours int    `json:"sla_escalation_hours"`
+	SLAEscalation      string `json:"sla_escalation"`
+}
+
+func auditTeam(t *domain.Team) *teamSnapshot {
+	if t == nil {
+		return nil
+	}
+	return &teamSnapshot{
+		Name:           t.Name,
+		Description:    t.Description,
+		SlackChannelID: t.SlackChannelID,
+		Leads:          t.Leads,
+		ReviewersCount: t.ReviewerPolicy.ReviewersCount,
+		ExcludeLeads:   t.ReviewerPolicy.ExcludeLeads,
+
+		SLAResponseHours:   t.ReviewSLA.ResponseHours,
+		SLAEscalationHours: t.ReviewSLA.EscalationHours,
+		SLAEscalation:      string(t.ReviewSLA.Escalation),
+	}
+}
+
+type userSnapshot struct {
+	ID               string            `json:"id"`
+	Username         string            `json:"username"`
+	TeamName         string            `json:"team_name"`
+	Teams            []string          `json:"teams"`
+	IsActive         bool              `json:"is_active"`
+	Email            string            `json:"email,omitempty"`
+	DisplayName      string            `json:"display_name,omitempty"`
+	ExternalAccounts map[string]string `json:"external_accounts,omitempty"`
+}
+
+func auditUser(u *domain.User) *userSnapshot {
+	if u == nil {
+		return nil
+	}
+	return &userSnapshot{
+		ID:               u.ID,
+		Username:         u.Username,
+		TeamName:         u.TeamName,
+		Teams:            u.Teams,
+		IsActive:         u.IsActive,
+		Email:            u.Email,
+		DisplayName:      u.DisplayName,
+		ExternalAccounts: u.ExternalAccounts,
+	}
+}
+
+type prSnapshot struct {
+	ID                string                          `json:"id"`
+	Name              string                          `json:"name"`
+	AuthorID          string                          `json:"author_id"`
+	TeamName          string                          `json:"team_name"`
+	Status            domain.PRStatus                 `json:"status"`
+	AssignedReviewers []string                        `json:"assigned_reviewers"`
+	Verdicts          map[string]domain.ReviewVerdict `json:"verdicts,omitempty"`
+	MergedAt          *time.Time                      `json:"merged_at,omitempty"`
+	ClosedAt          *time.Time                      `json:"closed_at,omitempty"`
+	Version           int64                           `json:"version"`
+}
+
+// auditPR copies the pull request, so later in-place changes of pr do not
+// leak into a snapshot taken before them.
+func auditPR(pr *domain.PullRequest) *prSnapshot {
+	if pr == nil {
+		return nil
+	}
+
+	snap := &prSnapshot{
+		ID:                pr.ID,
+		Name:              pr.Name,
+		AuthorID:          pr.AuthorID,
+		TeamName:          pr.TeamName,
+		Status:            pr.Status,
+		AssignedReviewers: append([]string(nil), pr.AssignedReviewers...),
+		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
+		Version:           pr.Version,
+	}
+	if len(pr.Verdicts) > 0 {
+		snap.Verdicts = make(map[string]domain.ReviewVerdict, len(pr.Verdicts))
+		for id, v := range pr.Verdicts {
+			snap.Verdicts[id] = v
+		}
+	}
+	return snap
+}
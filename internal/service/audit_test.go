@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/cursor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Setup("test")
+}
+
+func TestAuditService_Record_Actor(t *testing.T) {
+	auditRepo := mocks.NewAuditRepository(t)
+	svc := service.NewAuditService(auditRepo)
+
+	auditRepo.
+		On("Append", mock.Anything, mock.MatchedBy(func(e *domain.AuditEvent) bool {
+			return e.ActorID == "admin" &&
+				e.Action == domain.AuditUserDeactivated &&
+				e.EntityID == "u1" &&
+				json.Valid(e.Before) && json.Valid(e.After)
+		})).
+		Return(nil).
+		Once()
+
+	ctx := actor.WithID(context.Background(), "admin")
+	err := svc.Record(ctx, domain.AuditUserDeactivated, domain.AuditEntityUser, "u1",
+		map[string]bool{"is_active": true},
+		map[string]bool{"is_active": false},
+	)
+
+	require.NoError(t, err)
+}
+
+func TestAuditService_Record_NilService(t *testing.T) {
+	var svc *service.AuditService
+
+	err := svc.Record(context.Background(), domain.AuditTeamCreated, domain.AuditEntityTeam, "backend", nil, nil)
+
+	require.NoError(t, err)
+}
+
+func TestAuditService_ListEvents_NextCursor(t *testing.T) {
+	auditRepo := mocks.NewAuditRepository(t)
+	svc := service.NewAuditService(auditRepo)
+
+	auditRepo.
+		On("List", mock.Anything, mock.MatchedBy(func(f domain.AuditFilter) bool {
+			return f.Limit == 3 && f.BeforeID == 10 && f.EntityID == "pr1"
+		})).
+		Return([]domain.AuditEvent{{ID: 9}, {ID: 7}, {ID: 4}}, nil).
+		Once()
+
+	page, err := svc.ListEvents(context.Background(), domain.AuditFilter{
+		EntityID: "pr1",
+		Limit:    2,
+	}, cursor.Encode("10"))
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, cursor.Encode("7"), page.NextCursor)
+}
+
+func TestAuditService_ListEvents_InvalidCursor(t *testing.T) {
+	svc := service.NewAuditService(nil)
+
+	page, err := svc.ListEvents(context.Background(), domain.AuditFilter{}, cursor.Encode("abc"))
+
+	require.ErrorIs(t, err, domain.ErrInvalidCursor)
+	require.Nil(t, page)
+}
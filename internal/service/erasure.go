@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+const erasedUsernamePrefix = "erased-"
+
+type ErasureService struct {
+	txManager   repository.TxManager
+	userRepo    repository.UserRepository
+	prRepo      repository.PRRepository
+	erasureRepo repository.ErasureRepository
+	prService   *PRService
+	audit       *AuditService
+	outbox      *OutboxService
+}
+
+func NewErasureService(
+	txManager repository.TxManager,
+	userRepo repository.UserRepository,
+	prRepo repository.PRRepository,
+	erasureRepo repository.ErasureRepository,
+	prService *PRService,
+	audit *AuditService,
+	outbox *OutboxService,
+) *ErasureService {
+	return &ErasureService{
+		txManager:   txManager,
+		userRepo:    userRepo,
+		prRepo:      prRepo,
+		erasureRepo: erasureRepo,
+		prService:   prService,
+		audit:       audit,
+		outbox:      outbox,
+	}
+}
+
+// EraseUser anonymizes the user's personal data. The users row is kept so
+// that pull requests and statistics stay intact; open reviews are handed over
+// to other team members, or dropped when nobody can take them. Everything,
+// including the erasure record, is written in a single transaction.
+func (s *ErasureService) EraseUser(ctx context.Context, userID string) (*domain.UserErasure, error) {
+	log := logger.L()
+
+	log.Info("erasing user", slog.String("userID", userID))
+
+	if userID == "" {
+		log.Warn("empty user id provided")
+		return nil, fmt.Errorf("empty user id")
+	}
+
+	requestedBy, _ := actor.FromContext(ctx)
+
+	var erasure *domain.UserErasure
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.ErasedAt != nil {
+			return domain.ErrUserErased
+		}
+
+		prs, err := s.prRepo.ListByReviewer(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		var reassigned, removed int
+		for _, pr := range prs {
+			if pr.Status != domain.PRStatusOpen {
+				continue
+			}
+
+			_, _, err := s.prService.reassignReviewer(ctx, pr.ID, userID, 0, "reviewer was erased")
+			switch {
+			case err == nil:
+				reassigned++
+			case errors.Is(err, domain.ErrNoCandidate):
+				if err := s.removeReviewer(ctx, &pr, userID); err != nil {
+					return err
+				}
+				removed++
+			default:
+				return err
+			}
+		}
+
+		pseudonym, err := newPseudonym()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if err := s.userRepo.Anonymize(ctx, userID, pseudonym, now); err != nil {
+			return err
+		}
+
+		erasure = &domain.UserErasure{
+			UserID:            userID,
+			RequestedBy:       requestedBy,
+			ReassignedReviews: reassigned,
+			RemovedReviews:    removed,
+			ErasedAt:          now,
+		}
+
+		if err := s.erasureRepo.Create(ctx, erasure); err != nil {
+			return err
+		}
+
+		// Earlier snapshots carry the personal data that was just erased.
+		if err := s.audit.Redact(ctx, domain.AuditEntityUser, userID); err != nil {
+			return err
+		}
+		// So do the user events in the outbox, which are kept as webhook
+		// delivery history.
+		if err := s.outbox.RedactUser(ctx, userID, pseudonym); err != nil {
+			return err
+		}
+
+		err = s.audit.Record(ctx, domain.AuditUserErased, domain.AuditEntityUser, userID, nil, erasureSnapshot{
+			ReassignedReviews: reassigned,
+			RemovedReviews:    removed,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Publish(ctx, domain.EventUserErased, erasedUserPayload{ID: userID})
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrUserErased) {
+			log.Warn("user can not be erased",
+				slog.String("userID", userID),
+				slog.Any("err", err),
+			)
+			return nil, err
+		}
+		log.Error("failed to erase user",
+			slog.String("userID", userID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	log.Info("user successfully erased",
+		slog.String("userID", userID),
+		slog.Int("reassignedReviews", erasure.ReassignedReviews),
+		slog.Int("removedReviews", erasure.RemovedReviews),
+	)
+
+	return erasure, nil
+}
+
+func (s *ErasureService) removeReviewer(ctx context.Context, pr *domain.PullRequest, reviewerID string) error {
+	before := auditPR(pr)
+
+	if err := s.prRepo.RemoveReviewer(ctx, pr.ID, reviewerID); err != nil {
+		return err
+	}
+
+	reviewers := make([]string, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		if id != reviewerID {
+			reviewers = append(reviewers, id)
+		}
+	}
+	pr.AssignedReviewers = reviewers
+	pr.Version++
+
+	if err := s.prService.recordEvents(ctx, domain.PREvent{
+		PRID:       pr.ID,
+		Type:       domain.PREventReviewerRemoved,
+		ReviewerID: reviewerID,
+		Reason:     "reviewer was erased and no replacement was available",
+	}); err != nil {
+		return err
+	}
+
+	if err := s.audit.Record(ctx, domain.AuditPRReviewerRemoved, domain.AuditEntityPullRequest, pr.ID, before, auditPR(pr)); err != nil {
+		return err
+	}
+
+	return s.outbox.Publish(ctx, domain.EventPRReviewerRemoved, prEventPayload{
+		PullRequest: auditPR(pr),
+		ReviewerID:  reviewerID,
+	})
+}
+
+type erasedUserPayload struct {
+	ID string `json:"id"`
+}
+
+type erasureSnapshot struct {
+	ReassignedReviews int `json:"reassigned_reviews"`
+	RemovedReviews    int `json:"removed_reviews"`
+}
+
+func newPseudonym() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return erasedUsernamePrefix + hex.EncodeToString(b), nil
+}
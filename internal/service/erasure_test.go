@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Setup("test")
+}
+
+func TestErasureService_EraseUser_Success(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	erasureRepo := mocks.NewErasureRepository(t)
+
+	prSvc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, nil)
+	svc := service.NewErasureService(passthroughTx(t), userRepo, prRepo, erasureRepo, prSvc, nil, nil)
+
+	ctx := actor.WithID(context.Background(), "admin")
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", TeamName: "backend"}, nil)
+
+	prRepo.
+		On("ListByReviewer", mock.Anything, "u2").
+		Return([]domain.PullRequest{
+			{ID: "pr1", Status: domain.PRStatusOpen},
+			{ID: "pr2", Status: domain.PRStatusOpen},
+			{ID: "pr3", Status: domain.PRStatusMerged},
+		}, nil).
+		Once()
+
+	// pr1 can be handed over to u3
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{
+			ID:                "pr1",
+			AuthorID:          "u1",
+			TeamName:          "backend",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}, nil).
+		Once()
+
+	// pr2 has nobody left to review it
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr2").
+		Return(&domain.PullRequest{
+			ID:                "pr2",
+			AuthorID:          "u3",
+			TeamName:          "backend",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2", "u1"},
+		}, nil).
+		Once()
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil)
+
+	userRepo.
+		On("ListActiveByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}, nil)
+
+	prRepo.
+		On("ReplaceReviewer", mock.Anything, "pr1", "u2", "u3").
+		Return(nil).
+		Once()
+
+	prRepo.
+		On("RemoveReviewer", mock.Anything, "pr2", "u2").
+		Return(nil).
+		Once()
+
+	userRepo.
+		On("Anonymize", mock.Anything, "u2",
+			mock.MatchedBy(func(p string) bool { return strings.HasPrefix(p, "erased-") && !strings.Contains(p, "bob") }),
+			mock.AnythingOfType("time.Time"),
+		).
+		Return(nil).
+		Once()
+
+	erasureRepo.
+		On("Create", mock.Anything, mock.MatchedBy(func(e *domain.UserErasure) bool {
+			return e.UserID == "u2" &&
+				e.RequestedBy == "admin" &&
+				e.ReassignedReviews == 1 &&
+				e.RemovedReviews == 1
+		})).
+		Return(nil).
+		Once()
+
+	erasure, err := svc.EraseUser(ctx, "u2")
+
+	require.NoError(t, err)
+	require.Equal(t, "u2", erasure.UserID)
+	require.Equal(t, 1, erasure.ReassignedReviews)
+	require.Equal(t, 1, erasure.RemovedReviews)
+}
+
+func TestErasureService_EraseUser_RedactsOutboxEvents(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	erasureRepo := mocks.NewErasureRepository(t)
+	outboxRepo := mocks.NewOutboxRepository(t)
+
+	svc := service.NewErasureService(passthroughTx(t), userRepo, prRepo, erasureRepo, nil, nil, service.NewOutboxService(outboxRepo))
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", TeamName: "backend"}, nil).
+		Once()
+
+	prRepo.
+		On("ListByReviewer", mock.Anything, "u2").
+		Return([]domain.PullRequest{}, nil).
+		Once()
+
+	var pseudonym string
+	userRepo.
+		On("Anonymize", mock.Anything, "u2", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { pseudonym = args.String(2) }).
+		Return(nil).
+		Once()
+
+	erasureRepo.
+		On("Create", mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	outboxRepo.
+		On("RedactUser", mock.Anything, "u2", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) {
+			require.Equal(t, pseudonym, args.String(2))
+			require.NotContains(t, args.String(2), "bob")
+		}).
+		Return(nil).
+		Once()
+
+	outboxRepo.
+		On("Append", mock.Anything, mock.MatchedBy(func(e *domain.OutboxEvent) bool {
+			return e.Type == domain.EventUserErased && !strings.Contains(string(e.Payload), "bob")
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.EraseUser(context.Background(), "u2")
+
+	require.NoError(t, err)
+}
+
+func TestErasureService_EraseUser_AlreadyErased(t *testing.T) {
+	userRepo := mocks.NewUserRepository(t)
+
+	svc := service.NewErasureService(passthroughTx(t), userRepo, nil, nil, nil, nil, nil)
+
+	erasedAt := time.Now()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", ErasedAt: &erasedAt}, nil).
+		Once()
+
+	erasure, err := svc.EraseUser(context.Background(), "u2")
+
+	require.ErrorIs(t, err, domain.ErrUserErased)
+	require.Nil(t, erasure)
+}
+
+func TestErasureService_EraseUser_NotFound(t *testing.T) {
+	userRepo := mocks.NewUserRepository(t)
+
+	svc := service.NewErasureService(passthroughTx(t), userRepo, nil, nil, nil, nil, nil)
+
+	userRepo.
+		On("GetByID", mock.Anything, "u404").
+		Return(nil, domain.ErrUserNotFound).
+		Once()
+
+	erasure, err := svc.EraseUser(context.Background(), "u404")
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	require.Nil(t, erasure)
+}
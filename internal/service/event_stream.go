@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/cursor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// listenRetryDelay is how long Run waits before listening again after the
+// notifier failed.
+const listenRetryDelay = 5 * time.Second
+
+// EventStreamService pushes timeline events to live subscribers of this
+// replica. The database announces events when their transaction commits, so
+// subscribers see changes made through any replica; the events are then read
+// in stream order, see domain.PRStreamPosition.
+type EventStreamService struct {
+	eventRepo    repository.PREventRepository
+	notifier     repository.PREventNotifier
+	bufferSize   int
+	backlogLimit int
+	pollInterval time.Duration
+
+	mu   sync.Mutex
+	subs map[*PRStreamSubscription]struct{}
+	// position is where the fan-out got to; it is set once started.
+	position domain.PRStreamPosition
+	started  bool
+}
+
+// NewEventStreamService creates the service. Events held back behind an
+// older transaction that appends no events itself are picked up by polling
+// every pollInterval.
+func NewEventStreamService(
+	eventRepo repository.PREventRepository,
+	notifier repository.PREventNotifier,
+	bufferSize int,
+	backlogLimit int,
+	pollInterval time.Duration,
+) *EventStreamService {
+	return &EventStreamService{
+		eventRepo:    eventRepo,
+		notifier:     notifier,
+		bufferSize:   bufferSize,
+		backlogLimit: backlogLimit,
+		pollInterval: pollInterval,
+		subs:         map[*PRStreamSubscription]struct{}{},
+	}
+}
+
+// EncodeStreamPosition returns the SSE event id subscribers resume from.
+func EncodeStreamPosition(p domain.PRStreamPosition) string {
+	return cursor.Encode(fmt.Sprintf("%d:%d", p.TxID, p.EventID))
+}
+
+// DecodeStreamPosition reads a Last-Event-ID. It returns
+// domain.ErrInvalidCursor for ids not made by EncodeStreamPosition.
+func DecodeStreamPosition(token string) (domain.PRStreamPosition, error) {
+	raw, err := cursor.Decode(token)
+	if err != nil || raw == "" {
+		return domain.PRStreamPosition{}, domain.ErrInvalidCursor
+	}
+
+	rawTx, rawID, ok := strings.Cut(raw, ":")
+	txID, txErr := strconv.ParseInt(rawTx, 10, 64)
+	eventID, idErr := strconv.ParseInt(rawID, 10, 64)
+	if !ok || txErr != nil || idErr != nil || txID < 0 || eventID < 0 {
+		return domain.PRStreamPosition{}, domain.ErrInvalidCursor
+	}
+
+	return domain.PRStreamPosition{TxID: txID, EventID: eventID}, nil
+}
+
+// PRStreamSubscription receives the events matching its filter. The channel
+// is closed when the subscriber falls too far behind; it should reconnect
+// and resume from the last event it got.
+type PRStreamSubscription struct {
+	filter domain.PRStreamFilter
+	events chan domain.PRStreamEvent
+}
+
+func (sub *PRStreamSubscription) Events() <-chan domain.PRStreamEvent {
+	return sub.events
+}
+
+// Subscribe registers a subscriber. Call Unsubscribe when done.
+func (s *EventStreamService) Subscribe(filter domain.PRStreamFilter) *PRStreamSubscription {
+	sub := &PRStreamSubscription{
+		filter: filter,
+		events: make(chan domain.PRStreamEvent, s.bufferSize),
+	}
+
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	logger.L().Info("event stream subscribed",
+		slog.String("userID", filter.UserID),
+		slog.String("teamName", filter.TeamName),
+	)
+
+	return sub
+}
+
+func (s *EventStreamService) Unsubscribe(sub *PRStreamSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[sub]; ok {
+		delete(s.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Backlog returns a page of stored events after the given position for a
+// subscriber resuming with Last-Event-ID. A full page means there may be
+// more.
+func (s *EventStreamService) Backlog(
+	ctx context.Context,
+	filter domain.PRStreamFilter,
+	after domain.PRStreamPosition,
+) ([]domain.PRStreamEvent, bool, error) {
+	events, err := s.eventRepo.ListStreamAfter(ctx, after, filter, s.backlogLimit)
+	if err != nil {
+		logger.L().Error("failed to load event stream backlog",
+			slog.String("after", EncodeStreamPosition(after)),
+			slog.Any("err", err),
+		)
+		return nil, false, err
+	}
+
+	return events, len(events) == s.backlogLimit, nil
+}
+
+// Run fans out announced events until ctx is done, listening again whenever
+// the notifier fails.
+func (s *EventStreamService) Run(ctx context.Context) {
+	log := logger.L()
+
+	for {
+		ids, err := s.notifier.Listen(ctx)
+		if err == nil {
+			// Anything committed while not listening is picked up first.
+			s.catchUp(ctx)
+			s.consume(ctx, ids)
+		} else {
+			log.Error("failed to listen for pr events", slog.Any("err", err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Warn("event stream notifier stopped, listening again", slog.Duration("delay", listenRetryDelay))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (s *EventStreamService) consume(ctx context.Context, ids <-chan int64) {
+	poll := time.NewTicker(s.pollInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case _, ok := <-ids:
+			if !ok {
+				return
+			}
+			// Notifications arrive in bursts, one per event of a
+			// transaction; one catch-up loads them all. Which ids were
+			// announced does not matter: events are read in stream
+			// order, lost notifications included.
+		drain:
+			for {
+				select {
+				case _, ok := <-ids:
+					if !ok {
+						break drain
+					}
+				default:
+					break drain
+				}
+			}
+		case <-poll.C:
+		}
+
+		s.catchUp(ctx)
+	}
+}
+
+// catchUp publishes the events after the last one fanned out. On the first
+// call it only remembers where the stream starts: subscribers resuming from
+// earlier events load their own backlog.
+func (s *EventStreamService) catchUp(ctx context.Context) {
+	s.mu.Lock()
+	started, after := s.started, s.position
+	s.mu.Unlock()
+
+	if !started {
+		pos, err := s.eventRepo.LastStreamPosition(ctx)
+		if err != nil {
+			logger.L().Error("failed to get last pr event stream position", slog.Any("err", err))
+			return
+		}
+
+		s.mu.Lock()
+		s.position, s.started = pos, true
+		s.mu.Unlock()
+		return
+	}
+
+	for {
+		events, more, err := s.Backlog(ctx, domain.PRStreamFilter{}, after)
+		if err != nil {
+			return
+		}
+		s.publish(events)
+		if !more {
+			return
+		}
+		after = events[len(events)-1].Position
+	}
+}
+
+func (s *EventStreamService) publish(events []domain.PRStreamEvent) {
+	log := logger.L()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range events {
+		e := &events[i]
+		if !s.position.Before(e.Position) {
+			continue
+		}
+		s.position = e.Position
+
+		for sub := range s.subs {
+			if !sub.filter.Matches(e) {
+				continue
+			}
+
+			select {
+			case sub.events <- *e:
+			default:
+				log.Warn("event stream subscriber is too slow, dropping it",
+					slog.String("userID", sub.filter.UserID),
+					slog.String("teamName", sub.filter.TeamName),
+				)
+				delete(s.subs, sub)
+				close(sub.events)
+			}
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/cursor"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func streamEvent(id int64, typ domain.PREventType, reviewerID string, teamName string) domain.PRStreamEvent {
+	return domain.PRStreamEvent{
+		PREvent:  domain.PREvent{ID: id, PRID: "pr1", Type: typ, ReviewerID: reviewerID},
+		AuthorID: "u1",
+		TeamName: teamName,
+		Position: domain.PRStreamPosition{TxID: id, EventID: id},
+	}
+}
+
+// runStream starts the service on a notifier fed by the returned channel.
+func runStream(
+	t *testing.T,
+	eventRepo *mocks.PREventRepository,
+	bufferSize int,
+	pollInterval time.Duration,
+) (*service.EventStreamService, chan int64) {
+	ids := make(chan int64)
+
+	notifier := mocks.NewPREventNotifier(t)
+	notifier.
+		On("Listen", mock.Anything).
+		Return((<-chan int64)(ids), nil).
+		Once()
+
+	svc := service.NewEventStreamService(eventRepo, notifier, bufferSize, 100, pollInterval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		close(ids)
+		<-done
+	})
+
+	return svc, ids
+}
+
+func receive(t *testing.T, sub *service.PRStreamSubscription) (domain.PRStreamEvent, bool) {
+	t.Helper()
+
+	select {
+	case e, ok := <-sub.Events():
+		return e, ok
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return domain.PRStreamEvent{}, false
+	}
+}
+
+func pos(txID, eventID int64) domain.PRStreamPosition {
+	return domain.PRStreamPosition{TxID: txID, EventID: eventID}
+}
+
+func TestEventStreamService_FansOutMatchingEvents(t *testing.T) {
+	eventRepo := mocks.NewPREventRepository(t)
+
+	eventRepo.
+		On("LastStreamPosition", mock.Anything).
+		Return(pos(2, 2), nil).
+		Once()
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(2, 2), domain.PRStreamFilter{}, 100).
+		Return([]domain.PRStreamEvent{
+			streamEvent(3, domain.PREventReviewerAssigned, "u2", "backend"),
+			streamEvent(4, domain.PREventMerged, "", "backend"),
+		}, nil).
+		Once()
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(4, 4), domain.PRStreamFilter{}, 100).
+		Return(nil, nil).
+		Maybe()
+
+	svc, ids := runStream(t, eventRepo, 16, time.Hour)
+
+	mine := svc.Subscribe(domain.PRStreamFilter{UserID: "u2"})
+	author := svc.Subscribe(domain.PRStreamFilter{UserID: "u1"})
+	otherTeam := svc.Subscribe(domain.PRStreamFilter{TeamName: "frontend"})
+
+	ids <- 3
+	ids <- 4
+
+	e, ok := receive(t, mine)
+	require.True(t, ok)
+	require.Equal(t, int64(3), e.ID)
+
+	// The author is told about the assignment and the merge.
+	e, _ = receive(t, author)
+	require.Equal(t, int64(3), e.ID)
+	e, _ = receive(t, author)
+	require.Equal(t, domain.PREventMerged, e.Type)
+
+	require.Empty(t, otherTeam.Events())
+}
+
+func TestEventStreamService_DropsSlowSubscriber(t *testing.T) {
+	eventRepo := mocks.NewPREventRepository(t)
+
+	eventRepo.
+		On("LastStreamPosition", mock.Anything).
+		Return(domain.PRStreamPosition{}, nil).
+		Once()
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, domain.PRStreamPosition{}, domain.PRStreamFilter{}, 100).
+		Return([]domain.PRStreamEvent{
+			streamEvent(1, domain.PREventReviewerAssigned, "u2", "backend"),
+			streamEvent(2, domain.PREventReviewerAssigned, "u3", "backend"),
+		}, nil).
+		Once()
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(2, 2), domain.PRStreamFilter{}, 100).
+		Return(nil, nil).
+		Maybe()
+
+	svc, ids := runStream(t, eventRepo, 1, time.Hour)
+
+	sub := svc.Subscribe(domain.PRStreamFilter{})
+	ids <- 1
+
+	e, ok := receive(t, sub)
+	require.True(t, ok)
+	require.Equal(t, int64(1), e.ID)
+
+	_, ok = receive(t, sub)
+	require.False(t, ok, "the subscriber is disconnected instead of blocking the stream")
+
+	svc.Unsubscribe(sub)
+}
+
+func TestEventStreamService_PollsForHeldBackEvents(t *testing.T) {
+	eventRepo := mocks.NewPREventRepository(t)
+
+	eventRepo.
+		On("LastStreamPosition", mock.Anything).
+		Return(pos(10, 10), nil).
+		Once()
+
+	// Event 11 is held back while an older transaction is running and is
+	// not announced again once that transaction ends.
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(10, 10), domain.PRStreamFilter{}, 100).
+		Return(nil, nil).
+		Once()
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(10, 10), domain.PRStreamFilter{}, 100).
+		Return([]domain.PRStreamEvent{streamEvent(11, domain.PREventReviewerReassigned, "u2", "backend")}, nil).
+		Once()
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(11, 11), domain.PRStreamFilter{}, 100).
+		Return(nil, nil).
+		Maybe()
+
+	svc, ids := runStream(t, eventRepo, 16, 10*time.Millisecond)
+
+	sub := svc.Subscribe(domain.PRStreamFilter{TeamName: "backend"})
+	ids <- 11
+
+	e, ok := receive(t, sub)
+	require.True(t, ok)
+	require.Equal(t, int64(11), e.ID)
+}
+
+func TestEventStreamService_Backlog(t *testing.T) {
+	eventRepo := mocks.NewPREventRepository(t)
+	svc := service.NewEventStreamService(eventRepo, nil, 16, 2, time.Second)
+
+	filter := domain.PRStreamFilter{UserID: "u2"}
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(5, 5), filter, 2).
+		Return([]domain.PRStreamEvent{
+			streamEvent(6, domain.PREventReviewerAssigned, "u2", "backend"),
+			streamEvent(9, domain.PREventReviewerAssigned, "u2", "backend"),
+		}, nil).
+		Once()
+
+	events, more, err := svc.Backlog(context.Background(), filter, pos(5, 5))
+
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.True(t, more)
+}
+
+func TestEventStreamService_FollowsTransactionOrder(t *testing.T) {
+	eventRepo := mocks.NewPREventRepository(t)
+
+	eventRepo.
+		On("LastStreamPosition", mock.Anything).
+		Return(pos(10, 10), nil).
+		Once()
+
+	// Event 7 was inserted before 9 but by a later transaction.
+	late := streamEvent(7, domain.PREventReviewerAssigned, "u2", "backend")
+	late.Position = pos(12, 7)
+	merged := streamEvent(9, domain.PREventMerged, "", "backend")
+	merged.Position = pos(11, 9)
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(10, 10), domain.PRStreamFilter{}, 100).
+		Return([]domain.PRStreamEvent{merged, late}, nil).
+		Once()
+
+	eventRepo.
+		On("ListStreamAfter", mock.Anything, pos(12, 7), domain.PRStreamFilter{}, 100).
+		Return(nil, nil).
+		Maybe()
+
+	svc, ids := runStream(t, eventRepo, 16, time.Hour)
+
+	sub := svc.Subscribe(domain.PRStreamFilter{})
+	ids <- 9
+	ids <- 7
+
+	e, ok := receive(t, sub)
+	require.True(t, ok)
+	require.Equal(t, int64(9), e.ID)
+
+	e, ok = receive(t, sub)
+	require.True(t, ok)
+	require.Equal(t, int64(7), e.ID)
+
+	select {
+	case e := <-sub.Events():
+		t.Fatalf("event %d published twice", e.ID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestStreamPosition_RoundTrip(t *testing.T) {
+	token := service.EncodeStreamPosition(pos(812, 40))
+
+	got, err := service.DecodeStreamPosition(token)
+
+	require.NoError(t, err)
+	require.Equal(t, pos(812, 40), got)
+}
+
+func TestDecodeStreamPosition_Invalid(t *testing.T) {
+	for _, token := range []string{"42", "!!", cursor.Encode("1"), cursor.Encode("a:1"), cursor.Encode("-1:1")} {
+		_, err := service.DecodeStreamPosition(token)
+		require.ErrorIs(t, err, domain.ErrInvalidCursor, token)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// GetFairness reports how evenly reviews were assigned within the teams
+// over a period. A member's fair share of the assignments is their share of
+// the time the team's members were active and in office; members who were
+// not available at all are left out.
+func (s *StatsService) GetFairness(ctx context.Context, filter domain.FairnessFilter) (*domain.Fairness, error) {
+	log := logger.L()
+
+	if err := filter.Validate(); err != nil {
+		log.Warn("invalid fairness filter", slog.Any("err", err))
+		return nil, err
+	}
+
+	if err := s.checkTeam(ctx, filter.TeamName); err != nil {
+		return nil, err
+	}
+
+	// Nobody is available in the future.
+	to := time.Now().UTC()
+	if filter.To != nil && filter.To.Before(to) {
+		to = filter.To.UTC()
+	}
+	from := to.Add(-domain.DefaultFairnessWindow)
+	if filter.From != nil {
+		from = filter.From.UTC()
+	}
+	if !from.Before(to) {
+		return &domain.Fairness{From: from, To: to, Teams: []domain.TeamFairness{}}, nil
+	}
+
+	members, err := s.statsRepo.FairnessMembers(ctx, filter.TeamName, from, to)
+	if err != nil {
+		log.Error("failed to get team members load", slog.Any("err", err))
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, m := range members {
+		if !slices.Contains(userIDs, m.UserID) {
+			userIDs = append(userIDs, m.UserID)
+		}
+	}
+
+	activity, err := s.statsRepo.Activity(ctx, userIDs, from, to)
+	if err != nil {
+		log.Error("failed to get user activity", slog.Any("err", err))
+		return nil, err
+	}
+
+	absences, err := s.absenceRepo.ListOverlapping(ctx, userIDs, from, to)
+	if err != nil {
+		log.Error("failed to list absences", slog.Any("err", err))
+		return nil, err
+	}
+
+	report := &domain.Fairness{From: from, To: to, Teams: []domain.TeamFairness{}}
+	for i := 0; i < len(members); {
+		j := i
+		for j < len(members) && members[j].TeamName == members[i].TeamName {
+			members[j].Available = activity[members[j].UserID].Available(from, to, absences[members[j].UserID])
+			j++
+		}
+		report.Teams = append(report.Teams, teamFairness(members[i].TeamName, members[i:j]))
+		i = j
+	}
+
+	log.Info("fairness report successfully collected", slog.Int("teams", len(report.Teams)))
+
+	return report, nil
+}
+
+// teamFairness sums up the load of the members of a team.
+func teamFairness(teamName string, members []domain.FairnessMember) domain.TeamFairness {
+	team := domain.TeamFairness{
+		TeamName:    teamName,
+		Members:     []domain.FairnessMember{},
+		Overloaded:  []string{},
+		Underloaded: []string{},
+	}
+
+	var available time.Duration
+	for _, m := range members {
+		if m.Available > 0 {
+			team.Members = append(team.Members, m)
+			team.Assignments += m.Assignments
+			available += m.Available
+		}
+	}
+	if available == 0 {
+		return team
+	}
+
+	for i := range team.Members {
+		m := &team.Members[i]
+		m.ExpectedShare = float64(m.Available) / float64(available)
+		if team.Assignments > 0 {
+			m.Share = float64(m.Assignments) / float64(team.Assignments)
+			m.Load = m.Share / m.ExpectedShare
+		}
+	}
+
+	if team.Assignments == 0 {
+		return team
+	}
+
+	team.Gini = gini(team.Members, available, team.Assignments)
+
+	byLoad := slices.Clone(team.Members)
+	slices.SortStableFunc(byLoad, func(a, b domain.FairnessMember) int {
+		return cmp.Compare(b.Load, a.Load)
+	})
+	for _, m := range byLoad {
+		if m.Load > 1 && len(team.Overloaded) < domain.FairnessTop {
+			team.Overloaded = append(team.Overloaded, m.UserID)
+		}
+	}
+	for _, m := range slices.Backward(byLoad) {
+		if m.Load < 1 && len(team.Underloaded) < domain.FairnessTop {
+			team.Underloaded = append(team.Underloaded, m.UserID)
+		}
+	}
+
+	return team
+}
+
+// gini is the Gini coefficient of the members' assignments per unit of
+// available time, each member weighted by their available time.
+func gini(members []domain.FairnessMember, available time.Duration, assignments int) float64 {
+	rate := func(m domain.FairnessMember) float64 {
+		return float64(m.Assignments) / m.Available.Hours()
+	}
+
+	var sum float64
+	for _, a := range members {
+		for _, b := range members {
+			sum += a.Available.Hours() * b.Available.Hours() * math.Abs(rate(a)-rate(b))
+		}
+	}
+
+	return sum / (2 * available.Hours() * float64(assignments))
+}
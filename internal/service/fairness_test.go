@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+)
+
+func TestStatsService_GetFairness(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	absenceRepo := mocks.NewAbsenceRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), absenceRepo)
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+	midway := from.AddDate(0, 0, 5)
+
+	statsRepo.
+		On("FairnessMembers", ctx, "", from, to).
+		Return([]domain.FairnessMember{
+			{TeamName: "backend", UserID: "u1", Assignments: 6},
+			{TeamName: "backend", UserID: "u2", Assignments: 2},
+			{TeamName: "backend", UserID: "u3", Assignments: 2},
+			{TeamName: "backend", UserID: "u4"},
+			{TeamName: "backend", UserID: "u5"},
+			{TeamName: "frontend", UserID: "f1"},
+		}, nil).
+		Once()
+
+	userIDs := []string{"u1", "u2", "u3", "u4", "u5", "f1"}
+	statsRepo.
+		On("Activity", ctx, userIDs, from, to).
+		Return(map[string]domain.Activity{
+			"u1": {ActiveAtStart: true},
+			"u2": {ActiveAtStart: true},
+			"u3": {ActiveAtStart: true},
+			"u4": {ActiveAtStart: false},
+			"u5": {ActiveAtStart: true, Changes: []domain.ActivityChange{{At: midway, Active: false}}},
+			"f1": {ActiveAtStart: true},
+		}, nil).
+		Once()
+
+	absenceRepo.
+		On("ListOverlapping", ctx, userIDs, from, to).
+		Return(map[string][]domain.Absence{
+			"u3": {{UserID: "u3", From: from.AddDate(0, 0, -3), To: midway}},
+		}, nil).
+		Once()
+
+	report, err := svc.GetFairness(ctx, domain.FairnessFilter{From: &from, To: &to})
+
+	require.NoError(t, err)
+	require.Equal(t, from, report.From)
+	require.Equal(t, to, report.To)
+	require.Len(t, report.Teams, 2)
+
+	backend := report.Teams[0]
+	require.Equal(t, "backend", backend.TeamName)
+	require.Equal(t, 10, backend.Assignments)
+	require.InDelta(t, 0.3667, backend.Gini, 1e-4)
+
+	// u4 was inactive all along.
+	require.Len(t, backend.Members, 4)
+	load := map[string]float64{}
+	for _, m := range backend.Members {
+		load[m.UserID] = m.Load
+	}
+	require.InDelta(t, 1.8, load["u1"], 1e-9)
+	require.InDelta(t, 0.6, load["u2"], 1e-9)
+	require.InDelta(t, 1.2, load["u3"], 1e-9)
+	require.InDelta(t, 0, load["u5"], 1e-9)
+
+	u3 := backend.Members[2]
+	require.Equal(t, 5*24*time.Hour, u3.Available)
+	require.InDelta(t, 1.0/6, u3.ExpectedShare, 1e-9)
+	require.InDelta(t, 0.2, u3.Share, 1e-9)
+
+	require.Equal(t, []string{"u1", "u3"}, backend.Overloaded)
+	require.Equal(t, []string{"u5", "u2"}, backend.Underloaded)
+
+	// Without assignments there is nothing to compare.
+	frontend := report.Teams[1]
+	require.Equal(t, "frontend", frontend.TeamName)
+	require.Zero(t, frontend.Gini)
+	require.Empty(t, frontend.Overloaded)
+	require.Empty(t, frontend.Underloaded)
+	require.Equal(t, 1.0, frontend.Members[0].ExpectedShare)
+}
+
+func TestStatsService_GetFairness_EvenLoad(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	absenceRepo := mocks.NewAbsenceRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), absenceRepo)
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+
+	statsRepo.
+		On("FairnessMembers", ctx, "", from, to).
+		Return([]domain.FairnessMember{
+			{TeamName: "backend", UserID: "u1", Assignments: 4},
+			{TeamName: "backend", UserID: "u2", Assignments: 2},
+		}, nil).
+		Once()
+	statsRepo.
+		On("Activity", ctx, []string{"u1", "u2"}, from, to).
+		Return(map[string]domain.Activity{
+			"u1": {ActiveAtStart: true},
+			"u2": {ActiveAtStart: true},
+		}, nil).
+		Once()
+	// u2 was out for half of the period, so half the reviews is fair.
+	absenceRepo.
+		On("ListOverlapping", ctx, []string{"u1", "u2"}, from, to).
+		Return(map[string][]domain.Absence{
+			"u2": {{UserID: "u2", From: from, To: from.AddDate(0, 0, 5)}},
+		}, nil).
+		Once()
+
+	report, err := svc.GetFairness(ctx, domain.FairnessFilter{From: &from, To: &to})
+
+	require.NoError(t, err)
+	require.Len(t, report.Teams, 1)
+	require.InDelta(t, 0, report.Teams[0].Gini, 1e-9)
+	require.Empty(t, report.Teams[0].Overloaded)
+	require.Empty(t, report.Teams[0].Underloaded)
+}
+
+func TestStatsService_GetFairness_DefaultWindow(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	absenceRepo := mocks.NewAbsenceRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(statsRepo, teamRepo, absenceRepo)
+
+	teamRepo.On("ExistsByName", ctx, "backend").Return(true, nil).Once()
+
+	var window time.Duration
+	statsRepo.
+		On("FairnessMembers", ctx, "backend", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			window = args.Get(3).(time.Time).Sub(args.Get(2).(time.Time))
+		}).
+		Return([]domain.FairnessMember{}, nil).
+		Once()
+	statsRepo.On("Activity", ctx, []string{}, mock.Anything, mock.Anything).Return(map[string]domain.Activity{}, nil).Once()
+	absenceRepo.On("ListOverlapping", ctx, []string{}, mock.Anything, mock.Anything).Return(map[string][]domain.Absence{}, nil).Once()
+
+	report, err := svc.GetFairness(ctx, domain.FairnessFilter{TeamName: "backend"})
+
+	require.NoError(t, err)
+	require.Empty(t, report.Teams)
+	require.Equal(t, domain.DefaultFairnessWindow, window)
+}
+
+func TestStatsService_GetFairness_InvalidFilter(t *testing.T) {
+	svc := service.NewStatsService(mocks.NewStatsRepository(t), mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	report, err := svc.GetFairness(context.Background(), domain.FairnessFilter{From: &from, To: &to})
+
+	require.ErrorIs(t, err, domain.ErrInvalidStatsFilter)
+	require.Nil(t, report)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// GitHostService applies pull request webhooks of Git hosting providers.
+type GitHostService struct {
+	txManager  repository.TxManager
+	prRepo     repository.PRRepository
+	userRepo   repository.UserRepository
+	prService  *PRService
+	reviewSync *ReviewerSyncService
+}
+
+func NewGitHostService(
+	txManager repository.TxManager,
+	prRepo repository.PRRepository,
+	userRepo repository.UserRepository,
+	prService *PRService,
+	reviewSync *ReviewerSyncService,
+) *GitHostService {
+	return &GitHostService{
+		txManager:  txManager,
+		prRepo:     prRepo,
+		userRepo:   userRepo,
+		prService:  prService,
+		reviewSync: reviewSync,
+	}
+}
+
+// HandlePullRequestEvent creates, merges, closes or reopens the pull request
+// the event is about. Providers redeliver webhooks, so every action is
+// idempotent. It returns nil when the event refers to a pull request the
+// service does not know and can not create, such as a close of a pull
+// request opened before the integration was set up.
+func (s *GitHostService) HandlePullRequestEvent(ctx context.Context, ev domain.HostPREvent) (*domain.PullRequest, error) {
+	log := logger.L()
+
+	prID := ev.Source.PRID()
+
+	log.Info("handling git host pull request event",
+		slog.String("provider", ev.Source.Provider),
+		slog.String("action", string(ev.Action)),
+		slog.String("prID", prID),
+	)
+
+	// Changes are attributed to whoever triggered the event when their
+	// account is linked to a user.
+	if sender, err := s.userRepo.GetByExternalLogin(ctx, ev.Source.Provider, ev.SenderLogin); err == nil {
+		ctx = actor.WithID(ctx, sender.ID)
+	}
+
+	var (
+		pr  *domain.PullRequest
+		err error
+	)
+	switch ev.Action {
+	case domain.HostPROpened:
+		pr, err = s.open(ctx, ev)
+
+	case domain.HostPRReopened:
+		pr, err = s.prService.ReopenPR(ctx, prID, 0)
+		if errors.Is(err, domain.ErrPRNotFound) {
+			pr, err = s.open(ctx, ev)
+		}
+
+	case domain.HostPRClosed:
+		pr, err = s.prService.ClosePR(ctx, prID, 0)
+
+	case domain.HostPRMerged:
+		pr, err = s.prService.MergePR(ctx, prID, 0)
+
+	default:
+		return nil, nil
+	}
+
+	if errors.Is(err, domain.ErrPRNotFound) {
+		log.Info("git host event refers to an unknown pull request, ignoring",
+			slog.String("prID", prID),
+		)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *GitHostService) open(ctx context.Context, ev domain.HostPREvent) (*domain.PullRequest, error) {
+	log := logger.L()
+
+	prID := ev.Source.PRID()
+
+	author, err := s.userRepo.GetByExternalLogin(ctx, ev.Source.Provider, ev.AuthorLogin)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Warn("pull request author is not linked to a user",
+				slog.String("provider", ev.Source.Provider),
+				slog.String("login", ev.AuthorLogin),
+			)
+			return nil, fmt.Errorf("%w: %s login %q is not linked", domain.ErrUserNotFound, ev.Source.Provider, ev.AuthorLogin)
+		}
+		log.Error("failed to resolve pull request author",
+			slog.String("login", ev.AuthorLogin),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	var pr *domain.PullRequest
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prService.CreatePR(ctx, prID, ev.Title, author.ID, "")
+		if err != nil {
+			return err
+		}
+
+		if err := s.prRepo.SetSource(ctx, pr.ID, ev.Source); err != nil {
+			log.Error("failed to save pull request source",
+				slog.String("prID", prID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		// The source is only known now, so the reviewers picked by
+		// CreatePR are mirrored here rather than there.
+		return s.reviewSync.Enqueue(ctx, pr.ID, pr.AssignedReviewers, nil)
+	})
+	if errors.Is(err, domain.ErrPRExists) {
+		log.Info("pull request already created, webhook redelivered", slog.String("prID", prID))
+		return s.prService.GetPR(ctx, prID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
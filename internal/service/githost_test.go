@@ -0,0 +1,200 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// gitHubEvent parses a recorded GitHub payload from the githost fixtures.
+func gitHubEvent(t *testing.T, name string) domain.HostPREvent {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("..", "githost", "testdata", name))
+	require.NoError(t, err)
+
+	ev, err := githost.ParseGitHub("pull_request", body)
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	return *ev
+}
+
+func newGitHostService(t *testing.T) (*service.GitHostService, *mocks.PRRepository, *mocks.UserRepository, *mocks.TeamRepository) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	return newGitHostServiceWithSync(t, prRepo, userRepo, teamRepo, nil), prRepo, userRepo, teamRepo
+}
+
+func newGitHostServiceWithSync(
+	t *testing.T,
+	prRepo *mocks.PRRepository,
+	userRepo *mocks.UserRepository,
+	teamRepo *mocks.TeamRepository,
+	reviewSync *service.ReviewerSyncService,
+) *service.GitHostService {
+	txManager := passthroughTx(t)
+	prSvc := service.NewPRService(txManager, prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, reviewSync, nil)
+
+	return service.NewGitHostService(txManager, prRepo, userRepo, prSvc, reviewSync)
+}
+
+func TestGitHostService_Opened(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	syncRepo := mocks.NewReviewerSyncRepository(t)
+
+	reviewSync := service.NewReviewerSyncService(syncRepo, prRepo, userRepo,
+		map[string]githost.Client{domain.ProviderGitHub: githost.NewFakeClient()}, testDeliveryPolicy)
+	svc := newGitHostServiceWithSync(t, prRepo, userRepo, teamRepo, reviewSync)
+
+	ev := gitHubEvent(t, "github_opened.json")
+	author := &domain.User{ID: "u1", TeamName: "backend"}
+
+	userRepo.
+		On("GetByExternalLogin", mock.Anything, domain.ProviderGitHub, "octocat").
+		Return(author, nil).
+		Twice()
+
+	prRepo.
+		On("Exists", mock.Anything, "github:acme/hello-world#1347").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(author, nil).
+		Once()
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	userRepo.
+		On("ListActiveByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u1"}, {ID: "u2"}}, nil).
+		Once()
+
+	prRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+		Return(nil).
+		Once()
+
+	prRepo.
+		On("SetSource", mock.Anything, "github:acme/hello-world#1347", ev.Source).
+		Return(nil).
+		Once()
+
+	prRepo.
+		On("GetSource", mock.Anything, "github:acme/hello-world#1347").
+		Return(&ev.Source, nil).
+		Once()
+
+	syncRepo.
+		On("Enqueue", mock.Anything, mock.MatchedBy(func(job *domain.ReviewerSyncJob) bool {
+			return job.PRID == "github:acme/hello-world#1347" && len(job.Add) == 1 && job.Add[0] == "u2"
+		})).
+		Return(nil).
+		Once()
+
+	pr, err := svc.HandlePullRequestEvent(context.Background(), ev)
+
+	require.NoError(t, err)
+	require.Equal(t, "github:acme/hello-world#1347", pr.ID)
+	require.Equal(t, "Add search endpoint", pr.Name)
+	require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestGitHostService_Opened_Redelivered(t *testing.T) {
+	svc, prRepo, userRepo, _ := newGitHostService(t)
+
+	ev := gitHubEvent(t, "github_opened.json")
+	existing := &domain.PullRequest{ID: "github:acme/hello-world#1347", Status: domain.PRStatusOpen}
+
+	userRepo.
+		On("GetByExternalLogin", mock.Anything, domain.ProviderGitHub, "octocat").
+		Return(&domain.User{ID: "u1", TeamName: "backend"}, nil).
+		Twice()
+
+	prRepo.
+		On("Exists", mock.Anything, existing.ID).
+		Return(true, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, existing.ID).
+		Return(existing, nil).
+		Once()
+
+	pr, err := svc.HandlePullRequestEvent(context.Background(), ev)
+
+	require.NoError(t, err)
+	require.Equal(t, existing, pr)
+}
+
+func TestGitHostService_Opened_UnlinkedAuthor(t *testing.T) {
+	svc, _, userRepo, _ := newGitHostService(t)
+
+	userRepo.
+		On("GetByExternalLogin", mock.Anything, domain.ProviderGitHub, "octocat").
+		Return(nil, domain.ErrUserNotFound).
+		Twice()
+
+	_, err := svc.HandlePullRequestEvent(context.Background(), gitHubEvent(t, "github_opened.json"))
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestGitHostService_Merged(t *testing.T) {
+	svc, prRepo, userRepo, _ := newGitHostService(t)
+
+	userRepo.
+		On("GetByExternalLogin", mock.Anything, domain.ProviderGitHub, "hubot").
+		Return(&domain.User{ID: "u9"}, nil).
+		Once()
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "github:acme/hello-world#1347").
+		Return(&domain.PullRequest{ID: "github:acme/hello-world#1347", Status: domain.PRStatusOpen, Version: 2}, nil).
+		Once()
+
+	prRepo.
+		On("UpdateStatusAndMergedAt", mock.Anything, "github:acme/hello-world#1347", domain.PRStatusMerged, mock.Anything).
+		Return(nil).
+		Once()
+
+	pr, err := svc.HandlePullRequestEvent(context.Background(), gitHubEvent(t, "github_merged.json"))
+
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusMerged, pr.Status)
+}
+
+func TestGitHostService_Closed_UnknownPR(t *testing.T) {
+	svc, prRepo, userRepo, _ := newGitHostService(t)
+
+	userRepo.
+		On("GetByExternalLogin", mock.Anything, domain.ProviderGitHub, "octocat").
+		Return(nil, domain.ErrUserNotFound).
+		Once()
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "github:acme/hello-world#1347").
+		Return(nil, domain.ErrPRNotFound).
+		Once()
+
+	pr, err := svc.HandlePullRequestEvent(context.Background(), gitHubEvent(t, "github_closed.json"))
+
+	require.NoError(t, err)
+	require.Nil(t, pr)
+}
@@ -0,0 +1,36 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// passthroughTx returns a TxManager mock that simply runs the function.
+func passthroughTx(t *testing.T) *mocks.TxManager {
+	txManager := mocks.NewTxManager(t)
+
+	txManager.
+		On("WithinTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return txManager
+}
+
+// acceptEvents returns a PREventRepository mock that accepts any timeline
+// events.
+func acceptEvents(t *testing.T) *mocks.PREventRepository {
+	eventRepo := mocks.NewPREventRepository(t)
+
+	eventRepo.
+		On("Append", mock.Anything, mock.Anything).
+		Return(nil).
+		Maybe()
+
+	return eventRepo
+}
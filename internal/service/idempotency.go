@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type IdempotencyService struct {
+	repo  repository.IdempotencyRepository
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewIdempotencyService keeps responses for ttl. A request in flight holds
+// its key for lease at a time and renews it while it runs.
+func NewIdempotencyService(repo repository.IdempotencyRepository, ttl time.Duration, lease time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		repo:  repo,
+		ttl:   ttl,
+		lease: lease,
+	}
+}
+
+// Begin claims key for the request. It returns nil when the request should
+// be executed, or the stored record when its response has to be replayed.
+// The key of a request that died before finishing is taken over once its
+// lease runs out.
+func (s *IdempotencyService) Begin(
+	ctx context.Context,
+	key string,
+	method string,
+	path string,
+	requestHash string,
+) (*domain.IdempotencyRecord, error) {
+	log := logger.L()
+
+	if key == "" || len(key) > domain.MaxIdempotencyKeyLength {
+		log.Warn("invalid idempotency key", slog.Int("length", len(key)))
+		return nil, domain.ErrInvalidIdempotencyKey
+	}
+
+	now := time.Now().UTC()
+	rec := &domain.IdempotencyRecord{
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+		LockedUntil: now.Add(s.lease),
+	}
+
+	// The second attempt covers a record that expired and was purged
+	// between the failed reservation and the lookup.
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := s.repo.Reserve(ctx, rec)
+		if err != nil {
+			log.Error("failed to reserve idempotency key",
+				slog.String("path", path),
+				slog.Any("err", err),
+			)
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		stored, err := s.repo.Get(ctx, key, method, path)
+		if err != nil {
+			log.Error("failed to get idempotency record",
+				slog.String("path", path),
+				slog.Any("err", err),
+			)
+			return nil, err
+		}
+		if stored == nil {
+			continue
+		}
+
+		if stored.RequestHash != requestHash {
+			log.Warn("idempotency key reused with a different request",
+				slog.String("path", path),
+			)
+			return nil, domain.ErrIdempotencyKeyReused
+		}
+
+		if !stored.Completed() {
+			log.Warn("request with the same idempotency key is in progress",
+				slog.String("path", path),
+			)
+			return nil, domain.ErrIdempotencyInProgress
+		}
+
+		log.Info("replaying stored response",
+			slog.String("path", path),
+			slog.Int("status", stored.StatusCode),
+		)
+		return stored, nil
+	}
+
+	return nil, domain.ErrIdempotencyInProgress
+}
+
+// KeepAlive renews the lease on key every third of the lease until the
+// returned function is called, which the request must do before Finish or
+// Release.
+func (s *IdempotencyService) KeepAlive(ctx context.Context, key, method, path string) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.lease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				renewCtx, cancelRenew := context.WithTimeout(ctx, s.lease/3)
+				err := s.repo.Renew(renewCtx, key, method, path, time.Now().UTC().Add(s.lease))
+				cancelRenew()
+				if err != nil {
+					logger.L().Warn("failed to renew idempotency key lease",
+						slog.String("path", path),
+						slog.Any("err", err),
+					)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Finish stores the response for replays. Server errors are not stored so
+// that a retry gets another chance to succeed.
+func (s *IdempotencyService) Finish(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	log := logger.L()
+
+	if rec.StatusCode >= http.StatusInternalServerError {
+		return s.Release(ctx, rec.Key, rec.Method, rec.Path)
+	}
+
+	if err := s.repo.Complete(ctx, rec); err != nil {
+		log.Error("failed to store idempotent response",
+			slog.String("path", rec.Path),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// Release forgets key so that the request can be executed again.
+func (s *IdempotencyService) Release(ctx context.Context, key, method, path string) error {
+	if err := s.repo.Delete(ctx, key, method, path); err != nil {
+		logger.L().Error("failed to release idempotency key",
+			slog.String("path", path),
+			slog.Any("err", err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	log := logger.L()
+
+	n, err := s.repo.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to purge expired idempotency keys", slog.Any("err", err))
+		return 0, err
+	}
+
+	if n > 0 {
+		log.Info("expired idempotency keys purged", slog.Int64("count", n))
+	}
+
+	return n, nil
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Setup("test")
+}
+
+func TestIdempotencyService_Begin_Reserved(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	svc := service.NewIdempotencyService(repo, time.Hour, time.Minute)
+
+	repo.
+		On("Reserve", mock.Anything, mock.MatchedBy(func(rec *domain.IdempotencyRecord) bool {
+			return rec.Key == "k1" && rec.RequestHash == "h1" &&
+				rec.ExpiresAt.Sub(rec.CreatedAt) == time.Hour &&
+				rec.LockedUntil.Sub(rec.CreatedAt) == time.Minute
+		})).
+		Return(true, nil).
+		Once()
+
+	stored, err := svc.Begin(context.Background(), "k1", http.MethodPost, "/pullRequest/create", "h1")
+
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
+
+func TestIdempotencyService_Begin_Replay(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	svc := service.NewIdempotencyService(repo, time.Hour, time.Minute)
+
+	existing := &domain.IdempotencyRecord{
+		Key:         "k1",
+		RequestHash: "h1",
+		StatusCode:  http.StatusCreated,
+		Body:        []byte(`{"pr":{}}`),
+	}
+
+	repo.
+		On("Reserve", mock.Anything, mock.AnythingOfType("*domain.IdempotencyRecord")).
+		Return(false, nil).
+		Once()
+
+	repo.
+		On("Get", mock.Anything, "k1", http.MethodPost, "/pullRequest/create").
+		Return(existing, nil).
+		Once()
+
+	stored, err := svc.Begin(context.Background(), "k1", http.MethodPost, "/pullRequest/create", "h1")
+
+	require.NoError(t, err)
+	require.Equal(t, existing, stored)
+}
+
+func TestIdempotencyService_Begin_KeyReused(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	svc := service.NewIdempotencyService(repo, time.Hour, time.Minute)
+
+	repo.
+		On("Reserve", mock.Anything, mock.AnythingOfType("*domain.IdempotencyRecord")).
+		Return(false, nil).
+		Once()
+
+	repo.
+		On("Get", mock.Anything, "k1", http.MethodPost, "/pullRequest/create").
+		Return(&domain.IdempotencyRecord{RequestHash: "other", StatusCode: http.StatusCreated}, nil).
+		Once()
+
+	stored, err := svc.Begin(context.Background(), "k1", http.MethodPost, "/pullRequest/create", "h1")
+
+	require.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+	require.Nil(t, stored)
+}
+
+func TestIdempotencyService_Begin_InProgress(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	svc := service.NewIdempotencyService(repo, time.Hour, time.Minute)
+
+	repo.
+		On("Reserve", mock.Anything, mock.AnythingOfType("*domain.IdempotencyRecord")).
+		Return(false, nil).
+		Once()
+
+	repo.
+		On("Get", mock.Anything, "k1", http.MethodPost, "/pullRequest/reassign").
+		Return(&domain.IdempotencyRecord{RequestHash: "h1"}, nil).
+		Once()
+
+	stored, err := svc.Begin(context.Background(), "k1", http.MethodPost, "/pullRequest/reassign", "h1")
+
+	require.ErrorIs(t, err, domain.ErrIdempotencyInProgress)
+	require.Nil(t, stored)
+}
+
+func TestIdempotencyService_KeepAlive(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	svc := service.NewIdempotencyService(repo, time.Hour, 30*time.Millisecond)
+
+	renewed := make(chan time.Time, 10)
+	repo.
+		On("Renew", mock.Anything, "k1", http.MethodPost, "/pullRequest/reassign", mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			renewed <- args.Get(4).(time.Time)
+		}).
+		Return(nil)
+
+	stop := svc.KeepAlive(context.Background(), "k1", http.MethodPost, "/pullRequest/reassign")
+
+	select {
+	case lockedUntil := <-renewed:
+		require.WithinDuration(t, time.Now().Add(30*time.Millisecond), lockedUntil, 20*time.Millisecond)
+	case <-time.After(time.Second):
+		t.Fatal("lease was not renewed")
+	}
+
+	stop()
+	n := len(renewed)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, n, len(renewed), "lease renewed after stop")
+}
+
+func TestIdempotencyService_Begin_InvalidKey(t *testing.T) {
+	svc := service.NewIdempotencyService(nil, time.Hour, time.Minute)
+
+	key := strings.Repeat("k", domain.MaxIdempotencyKeyLength+1)
+	stored, err := svc.Begin(context.Background(), key, http.MethodPost, "/team/add", "h1")
+
+	require.ErrorIs(t, err, domain.ErrInvalidIdempotencyKey)
+	require.Nil(t, stored)
+}
+
+func TestIdempotencyService_Finish_ServerErrorReleasesKey(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	svc := service.NewIdempotencyService(repo, time.Hour, time.Minute)
+
+	repo.
+		On("Delete", mock.Anything, "k1", http.MethodPost, "/pullRequest/merge").
+		Return(nil).
+		Once()
+
+	err := svc.Finish(context.Background(), &domain.IdempotencyRecord{
+		Key:        "k1",
+		Method:     http.MethodPost,
+		Path:       "/pullRequest/merge",
+		StatusCode: http.StatusInternalServerError,
+	})
+
+	require.NoError(t, err)
+}
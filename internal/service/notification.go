@@ -0,0 +1,618 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// NotificationSchedule configures when notifications go out.
+type NotificationSchedule struct {
+	// DigestAt is the time of day, in minutes after midnight in the user's
+	// timezone, at which digests are sent.
+	DigestAt int
+	// RemindAfter is how long a reviewer may stay silent on a pull request
+	// before being reminded of it.
+	RemindAfter time.Duration
+}
+
+// NotificationService tells reviewers about their reviews by email. Like
+// webhook deliveries, notifications are queued in the transaction that
+// causes them and sent by a background dispatcher that honours the
+// preferences of every user.
+type NotificationService struct {
+	repo     repository.NotificationRepository
+	userRepo repository.UserRepository
+	prRepo   repository.PRRepository
+	email    notify.Sender
+	policy   DeliveryPolicy
+	schedule NotificationSchedule
+}
+
+// NewNotificationService takes the sender of emails; with a nil sender no
+// notifications are queued.
+func NewNotificationService(
+	repo repository.NotificationRepository,
+	userRepo repository.UserRepository,
+	prRepo repository.PRRepository,
+	email notify.Sender,
+	policy DeliveryPolicy,
+	schedule NotificationSchedule,
+) *NotificationService {
+	return &NotificationService{
+		repo:     repo,
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		email:    email,
+		policy:   policy,
+		schedule: schedule,
+	}
+}
+
+// GetPreferences returns the notification preferences of a user, or the
+// defaults when the user never changed them.
+func (s *NotificationService) GetPreferences(ctx context.Context, userID string) (*domain.NotificationPreferences, error) {
+	log := logger.L()
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("userID", userID))
+			return nil, err
+		}
+		log.Error("failed to get user",
+			slog.String("userID", userID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return s.preferences(ctx, userID)
+}
+
+// UpdatePreferences replaces the notification preferences of a user.
+func (s *NotificationService) UpdatePreferences(
+	ctx context.Context,
+	prefs *domain.NotificationPreferences,
+) (*domain.NotificationPreferences, error) {
+	log := logger.L()
+
+	log.Info("updating notification preferences", slog.String("userID", prefs.UserID))
+
+	if err := prefs.Validate(); err != nil {
+		log.Warn("invalid notification preferences",
+			slog.String("userID", prefs.UserID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, prefs.UserID); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("userID", prefs.UserID))
+			return nil, err
+		}
+		log.Error("failed to get user",
+			slog.String("userID", prefs.UserID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	prefs.UpdatedAt = time.Now().UTC()
+	if err := s.repo.SavePreferences(ctx, prefs); err != nil {
+		log.Error("failed to save notification preferences",
+			slog.String("userID", prefs.UserID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	log.Info("notification preferences successfully updated", slog.String("userID", prefs.UserID))
+
+	return prefs, nil
+}
+
+// NotifyAssigned queues a notification for every reviewer assigned to a
+// pull request. It does nothing on a nil service or without a sender.
+func (s *NotificationService) NotifyAssigned(ctx context.Context, prID string, reviewerIDs []string) error {
+	if s == nil || s.email == nil || len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	list := make([]domain.Notification, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		list = append(list, domain.Notification{
+			UserID:        id,
+			Kind:          domain.NotificationReviewAssigned,
+			PRID:          prID,
+			Status:        domain.NotificationPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	return s.enqueue(ctx, list)
+}
+
+// NotifyReassigned queues a notification for a reviewer who replaced
+// previousReviewerID. It does nothing on a nil service or without a sender.
+func (s *NotificationService) NotifyReassigned(ctx context.Context, prID string, reviewerID string, previousReviewerID string) error {
+	if s == nil || s.email == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	return s.enqueue(ctx, []domain.Notification{{
+		UserID:             reviewerID,
+		Kind:               domain.NotificationReviewReassigned,
+		PRID:               prID,
+		PreviousReviewerID: previousReviewerID,
+		Status:             domain.NotificationPending,
+		NextAttemptAt:      now,
+		CreatedAt:          now,
+	}})
+}
+
+// NotifyOverdue queues a notification for a reviewer who missed the review
+// SLA. It does nothing on a nil service or without a sender.
+func (s *NotificationService) NotifyOverdue(ctx context.Context, prID string, reviewerID string) error {
+	if s == nil || s.email == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	return s.enqueue(ctx, []domain.Notification{{
+		UserID:        reviewerID,
+		Kind:          domain.NotificationReviewOverdue,
+		PRID:          prID,
+		Status:        domain.NotificationPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}})
+}
+
+// NotifyEscalated queues a notification for every lead about a review
+// reviewerID left unanswered. It does nothing on a nil service or without a
+// sender.
+func (s *NotificationService) NotifyEscalated(ctx context.Context, prID string, leadIDs []string, reviewerID string) error {
+	if s == nil || s.email == nil || len(leadIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	list := make([]domain.Notification, 0, len(leadIDs))
+	for _, id := range leadIDs {
+		list = append(list, domain.Notification{
+			UserID:             id,
+			Kind:               domain.NotificationReviewEscalated,
+			PRID:               prID,
+			PreviousReviewerID: reviewerID,
+			Status:             domain.NotificationPending,
+			NextAttemptAt:      now,
+			CreatedAt:          now,
+		})
+	}
+
+	return s.enqueue(ctx, list)
+}
+
+// staleRepeatAfter keeps notifications about a stale pull request to one a
+// day: they wait for the digest, and one is not queued again right after the
+// previous one was sent.
+const staleRepeatAfter = 12 * time.Hour
+
+// NotifyStale queues the daily notifications about stale pull requests: one
+// for every pending reviewer and one for every lead of the team, given by
+// team name in leads. Recipients who already have one pending are left out.
+// It returns the number of notifications queued and does nothing on a nil
+// service or without a sender.
+func (s *NotificationService) NotifyStale(ctx context.Context, prs []domain.StalePR, leads map[string][]string) (int, error) {
+	if s == nil || s.email == nil || len(prs) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	var list []domain.Notification
+	add := func(userID string, kind domain.NotificationKind, prID string) {
+		list = append(list, domain.Notification{
+			UserID:        userID,
+			Kind:          kind,
+			PRID:          prID,
+			Status:        domain.NotificationPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	for _, pr := range prs {
+		for _, id := range pr.PendingReviewers {
+			add(id, domain.NotificationStaleReview, pr.PRID)
+		}
+		for _, id := range leads[pr.TeamName] {
+			add(id, domain.NotificationStalePR, pr.PRID)
+		}
+	}
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	// Notifications of a user queued next to each other are likely to be
+	// claimed by the same dispatch and sent in one letter.
+	slices.SortStableFunc(list, func(a, b domain.Notification) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	n, err := s.repo.EnqueueMissing(ctx, list, now.Add(-staleRepeatAfter))
+	if err != nil {
+		logger.L().Error("failed to enqueue stale pull request notifications", slog.Any("err", err))
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *NotificationService) enqueue(ctx context.Context, list []domain.Notification) error {
+	log := logger.L()
+
+	if err := s.repo.Enqueue(ctx, list); err != nil {
+		log.Error("failed to enqueue notifications",
+			slog.String("prID", list[0].PRID),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	log.Info("notifications enqueued",
+		slog.String("prID", list[0].PRID),
+		slog.String("kind", string(list[0].Kind)),
+		slog.Int("count", len(list)),
+	)
+
+	return nil
+}
+
+// Remind queues reminders for reviews that have been waiting longer than
+// the configured delay. It returns the number of reminders queued and does
+// nothing on a nil service or without a sender.
+func (s *NotificationService) Remind(ctx context.Context) (int, error) {
+	if s == nil || s.email == nil {
+		return 0, nil
+	}
+
+	log := logger.L()
+
+	now := time.Now().UTC()
+	n, err := s.repo.EnqueueReminders(ctx, now.Add(-s.schedule.RemindAfter), now)
+	if err != nil {
+		log.Error("failed to enqueue review reminders", slog.Any("err", err))
+		return 0, err
+	}
+
+	if n > 0 {
+		log.Info("review reminders enqueued", slog.Int("count", n))
+	}
+
+	return n, nil
+}
+
+// Dispatch sends the notifications that are due. Notifications of a user
+// that are ready together go out as one digest letter. It returns the
+// number of notifications handled and does nothing on a nil service or
+// without a sender.
+func (s *NotificationService) Dispatch(ctx context.Context) (int, error) {
+	if s == nil || s.email == nil {
+		return 0, nil
+	}
+
+	log := logger.L()
+
+	now := time.Now().UTC()
+	due, err := s.repo.ClaimDue(ctx, now, now.Add(s.policy.Lease), s.policy.BatchSize)
+	if err != nil {
+		log.Error("failed to claim notifications", slog.Any("err", err))
+		return 0, err
+	}
+
+	var (
+		users  []string
+		byUser = map[string][]*domain.Notification{}
+	)
+	for i := range due {
+		n := &due[i]
+		if _, ok := byUser[n.UserID]; !ok {
+			users = append(users, n.UserID)
+		}
+		byUser[n.UserID] = append(byUser[n.UserID], n)
+	}
+
+	d := &dispatch{NotificationService: s, now: now, prs: map[string]*domain.PullRequest{}, names: map[string]string{}}
+	for _, userID := range users {
+		d.sendTo(ctx, userID, byUser[userID])
+	}
+
+	return len(due), nil
+}
+
+// RunDispatcher calls Dispatch every interval until ctx is done.
+func (s *NotificationService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.Dispatch(ctx)
+		}
+	}
+}
+
+func (s *NotificationService) preferences(ctx context.Context, userID string) (*domain.NotificationPreferences, error) {
+	prefs, err := s.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		logger.L().Error("failed to get notification preferences",
+			slog.String("userID", userID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	if prefs == nil {
+		prefs = domain.DefaultNotificationPreferences(userID)
+	}
+	return prefs, nil
+}
+
+// dispatch caches what a single Dispatch run looks up, since a batch often
+// holds several notifications about the same pull request.
+type dispatch struct {
+	*NotificationService
+
+	now   time.Time
+	prs   map[string]*domain.PullRequest
+	names map[string]string
+}
+
+// sendTo sends the notifications of one user that are ready in a single
+// letter and postpones the others.
+func (d *dispatch) sendTo(ctx context.Context, userID string, list []*domain.Notification) {
+	log := logger.L()
+
+	user, err := d.userRepo.GetByID(ctx, userID)
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		d.skip(ctx, list, "user not found")
+		return
+	case err != nil:
+		d.fail(ctx, list, err)
+		return
+	case user.ErasedAt != nil:
+		d.skip(ctx, list, "user was erased")
+		return
+	}
+
+	prefs, err := d.preferences(ctx, userID)
+	if err != nil {
+		d.fail(ctx, list, err)
+		return
+	}
+	if !prefs.HasChannel(domain.ChannelEmail) {
+		d.skip(ctx, list, "email notifications are disabled")
+		return
+	}
+	if user.Email == "" {
+		d.skip(ctx, list, "user has no email address")
+		return
+	}
+
+	digestPrefs := *prefs
+	digestPrefs.Mode = domain.NotificationDigest
+
+	var (
+		ready []*domain.Notification
+		items []notify.Item
+	)
+	for _, n := range list {
+		// Retries keep their backoff instead of being rescheduled.
+		if n.Attempts == 0 {
+			p := prefs
+			if n.Kind.DigestOnly() {
+				p = &digestPrefs
+			}
+			if at := p.DeliverAt(n.CreatedAt, d.schedule.DigestAt); at.After(d.now) {
+				n.NextAttemptAt = at.UTC()
+				d.save(ctx, n)
+				continue
+			}
+		}
+
+		item, reason, err := d.item(ctx, n)
+		if err != nil {
+			d.fail(ctx, []*domain.Notification{n}, err)
+			continue
+		}
+		if reason != "" {
+			d.skip(ctx, []*domain.Notification{n}, reason)
+			continue
+		}
+
+		ready = append(ready, n)
+		items = append(items, *item)
+	}
+	if len(ready) == 0 {
+		return
+	}
+
+	subject, body, err := notify.Render(prefs.Language, notify.Letter{
+		RecipientName: displayName(user),
+		Items:         items,
+	})
+	if err == nil {
+		err = d.email.Send(ctx, user.Email, subject, body)
+	}
+	if err != nil {
+		d.fail(ctx, ready, err)
+		return
+	}
+
+	for _, n := range ready {
+		n.Status = domain.NotificationSent
+		n.Attempts++
+		n.SentAt = &d.now
+		n.LastError = ""
+		d.save(ctx, n)
+	}
+
+	log.Info("notification letter sent",
+		slog.String("userID", userID),
+		slog.Int("notifications", len(ready)),
+	)
+}
+
+// item describes a notification for its letter. A non-empty reason means
+// the notification is no longer relevant and must not be sent.
+func (d *dispatch) item(ctx context.Context, n *domain.Notification) (*notify.Item, string, error) {
+	pr, ok := d.prs[n.PRID]
+	if !ok {
+		var err error
+		pr, err = d.prRepo.GetByID(ctx, n.PRID)
+		if err != nil && !errors.Is(err, domain.ErrPRNotFound) {
+			return nil, "", err
+		}
+		d.prs[n.PRID] = pr
+	}
+
+	if pr == nil || pr.Status != domain.PRStatusOpen {
+		return nil, "pull request is no longer open", nil
+	}
+
+	// Escalations go to team leads about someone else's review, stale pull
+	// requests to team leads about no review in particular.
+	reviewerID := n.UserID
+	switch n.Kind {
+	case domain.NotificationReviewEscalated:
+		reviewerID = n.PreviousReviewerID
+	case domain.NotificationStalePR:
+		reviewerID = ""
+	}
+	if reviewerID != "" {
+		if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+			return nil, "user is no longer a reviewer", nil
+		}
+		if _, reviewed := pr.Verdicts[reviewerID]; reviewed && n.Kind != domain.NotificationReviewAssigned &&
+			n.Kind != domain.NotificationReviewReassigned {
+			return nil, "review was already submitted", nil
+		}
+	}
+
+	author, err := d.name(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	item := &notify.Item{
+		Kind:       n.Kind,
+		PRID:       pr.ID,
+		PRName:     pr.Name,
+		AuthorName: author,
+	}
+	if n.Kind.DigestOnly() && pr.CreatedAt != nil {
+		item.OpenDays = int(d.now.Sub(*pr.CreatedAt) / (24 * time.Hour))
+	}
+	switch {
+	case n.Kind == domain.NotificationReviewEscalated:
+		if item.ReviewerName, err = d.name(ctx, n.PreviousReviewerID); err != nil {
+			return nil, "", err
+		}
+	case n.PreviousReviewerID != "":
+		if item.PreviousReviewerName, err = d.name(ctx, n.PreviousReviewerID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return item, "", nil
+}
+
+// name returns how a user is called in letters, falling back to the id of
+// users that no longer exist.
+func (d *dispatch) name(ctx context.Context, userID string) (string, error) {
+	if name, ok := d.names[userID]; ok {
+		return name, nil
+	}
+
+	name := userID
+	u, err := d.userRepo.GetByID(ctx, userID)
+	switch {
+	case err == nil:
+		name = displayName(u)
+	case !errors.Is(err, domain.ErrUserNotFound):
+		return "", err
+	}
+
+	d.names[userID] = name
+	return name, nil
+}
+
+func (d *dispatch) skip(ctx context.Context, list []*domain.Notification, reason string) {
+	for _, n := range list {
+		n.Status = domain.NotificationSkipped
+		n.LastError = reason
+		d.save(ctx, n)
+	}
+
+	logger.L().Info("notifications skipped",
+		slog.String("userID", list[0].UserID),
+		slog.Int("count", len(list)),
+		slog.String("reason", reason),
+	)
+}
+
+// fail records a failed attempt. Notifications are retried with backoff
+// until they run out of attempts.
+func (d *dispatch) fail(ctx context.Context, list []*domain.Notification, err error) {
+	msg := err.Error()
+	if len(msg) > maxDeliveryErrorLength {
+		msg = msg[:maxDeliveryErrorLength]
+	}
+
+	for _, n := range list {
+		n.Attempts++
+		n.LastError = msg
+		if n.Attempts >= d.policy.MaxAttempts {
+			n.Status = domain.NotificationFailed
+		} else {
+			n.NextAttemptAt = d.now.Add(d.policy.backoff(n.Attempts))
+		}
+		d.save(ctx, n)
+	}
+
+	logger.L().Warn("notification delivery failed",
+		slog.String("userID", list[0].UserID),
+		slog.Int("count", len(list)),
+		slog.Any("err", err),
+	)
+}
+
+func (d *dispatch) save(ctx context.Context, n *domain.Notification) {
+	if err := d.repo.SaveAttempt(ctx, n); err != nil {
+		logger.L().Error("failed to save notification attempt",
+			slog.Int64("notificationID", n.ID),
+			slog.Any("err", err),
+		)
+	}
+}
+
+func displayName(u *domain.User) string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Username
+}
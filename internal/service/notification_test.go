@@ -0,0 +1,864 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testNotificationSchedule = service.NotificationSchedule{DigestAt: 9 * 60, RemindAfter: 24 * time.Hour}
+
+type sentEmail struct {
+	to      string
+	subject string
+	body    string
+}
+
+// recordingSender keeps the emails it is asked to send.
+type recordingSender struct {
+	mu   sync.Mutex
+	sent []sentEmail
+	err  error
+}
+
+func (s *recordingSender) Send(_ context.Context, to string, subject string, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, sentEmail{to, subject, body})
+	return nil
+}
+
+func pendingNotification(id int64, kind domain.NotificationKind, prID string) domain.Notification {
+	return domain.Notification{
+		ID:        id,
+		UserID:    "u2",
+		Kind:      kind,
+		PRID:      prID,
+		Status:    domain.NotificationPending,
+		CreatedAt: time.Now().UTC().Add(-time.Minute),
+	}
+}
+
+func TestNotificationService_NotifyAssigned(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+
+	svc := service.NewNotificationService(repo, nil, nil, &recordingSender{}, testDeliveryPolicy, testNotificationSchedule)
+
+	repo.
+		On("Enqueue", mock.Anything, mock.MatchedBy(func(list []domain.Notification) bool {
+			return len(list) == 2 &&
+				list[0].UserID == "u2" && list[1].UserID == "u3" &&
+				list[0].Kind == domain.NotificationReviewAssigned &&
+				list[0].PRID == "pr1" &&
+				list[0].Status == domain.NotificationPending
+		})).
+		Return(nil).
+		Once()
+
+	require.NoError(t, svc.NotifyAssigned(context.Background(), "pr1", []string{"u2", "u3"}))
+}
+
+func TestNotificationService_NotifyWithoutSender(t *testing.T) {
+	svc := service.NewNotificationService(mocks.NewNotificationRepository(t), nil, nil, nil, testDeliveryPolicy, service.NotificationSchedule{})
+	require.NoError(t, svc.NotifyAssigned(context.Background(), "pr1", []string{"u2"}))
+	require.NoError(t, svc.NotifyReassigned(context.Background(), "pr1", "u2", "u3"))
+
+	var nilSvc *service.NotificationService
+	require.NoError(t, nilSvc.NotifyAssigned(context.Background(), "pr1", []string{"u2"}))
+}
+
+func TestNotificationService_RemindAndDispatchWithoutSender(t *testing.T) {
+	// Neither queues nor claims anything: the repository mock expects no
+	// calls, and a claimed notification would be sent through a nil sender.
+	svc := service.NewNotificationService(mocks.NewNotificationRepository(t), nil, nil, nil, testDeliveryPolicy, testNotificationSchedule)
+
+	n, err := svc.Remind(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	n, err = svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestNotificationService_Dispatch_Single(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, prRepo, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	n := pendingNotification(1, domain.NotificationReviewReassigned, "pr1")
+	n.PreviousReviewerID = "u3"
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{n}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr1").
+		Return(&domain.PullRequest{
+			ID:                "pr1",
+			Name:              "Add search",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", Username: "alice", DisplayName: "Alice"}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u3").
+		Return(&domain.User{ID: "u3", Username: "carol"}, nil).
+		Once()
+
+	var saved []domain.Notification
+	repo.
+		On("SaveAttempt", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, *args.Get(1).(*domain.Notification))
+		}).
+		Return(nil).
+		Once()
+
+	count, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	require.Len(t, sender.sent, 1)
+	require.Equal(t, "bob@example.com", sender.sent[0].to)
+	require.Equal(t, "Review handed over to you: Add search", sender.sent[0].subject)
+	require.Contains(t, sender.sent[0].body, "You replaced carol as a reviewer")
+	require.Contains(t, sender.sent[0].body, "by Alice")
+
+	require.Len(t, saved, 1)
+	require.Equal(t, domain.NotificationSent, saved[0].Status)
+	require.Equal(t, 1, saved[0].Attempts)
+	require.NotNil(t, saved[0].SentAt)
+}
+
+func TestNotificationService_Dispatch_GroupsIntoDigest(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, prRepo, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	prefs := domain.DefaultNotificationPreferences("u2")
+	prefs.Language = domain.LanguageRU
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{
+			pendingNotification(1, domain.NotificationReviewAssigned, "pr1"),
+			pendingNotification(2, domain.NotificationReviewReminder, "pr2"),
+		}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(prefs, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr1").
+		Return(&domain.PullRequest{
+			ID:                "pr1",
+			Name:              "Add search",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr2").
+		Return(&domain.PullRequest{
+			ID:                "pr2",
+			Name:              "Fix login",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2", "u3"},
+		}, nil).
+		Once()
+
+	// The author is looked up once for both pull requests.
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", Username: "alice"}, nil).
+		Once()
+
+	var saved []domain.Notification
+	repo.
+		On("SaveAttempt", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, *args.Get(1).(*domain.Notification))
+		}).
+		Return(nil).
+		Twice()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sender.sent, 1)
+	require.Equal(t, "Pull request'ы, ожидающие вашего ревью: 2", sender.sent[0].subject)
+	require.Contains(t, sender.sent[0].body, "Add search")
+	require.Contains(t, sender.sent[0].body, "Fix login")
+
+	require.Len(t, saved, 2)
+	for _, n := range saved {
+		require.Equal(t, domain.NotificationSent, n.Status)
+	}
+}
+
+func TestNotificationService_Dispatch_PostponesDuringQuietHours(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, nil, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	now := time.Now().UTC()
+	minute := now.Hour()*60 + now.Minute()
+	prefs := domain.DefaultNotificationPreferences("u2")
+	prefs.QuietHours = &domain.QuietHours{Start: (minute + 1440 - 60) % 1440, End: (minute + 60) % 1440}
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{pendingNotification(1, domain.NotificationReviewAssigned, "pr1")}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(prefs, nil).
+		Once()
+
+	var saved []domain.Notification
+	repo.
+		On("SaveAttempt", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, *args.Get(1).(*domain.Notification))
+		}).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	require.Empty(t, sender.sent)
+	require.Len(t, saved, 1)
+	require.Equal(t, domain.NotificationPending, saved[0].Status)
+	require.Zero(t, saved[0].Attempts)
+	require.True(t, saved[0].NextAttemptAt.After(now.Add(58*time.Minute)))
+	require.True(t, saved[0].NextAttemptAt.Before(now.Add(61*time.Minute)))
+}
+
+func TestNotificationService_Dispatch_DigestWaitsForDigestTime(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, nil, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	prefs := domain.DefaultNotificationPreferences("u2")
+	prefs.Mode = domain.NotificationDigest
+	prefs.Timezone = "Europe/Moscow"
+	n := pendingNotification(1, domain.NotificationReviewAssigned, "pr1")
+	n.CreatedAt = time.Now().UTC()
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{n}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(prefs, nil).
+		Once()
+
+	var saved []domain.Notification
+	repo.
+		On("SaveAttempt", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, *args.Get(1).(*domain.Notification))
+		}).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, saved, 1)
+	at := saved[0].NextAttemptAt.In(time.FixedZone("MSK", 3*60*60))
+	require.Equal(t, 9, at.Hour())
+	require.Equal(t, 0, at.Minute())
+	require.Empty(t, sender.sent)
+}
+
+func TestNotificationService_Dispatch_SkipsWhenEmailDisabled(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, nil, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	prefs := domain.DefaultNotificationPreferences("u2")
+	prefs.Channels = []domain.NotificationChannel{}
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{pendingNotification(1, domain.NotificationReviewAssigned, "pr1")}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(prefs, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.Status == domain.NotificationSkipped && n.LastError == "email notifications are disabled"
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, sender.sent)
+}
+
+func TestNotificationService_Dispatch_SkipsFormerReviewer(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, prRepo, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{pendingNotification(1, domain.NotificationReviewAssigned, "pr1")}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u3"}}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.Status == domain.NotificationSkipped && n.LastError == "user is no longer a reviewer"
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, sender.sent)
+}
+
+func TestNotificationService_Dispatch_SkipsMergedPR(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, prRepo, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{pendingNotification(1, domain.NotificationReviewReminder, "pr1")}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2"}}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.Status == domain.NotificationSkipped
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, sender.sent)
+}
+
+func TestNotificationService_Dispatch_RetriesThenFails(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	sender := &recordingSender{err: errors.New("connection refused")}
+
+	svc := service.NewNotificationService(repo, userRepo, prRepo, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	first := pendingNotification(1, domain.NotificationReviewAssigned, "pr1")
+	last := pendingNotification(2, domain.NotificationReviewAssigned, "pr2")
+	last.Attempts = testDeliveryPolicy.MaxAttempts - 1
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{first, last}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}}, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr2").
+		Return(&domain.PullRequest{ID: "pr2", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", Username: "alice"}, nil).
+		Once()
+
+	var saved []domain.Notification
+	repo.
+		On("SaveAttempt", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, *args.Get(1).(*domain.Notification))
+		}).
+		Return(nil).
+		Twice()
+
+	before := time.Now().UTC()
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, saved, 2)
+
+	require.Equal(t, domain.NotificationPending, saved[0].Status)
+	require.Equal(t, 1, saved[0].Attempts)
+	require.Equal(t, "connection refused", saved[0].LastError)
+	require.False(t, saved[0].NextAttemptAt.Before(before.Add(testDeliveryPolicy.BackoffBase)))
+
+	require.Equal(t, domain.NotificationFailed, saved[1].Status)
+	require.Equal(t, testDeliveryPolicy.MaxAttempts, saved[1].Attempts)
+}
+
+func TestNotificationService_Remind(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+
+	svc := service.NewNotificationService(repo, nil, nil, &recordingSender{}, testDeliveryPolicy, testNotificationSchedule)
+
+	before := time.Now().UTC()
+	repo.
+		On("EnqueueReminders", mock.Anything, mock.MatchedBy(func(notifiedBefore time.Time) bool {
+			return !notifiedBefore.Before(before.Add(-24*time.Hour)) && notifiedBefore.Before(before.Add(-23*time.Hour))
+		}), mock.Anything).
+		Return(3, nil).
+		Once()
+
+	n, err := svc.Remind(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+}
+
+func TestNotificationService_GetPreferences_Defaults(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+
+	svc := service.NewNotificationService(repo, userRepo, nil, &recordingSender{}, testDeliveryPolicy, testNotificationSchedule)
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	prefs, err := svc.GetPreferences(context.Background(), "u2")
+	require.NoError(t, err)
+	require.Equal(t, domain.DefaultNotificationPreferences("u2"), prefs)
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+
+	svc := service.NewNotificationService(repo, userRepo, nil, &recordingSender{}, testDeliveryPolicy, testNotificationSchedule)
+
+	prefs := &domain.NotificationPreferences{
+		UserID:     "u2",
+		Channels:   []domain.NotificationChannel{domain.ChannelEmail},
+		Language:   domain.LanguageRU,
+		Mode:       domain.NotificationDigest,
+		QuietHours: &domain.QuietHours{Start: 22 * 60, End: 8 * 60},
+		Timezone:   "Europe/Moscow",
+	}
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2"}, nil).
+		Once()
+
+	repo.
+		On("SavePreferences", mock.Anything, prefs).
+		Return(nil).
+		Once()
+
+	got, err := svc.UpdatePreferences(context.Background(), prefs)
+	require.NoError(t, err)
+	require.False(t, got.UpdatedAt.IsZero())
+}
+
+func TestNotificationService_UpdatePreferences_Invalid(t *testing.T) {
+	svc := service.NewNotificationService(nil, nil, nil, &recordingSender{}, testDeliveryPolicy, testNotificationSchedule)
+
+	for name, change := range map[string]func(p *domain.NotificationPreferences){
+		"channel":     func(p *domain.NotificationPreferences) { p.Channels = []domain.NotificationChannel{"sms"} },
+		"language":    func(p *domain.NotificationPreferences) { p.Language = "de" },
+		"mode":        func(p *domain.NotificationPreferences) { p.Mode = "weekly" },
+		"timezone":    func(p *domain.NotificationPreferences) { p.Timezone = "Mars/Olympus" },
+		"quiet hours": func(p *domain.NotificationPreferences) { p.QuietHours = &domain.QuietHours{Start: 60, End: 60} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			prefs := domain.DefaultNotificationPreferences("u2")
+			change(prefs)
+
+			_, err := svc.UpdatePreferences(context.Background(), prefs)
+			require.ErrorIs(t, err, domain.ErrInvalidPreferences)
+		})
+	}
+}
+
+func TestPRService_CreatePR_NotifiesReviewers(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	notificationRepo := mocks.NewNotificationRepository(t)
+
+	notificationSvc := service.NewNotificationService(notificationRepo, userRepo, prRepo, &recordingSender{}, testDeliveryPolicy, testNotificationSchedule)
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, notificationSvc)
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", TeamName: "backend"}, nil).
+		Once()
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	userRepo.
+		On("ListActiveByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u1"}, {ID: "u2"}}, nil).
+		Once()
+
+	prRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+		Return(nil).
+		Once()
+
+	notificationRepo.
+		On("Enqueue", mock.Anything, mock.MatchedBy(func(list []domain.Notification) bool {
+			return len(list) == 1 && list[0].UserID == "u2" && list[0].Kind == domain.NotificationReviewAssigned
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.CreatePR(context.Background(), "pr1", "Fix bug", "u1", "")
+	require.NoError(t, err)
+}
+
+func TestNotificationService_Dispatch_Escalation(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, prRepo, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	// u2 leads the team; u3 is the reviewer who missed the SLA.
+	n := pendingNotification(1, domain.NotificationReviewEscalated, "pr1")
+	n.PreviousReviewerID = "u3"
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{n}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr1").
+		Return(&domain.PullRequest{
+			ID:                "pr1",
+			Name:              "Add search",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u3"},
+		}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", Username: "alice"}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u3").
+		Return(&domain.User{ID: "u3", Username: "carol"}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.Status == domain.NotificationSent
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sender.sent, 1)
+	require.Equal(t, "Review escalated: Add search", sender.sent[0].subject)
+	require.Contains(t, sender.sent[0].body, "carol has not responded")
+}
+
+func TestNotificationService_Dispatch_StaleWaitsForDigest(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, nil, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	// Stale pull requests wait for the digest even for users who get
+	// everything else immediately.
+	n := pendingNotification(1, domain.NotificationStaleReview, "pr1")
+	n.CreatedAt = time.Now().UTC()
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{n}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	var saved []domain.Notification
+	repo.
+		On("SaveAttempt", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, *args.Get(1).(*domain.Notification))
+		}).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, saved, 1)
+	require.Equal(t, domain.NotificationPending, saved[0].Status)
+	require.Equal(t, 9, saved[0].NextAttemptAt.Hour())
+	require.Equal(t, 0, saved[0].NextAttemptAt.Minute())
+	require.Empty(t, sender.sent)
+}
+
+func TestNotificationService_Dispatch_StalePRToLead(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	sender := &recordingSender{}
+
+	svc := service.NewNotificationService(repo, userRepo, prRepo, sender, testDeliveryPolicy, testNotificationSchedule)
+
+	// u2 leads the team and does not review the pull request.
+	n := pendingNotification(1, domain.NotificationStalePR, "pr1")
+	n.CreatedAt = time.Now().UTC().Add(-48 * time.Hour)
+	createdAt := time.Now().UTC().AddDate(0, 0, -10).Add(-time.Hour)
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.Notification{n}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", Username: "bob", Email: "bob@example.com"}, nil).
+		Once()
+
+	repo.
+		On("GetPreferences", mock.Anything, "u2").
+		Return(nil, nil).
+		Once()
+
+	prRepo.
+		On("GetByID", mock.Anything, "pr1").
+		Return(&domain.PullRequest{
+			ID:                "pr1",
+			Name:              "Add search",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u3"},
+			CreatedAt:         &createdAt,
+		}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", Username: "alice"}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.Status == domain.NotificationSent
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, sender.sent, 1)
+	require.Equal(t, "Stale pull request in your team: Add search", sender.sent[0].subject)
+	require.Contains(t, sender.sent[0].body, "has been open for 10 days without progress")
+}
+
+func TestNotificationService_NotifyStale(t *testing.T) {
+	repo := mocks.NewNotificationRepository(t)
+
+	svc := service.NewNotificationService(repo, nil, nil, &recordingSender{}, testDeliveryPolicy, testNotificationSchedule)
+
+	prs := []domain.StalePR{
+		{PRID: "pr1", TeamName: "backend", PendingReviewers: []string{"u3", "u2"}},
+		{PRID: "pr2", TeamName: "frontend", PendingReviewers: []string{"u3"}},
+	}
+	leads := map[string][]string{"backend": {"u1"}}
+
+	repo.
+		On("EnqueueMissing", mock.Anything, mock.MatchedBy(func(list []domain.Notification) bool {
+			type sent struct {
+				user string
+				kind domain.NotificationKind
+				pr   string
+			}
+			got := make([]sent, 0, len(list))
+			for _, n := range list {
+				got = append(got, sent{n.UserID, n.Kind, n.PRID})
+			}
+			return assert.ObjectsAreEqual([]sent{
+				{"u1", domain.NotificationStalePR, "pr1"},
+				{"u2", domain.NotificationStaleReview, "pr1"},
+				{"u3", domain.NotificationStaleReview, "pr1"},
+				{"u3", domain.NotificationStaleReview, "pr2"},
+			}, got)
+		}), mock.Anything).
+		Return(3, nil).
+		Once()
+
+	n, err := svc.NotifyStale(context.Background(), prs, leads)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+}
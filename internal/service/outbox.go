@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+type OutboxService struct {
+	outboxRepo repository.OutboxRepository
+}
+
+func NewOutboxService(outboxRepo repository.OutboxRepository) *OutboxService {
+	return &OutboxService{outboxRepo: outboxRepo}
+}
+
+// Publish writes an event to the outbox. Like AuditService.Record, it must be
+// called with the context of the transaction that makes the change: the
+// event is delivered only if the change is committed. A nil OutboxService
+// publishes nothing.
+func (s *OutboxService) Publish(ctx context.Context, eventType domain.EventType, payload any) error {
+	if s == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	event := &domain.OutboxEvent{
+		Type:      eventType,
+		Payload:   raw,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.outboxRepo.Append(ctx, event); err != nil {
+		logger.L().Error("failed to append outbox event",
+			slog.String("eventType", string(eventType)),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// RedactUser puts pseudonym in place of the username in the events already
+// published about userID and drops their pending deliveries. Like Publish, it
+// must be called within the transaction erasing the user.
+func (s *OutboxService) RedactUser(ctx context.Context, userID string, pseudonym string) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := s.outboxRepo.RedactUser(ctx, userID, pseudonym); err != nil {
+		logger.L().Error("failed to redact outbox events",
+			slog.String("userID", userID),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// Webhook payloads are a public contract, so like the audit snapshots they
+// do not follow the domain structs. User payloads leave out contact details.
+
+type prEventPayload struct {
+	PullRequest        *prSnapshot `json:"pull_request"`
+	ReviewerID         string      `json:"reviewer_id,omitempty"`
+	ReplacedReviewerID string      `json:"replaced_reviewer_id,omitempty"`
+	Verdict            string      `json:"verdict,omitempty"`
+}
+
+type userEventPayload struct {
+	ID       string   `json:"id"`
+	Username string   `json:"username"`
+	TeamName string   `json:"team_name"`
+	Teams    []string `json:"teams"`
+	IsActive bool     `json:"is_active"`
+}
+
+func userPayload(u *domain.User) userEventPayload {
+	return userEventPayload{
+		ID:       u.ID,
+		Username: u.Username,
+		TeamName: u.TeamName,
+		Teams:    u.Teams,
+		IsActive: u.IsActive,
+	}
+}
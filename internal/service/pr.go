@@ -8,26 +8,48 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/metrics"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
 )
 
 type PRService struct {
-	prRepo   repository.PRRepository
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
+	txManager  repository.TxManager
+	prRepo     repository.PRRepository
+	userRepo   repository.UserRepository
+	teamRepo   repository.TeamRepository
+	eventRepo  repository.PREventRepository
+	audit      *AuditService
+	outbox     *OutboxService
+	reviewSync *ReviewerSyncService
+
+	notifications *NotificationService
 }
 
 func NewPRService(
+	txManager repository.TxManager,
 	prRepo repository.PRRepository,
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
+	eventRepo repository.PREventRepository,
+	audit *AuditService,
+	outbox *OutboxService,
+	reviewSync *ReviewerSyncService,
+	notifications *NotificationService,
 ) *PRService {
 	return &PRService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		txManager:  txManager,
+		prRepo:     prRepo,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		eventRepo:  eventRepo,
+		audit:      audit,
+		outbox:     outbox,
+		reviewSync: reviewSync,
+
+		notifications: notifications,
 	}
 }
 
@@ -36,6 +58,7 @@ func (s *PRService) CreatePR(
 	prID string,
 	prName string,
 	authorID string,
+	teamName string,
 ) (*domain.PullRequest, error) {
 	log := logger.L()
 
@@ -43,6 +66,7 @@ func (s *PRService) CreatePR(
 		slog.String("prID", prID),
 		slog.String("name", prName),
 		slog.String("authorID", authorID),
+		slog.String("teamName", teamName),
 	)
 
 	if prID == "" || prName == "" || authorID == "" {
@@ -84,42 +108,58 @@ func (s *PRService) CreatePR(
 		return nil, err
 	}
 
-	team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+	resolvedTeam, err := resolvePRTeam(author, teamName)
+	if err != nil {
+		log.Warn("can not resolve team for pull request",
+			slog.String("authorID", authorID),
+			slog.String("teamName", teamName),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	teamName = resolvedTeam
+
+	team, err := s.teamRepo.GetByName(ctx, teamName)
 	if err != nil {
 		if errors.Is(err, domain.ErrTeamNotFound) {
 			log.Warn("team not found for author",
-				slog.String("teamName", author.TeamName),
+				slog.String("teamName", teamName),
 				slog.String("authorID", authorID),
 			)
 			return nil, err
 		}
 		log.Error("failed to fetch team",
-			slog.String("teamName", author.TeamName),
+			slog.String("teamName", teamName),
 			slog.Any("err", err),
 		)
 		return nil, err
 	}
 	if team == nil {
 		log.Warn("team lookup returned nil",
-			slog.String("teamName", author.TeamName),
+			slog.String("teamName", teamName),
 		)
 		return nil, domain.ErrTeamNotFound
 	}
 
-	candidates, err := s.userRepo.ListActiveByTeam(ctx, author.TeamName)
+	candidates, err := s.userRepo.ListActiveByTeam(ctx, teamName)
 	if err != nil {
 		log.Error("failed to list active reviewers",
-			slog.String("teamName", author.TeamName),
+			slog.String("teamName", teamName),
 			slog.Any("err", err),
 		)
 		return nil, err
 	}
 
+	policy := team.ReviewerPolicy
+
 	filtered := make([]domain.User, 0, len(candidates))
 	for _, u := range candidates {
 		if u.ID == author.ID {
 			continue
 		}
+		if policy.ExcludeLeads && team.IsLead(u.ID) {
+			continue
+		}
 		filtered = append(filtered, u)
 	}
 
@@ -128,7 +168,7 @@ func (s *PRService) CreatePR(
 	})
 
 	var reviewers []string
-	for i := 0; i < len(filtered) && i < 2; i++ {
+	for i := 0; i < len(filtered) && i < policy.ReviewersCount; i++ {
 		reviewers = append(reviewers, filtered[i].ID)
 	}
 
@@ -137,19 +177,59 @@ func (s *PRService) CreatePR(
 		ID:                prID,
 		Name:              prName,
 		AuthorID:          authorID,
+		TeamName:          teamName,
 		Status:            domain.PRStatusOpen,
 		AssignedReviewers: reviewers,
 		CreatedAt:         &now,
 		MergedAt:          nil,
+		Version:           1,
 	}
 
-	if err := s.prRepo.Create(ctx, pr); err != nil {
-		log.Error("failed to create pull request",
-			slog.String("prID", prID),
-			slog.String("name", prName),
-			slog.String("authorID", authorID),
-			slog.Any("err", err),
-		)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.prRepo.Create(ctx, pr); err != nil {
+			if errors.Is(err, domain.ErrPRExists) {
+				log.Warn("pull request already exists",
+					slog.String("prID", prID),
+				)
+				return err
+			}
+			log.Error("failed to create pull request",
+				slog.String("prID", prID),
+				slog.String("name", prName),
+				slog.String("authorID", authorID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		events := []domain.PREvent{{PRID: pr.ID, Type: domain.PREventCreated, OccurredAt: now}}
+		reason := fmt.Sprintf("picked %d of %d eligible members of team %s",
+			len(reviewers), len(filtered), teamName)
+		for _, id := range reviewers {
+			events = append(events, domain.PREvent{
+				PRID:       pr.ID,
+				Type:       domain.PREventReviewerAssigned,
+				OccurredAt: now,
+				ReviewerID: id,
+				Strategy:   domain.AssignmentStrategyRandom,
+				Reason:     reason,
+			})
+		}
+		if err := s.recordEvents(ctx, events...); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, domain.AuditPRCreated, domain.AuditEntityPullRequest, pr.ID, nil, auditPR(pr)); err != nil {
+			return err
+		}
+
+		if err := s.outbox.Publish(ctx, domain.EventPRCreated, prEventPayload{PullRequest: auditPR(pr)}); err != nil {
+			return err
+		}
+
+		return s.notifications.NotifyAssigned(ctx, pr.ID, reviewers)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -162,7 +242,10 @@ func (s *PRService) CreatePR(
 	return pr, nil
 }
 
-func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+// MergePR merges the pull request. A non-zero expectedVersion makes the merge
+// conditional: on mismatch the current pull request is returned together with
+// domain.ErrPRVersionMismatch.
+func (s *PRService) MergePR(ctx context.Context, prID string, expectedVersion int64) (*domain.PullRequest, error) {
 	log := logger.L()
 
 	log.Info("merging pull request",
@@ -174,45 +257,105 @@ func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullReque
 		return nil, fmt.Errorf("empty prID")
 	}
 
-	pr, err := s.prRepo.GetByID(ctx, prID)
-	if err != nil {
-		if errors.Is(err, domain.ErrPRNotFound) {
-			log.Warn("pull request not found", slog.String("prID", prID))
-			return nil, err
+	// The row lock keeps a concurrent reassignment from touching the
+	// reviewers of a pull request that is being merged.
+	var merged *domain.PullRequest
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prRepo.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			if errors.Is(err, domain.ErrPRNotFound) {
+				log.Warn("pull request not found", slog.String("prID", prID))
+				return err
+			}
+			log.Error("failed to get pull request",
+				slog.String("prID", prID),
+				slog.Any("err", err),
+			)
+			return err
 		}
-		log.Error("failed to get pull request",
-			slog.String("prID", prID),
-			slog.Any("err", err),
-		)
-		return nil, err
-	}
 
-	if pr.Status == domain.PRStatusMerged {
-		log.Info("pull request already merged", slog.String("prID", prID))
-		return pr, nil
-	}
+		if !pr.MatchesVersion(expectedVersion) {
+			log.Warn("pull request version mismatch",
+				slog.String("prID", prID),
+				slog.Int64("expected", expectedVersion),
+				slog.Int64("actual", pr.Version),
+			)
+			merged = pr
+			return domain.ErrPRVersionMismatch
+		}
 
-	now := time.Now().UTC()
-	if err := s.prRepo.UpdateStatusAndMergedAt(ctx, pr.ID, domain.PRStatusMerged, &now); err != nil {
-		log.Error("failed to update pull request status",
-			slog.String("prID", pr.ID),
-			slog.Any("err", err),
-		)
+		if pr.Status == domain.PRStatusMerged {
+			log.Info("pull request already merged", slog.String("prID", prID))
+			merged = pr
+			return nil
+		}
+
+		if pr.Status == domain.PRStatusClosed {
+			log.Warn("attempt to merge closed pull request", slog.String("prID", prID))
+			return domain.ErrPRClosed
+		}
+
+		before := auditPR(pr)
+
+		now := time.Now().UTC()
+		if err := s.prRepo.UpdateStatusAndMergedAt(ctx, pr.ID, domain.PRStatusMerged, &now); err != nil {
+			log.Error("failed to update pull request status",
+				slog.String("prID", pr.ID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		pr.Status = domain.PRStatusMerged
+		pr.MergedAt = &now
+		pr.Version++
+		merged = pr
+
+		if err := s.recordEvents(ctx, domain.PREvent{
+			PRID:       pr.ID,
+			Type:       domain.PREventMerged,
+			OccurredAt: now,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, domain.AuditPRMerged, domain.AuditEntityPullRequest, pr.ID, before, auditPR(pr)); err != nil {
+			return err
+		}
+
+		return s.outbox.Publish(ctx, domain.EventPRMerged, prEventPayload{PullRequest: auditPR(pr)})
+	})
+	if errors.Is(err, domain.ErrPRVersionMismatch) {
+		return merged, err
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	log.Info("pull request successfully merged", slog.String("prID", prID))
 
-	pr.Status = domain.PRStatusMerged
-	pr.MergedAt = &now
-
-	return pr, nil
+	return merged, nil
 }
 
+// ReassignReviewer replaces oldReviewerID with a random eligible teammate.
+// expectedVersion works as in MergePR.
 func (s *PRService) ReassignReviewer(
 	ctx context.Context,
 	prID string,
 	oldReviewerID string,
+	expectedVersion int64,
+) (*domain.PullRequest, string, error) {
+	return s.reassignReviewer(ctx, prID, oldReviewerID, expectedVersion, "reassignment requested")
+}
+
+// reassignReviewer is ReassignReviewer with the reason stored in the pull
+// request timeline.
+func (s *PRService) reassignReviewer(
+	ctx context.Context,
+	prID string,
+	oldReviewerID string,
+	expectedVersion int64,
+	reason string,
 ) (*domain.PullRequest, string, error) {
 	log := logger.L()
 
@@ -229,110 +372,471 @@ func (s *PRService) ReassignReviewer(
 		return nil, "", fmt.Errorf("invalid input: empty fields")
 	}
 
-	pr, err := s.prRepo.GetByID(ctx, prID)
-	if err != nil {
-		if errors.Is(err, domain.ErrPRNotFound) {
-			log.Warn("pull request not found", slog.String("prID", prID))
-			return nil, "", err
+	// The pull request row stays locked until the reviewers are written,
+	// so parallel reassignments and merges see each other's results.
+	var (
+		pr          *domain.PullRequest
+		newReviewer domain.User
+	)
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			if errors.Is(err, domain.ErrPRNotFound) {
+				log.Warn("pull request not found", slog.String("prID", prID))
+				return err
+			}
+			log.Error("failed to get pull request",
+				slog.String("prID", prID),
+				slog.Any("err", err),
+			)
+			return err
 		}
-		log.Error("failed to get pull request",
-			slog.String("prID", prID),
-			slog.Any("err", err),
-		)
+
+		if !pr.MatchesVersion(expectedVersion) {
+			log.Warn("pull request version mismatch",
+				slog.String("prID", prID),
+				slog.Int64("expected", expectedVersion),
+				slog.Int64("actual", pr.Version),
+			)
+			return domain.ErrPRVersionMismatch
+		}
+
+		if pr.Status == domain.PRStatusMerged {
+			log.Warn("attempt to reassign reviewer for merged pull request",
+				slog.String("prID", prID),
+			)
+			return domain.ErrPRAlreadyMerged
+		}
+
+		if pr.Status == domain.PRStatusClosed {
+			log.Warn("attempt to reassign reviewer for closed pull request",
+				slog.String("prID", prID),
+			)
+			return domain.ErrPRClosed
+		}
+
+		index := -1
+		for i, id := range pr.AssignedReviewers {
+			if id == oldReviewerID {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			log.Warn("old reviewer is not assigned to the pull request",
+				slog.String("prID", prID),
+				slog.String("oldReviewerID", oldReviewerID),
+			)
+			return domain.ErrNotAssigned
+		}
+
+		oldReviewer, err := s.userRepo.GetByID(ctx, oldReviewerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				log.Warn("old reviewer not found", slog.String("oldReviewerID", oldReviewerID))
+				return err
+			}
+			log.Error("failed to get old reviewer",
+				slog.String("oldReviewerID", oldReviewerID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		// Replacements come from the team the pull request belongs to. Pull
+		// requests created before team membership existed fall back to the
+		// old reviewer's primary team.
+		teamName := pr.TeamName
+		if teamName == "" {
+			teamName = oldReviewer.TeamName
+		}
+
+		team, err := s.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			if errors.Is(err, domain.ErrTeamNotFound) {
+				log.Warn("team not found for pull request",
+					slog.String("prID", prID),
+					slog.String("teamName", teamName),
+				)
+				return err
+			}
+			log.Error("failed to fetch team",
+				slog.String("teamName", teamName),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		candidates, err := s.userRepo.ListActiveByTeam(ctx, teamName)
+		if err != nil {
+			log.Error("failed to list active candidates",
+				slog.String("teamName", teamName),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		reviewersSet := make(map[string]struct{}, len(pr.AssignedReviewers))
+		for _, id := range pr.AssignedReviewers {
+			reviewersSet[id] = struct{}{}
+		}
+
+		filtered := make([]domain.User, 0, len(candidates))
+		for _, u := range candidates {
+			if u.ID == oldReviewerID {
+				continue
+			}
+			if u.ID == pr.AuthorID {
+				continue
+			}
+			if _, exists := reviewersSet[u.ID]; exists {
+				continue
+			}
+			if team.ReviewerPolicy.ExcludeLeads && team.IsLead(u.ID) {
+				continue
+			}
+			filtered = append(filtered, u)
+		}
+
+		if len(filtered) == 0 {
+			metrics.NoCandidate.WithLabelValues(teamName).Inc()
+			return domain.ErrNoCandidate
+		}
+
+		newIdx := rand.Intn(len(filtered))
+		newReviewer = filtered[newIdx]
+
+		before := auditPR(pr)
+
+		if err := s.prRepo.ReplaceReviewer(ctx, pr.ID, oldReviewerID, newReviewer.ID); err != nil {
+			log.Error("failed to replace reviewer",
+				slog.String("prID", prID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		pr.AssignedReviewers[index] = newReviewer.ID
+		delete(pr.Verdicts, oldReviewerID)
+		pr.Version++
+
+		if err := s.recordEvents(ctx, domain.PREvent{
+			PRID:               pr.ID,
+			Type:               domain.PREventReviewerReassigned,
+			ReviewerID:         newReviewer.ID,
+			PreviousReviewerID: oldReviewerID,
+			Strategy:           domain.AssignmentStrategyRandom,
+			Reason:             fmt.Sprintf("%s; picked 1 of %d eligible members of team %s", reason, len(filtered), teamName),
+		}); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, domain.AuditPRReassigned, domain.AuditEntityPullRequest, pr.ID, before, auditPR(pr)); err != nil {
+			return err
+		}
+
+		if err := s.outbox.Publish(ctx, domain.EventPRReassigned, prEventPayload{
+			PullRequest:        auditPR(pr),
+			ReviewerID:         newReviewer.ID,
+			ReplacedReviewerID: oldReviewerID,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.reviewSync.Enqueue(ctx, pr.ID, []string{newReviewer.ID}, []string{oldReviewerID}); err != nil {
+			return err
+		}
+
+		return s.notifications.NotifyReassigned(ctx, pr.ID, newReviewer.ID, oldReviewerID)
+	})
+	if errors.Is(err, domain.ErrPRVersionMismatch) {
+		return pr, "", err
+	}
+	if err != nil {
 		return nil, "", err
 	}
 
-	if pr.Status == domain.PRStatusMerged {
-		log.Warn("attempt to reassign reviewer for merged pull request",
+	log.Info("reviewer successfully reassigned",
+		slog.String("prID", prID),
+		slog.String("oldReviewerID", oldReviewerID),
+		slog.String("newReviewerID", newReviewer.ID),
+	)
+
+	return pr, newReviewer.ID, nil
+}
+
+// SubmitReview records the verdict of an assigned reviewer. expectedVersion
+// works as in MergePR.
+func (s *PRService) SubmitReview(
+	ctx context.Context,
+	prID string,
+	reviewerID string,
+	verdict domain.ReviewVerdict,
+	expectedVersion int64,
+) (*domain.PullRequest, error) {
+	log := logger.L()
+
+	log.Info("submitting review",
+		slog.String("prID", prID),
+		slog.String("reviewerID", reviewerID),
+		slog.String("verdict", string(verdict)),
+	)
+
+	if prID == "" || reviewerID == "" {
+		log.Warn("invalid input: empty fields",
 			slog.String("prID", prID),
+			slog.String("reviewerID", reviewerID),
 		)
-		return nil, "", domain.ErrPRAlreadyMerged
+		return nil, fmt.Errorf("invalid input: empty fields")
 	}
 
-	index := -1
-	for i, id := range pr.AssignedReviewers {
-		if id == oldReviewerID {
-			index = i
-			break
+	if !domain.IsKnownVerdict(verdict) {
+		log.Warn("unknown review verdict", slog.String("verdict", string(verdict)))
+		return nil, domain.ErrInvalidVerdict
+	}
+
+	var pr *domain.PullRequest
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			if errors.Is(err, domain.ErrPRNotFound) {
+				log.Warn("pull request not found", slog.String("prID", prID))
+				return err
+			}
+			log.Error("failed to get pull request",
+				slog.String("prID", prID),
+				slog.Any("err", err),
+			)
+			return err
 		}
+
+		if !pr.MatchesVersion(expectedVersion) {
+			log.Warn("pull request version mismatch",
+				slog.String("prID", prID),
+				slog.Int64("expected", expectedVersion),
+				slog.Int64("actual", pr.Version),
+			)
+			return domain.ErrPRVersionMismatch
+		}
+
+		if pr.Status == domain.PRStatusMerged {
+			log.Warn("attempt to review merged pull request", slog.String("prID", prID))
+			return domain.ErrPRAlreadyMerged
+		}
+
+		if pr.Status == domain.PRStatusClosed {
+			log.Warn("attempt to review closed pull request", slog.String("prID", prID))
+			return domain.ErrPRClosed
+		}
+
+		before := auditPR(pr)
+
+		now := time.Now().UTC()
+		if err := s.prRepo.SetVerdict(ctx, pr.ID, reviewerID, verdict, now); err != nil {
+			if errors.Is(err, domain.ErrNotAssigned) {
+				log.Warn("reviewer is not assigned to the pull request",
+					slog.String("prID", prID),
+					slog.String("reviewerID", reviewerID),
+				)
+				return err
+			}
+			log.Error("failed to save review verdict",
+				slog.String("prID", prID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		if pr.Verdicts == nil {
+			pr.Verdicts = make(map[string]domain.ReviewVerdict)
+		}
+		pr.Verdicts[reviewerID] = verdict
+		pr.Version++
+
+		if err := s.recordEvents(ctx, domain.PREvent{
+			PRID:       pr.ID,
+			Type:       domain.PREventReviewSubmitted,
+			OccurredAt: now,
+			ReviewerID: reviewerID,
+			Verdict:    verdict,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, domain.AuditPRReviewed, domain.AuditEntityPullRequest, pr.ID, before, auditPR(pr)); err != nil {
+			return err
+		}
+
+		return s.outbox.Publish(ctx, domain.EventPRReviewed, prEventPayload{
+			PullRequest: auditPR(pr),
+			ReviewerID:  reviewerID,
+			Verdict:     string(verdict),
+		})
+	})
+	if errors.Is(err, domain.ErrPRVersionMismatch) {
+		return pr, err
 	}
-	if index == -1 {
-		log.Warn("old reviewer is not assigned to the pull request",
-			slog.String("prID", prID),
-			slog.String("oldReviewerID", oldReviewerID),
-		)
-		return nil, "", domain.ErrNotAssigned
+	if err != nil {
+		return nil, err
 	}
 
-	oldReviewer, err := s.userRepo.GetByID(ctx, oldReviewerID)
+	log.Info("review successfully submitted",
+		slog.String("prID", prID),
+		slog.String("reviewerID", reviewerID),
+	)
+
+	return pr, nil
+}
+
+func (s *PRService) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	log := logger.L()
+
+	if prID == "" {
+		log.Warn("empty prID provided")
+		return nil, fmt.Errorf("empty prID")
+	}
+
+	pr, err := s.prRepo.GetByID(ctx, prID)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			log.Warn("old reviewer not found", slog.String("oldReviewerID", oldReviewerID))
-			return nil, "", err
+		if errors.Is(err, domain.ErrPRNotFound) {
+			log.Warn("pull request not found", slog.String("prID", prID))
+			return nil, err
 		}
-		log.Error("failed to get old reviewer",
-			slog.String("oldReviewerID", oldReviewerID),
+		log.Error("failed to get pull request",
+			slog.String("prID", prID),
 			slog.Any("err", err),
 		)
-		return nil, "", err
+		return nil, err
 	}
 
-	candidates, err := s.userRepo.ListActiveByTeam(ctx, oldReviewer.TeamName)
-	if err != nil {
-		log.Error("failed to list active candidates",
-			slog.String("teamName", oldReviewer.TeamName),
-			slog.Any("err", err),
-		)
-		return nil, "", err
+	return pr, nil
+}
+
+// ClosePR closes an open pull request without merging it. Closing a closed
+// pull request is a no-op. expectedVersion works as in MergePR.
+func (s *PRService) ClosePR(ctx context.Context, prID string, expectedVersion int64) (*domain.PullRequest, error) {
+	return s.setClosed(ctx, prID, expectedVersion, true)
+}
+
+// ReopenPR opens a closed pull request again with the reviewers it had.
+// Reopening an open pull request is a no-op. expectedVersion works as in
+// MergePR.
+func (s *PRService) ReopenPR(ctx context.Context, prID string, expectedVersion int64) (*domain.PullRequest, error) {
+	return s.setClosed(ctx, prID, expectedVersion, false)
+}
+
+func (s *PRService) setClosed(
+	ctx context.Context,
+	prID string,
+	expectedVersion int64,
+	closed bool,
+) (*domain.PullRequest, error) {
+	log := logger.L()
+
+	to := domain.PRStatusClosed
+	action, eventType, event := domain.AuditPRClosed, domain.PREventClosed, domain.EventPRClosed
+	if !closed {
+		to = domain.PRStatusOpen
+		action, eventType, event = domain.AuditPRReopened, domain.PREventReopened, domain.EventPRReopened
 	}
 
-	reviewersSet := make(map[string]struct{}, len(pr.AssignedReviewers))
-	for _, id := range pr.AssignedReviewers {
-		reviewersSet[id] = struct{}{}
+	log.Info("changing pull request status",
+		slog.String("prID", prID),
+		slog.String("status", string(to)),
+	)
+
+	if prID == "" {
+		log.Warn("empty prID provided")
+		return nil, fmt.Errorf("empty prID")
 	}
 
-	filtered := make([]domain.User, 0, len(candidates))
-	for _, u := range candidates {
-		if u.ID == oldReviewerID {
-			continue
+	var pr *domain.PullRequest
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			if errors.Is(err, domain.ErrPRNotFound) {
+				log.Warn("pull request not found", slog.String("prID", prID))
+				return err
+			}
+			log.Error("failed to get pull request",
+				slog.String("prID", prID),
+				slog.Any("err", err),
+			)
+			return err
 		}
-		if u.ID == pr.AuthorID {
-			continue
+
+		if !pr.MatchesVersion(expectedVersion) {
+			log.Warn("pull request version mismatch",
+				slog.String("prID", prID),
+				slog.Int64("expected", expectedVersion),
+				slog.Int64("actual", pr.Version),
+			)
+			return domain.ErrPRVersionMismatch
 		}
-		if _, exists := reviewersSet[u.ID]; exists {
-			continue
+
+		if pr.Status == domain.PRStatusMerged {
+			log.Warn("attempt to change status of merged pull request", slog.String("prID", prID))
+			return domain.ErrPRAlreadyMerged
 		}
-		filtered = append(filtered, u)
-	}
 
-	if len(filtered) == 0 {
-		return nil, "", domain.ErrNoCandidate
-	}
+		if pr.Status == to {
+			log.Info("pull request already has the status",
+				slog.String("prID", prID),
+				slog.String("status", string(to)),
+			)
+			return nil
+		}
 
-	newIdx := rand.Intn(len(filtered))
-	newReviewer := filtered[newIdx]
+		before := auditPR(pr)
 
-	newReviewers := make([]string, len(pr.AssignedReviewers))
-	copy(newReviewers, pr.AssignedReviewers)
-	newReviewers[index] = newReviewer.ID
+		now := time.Now().UTC()
+		var closedAt *time.Time
+		if closed {
+			closedAt = &now
+		}
 
-	if err := s.prRepo.UpdateReviewers(ctx, pr.ID, newReviewers); err != nil {
-		log.Error("failed to update reviewers",
-			slog.String("prID", prID),
-			slog.Any("err", err),
-		)
-		return nil, "", err
+		if err := s.prRepo.UpdateStatusAndClosedAt(ctx, pr.ID, to, closedAt); err != nil {
+			log.Error("failed to update pull request status",
+				slog.String("prID", pr.ID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		pr.Status = to
+		pr.ClosedAt = closedAt
+		pr.Version++
+
+		if err := s.recordEvents(ctx, domain.PREvent{
+			PRID:       pr.ID,
+			Type:       eventType,
+			OccurredAt: now,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, action, domain.AuditEntityPullRequest, pr.ID, before, auditPR(pr)); err != nil {
+			return err
+		}
+
+		return s.outbox.Publish(ctx, event, prEventPayload{PullRequest: auditPR(pr)})
+	})
+	if errors.Is(err, domain.ErrPRVersionMismatch) {
+		return pr, err
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	log.Info("reviewer successfully reassigned",
+	log.Info("pull request status successfully changed",
 		slog.String("prID", prID),
-		slog.String("oldReviewerID", oldReviewerID),
-		slog.String("newReviewerID", newReviewer.ID),
+		slog.String("status", string(pr.Status)),
 	)
 
-	pr.AssignedReviewers = newReviewers
-
-	return pr, newReviewer.ID, nil
+	return pr, nil
 }
 
 func (s *PRService) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
@@ -363,3 +867,86 @@ func (s *PRService) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]
 
 	return prs, nil
 }
+
+// GetTimeline returns the stored history of the pull request, oldest first.
+func (s *PRService) GetTimeline(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	log := logger.L()
+
+	log.Info("getting pull request timeline", slog.String("prID", prID))
+
+	if prID == "" {
+		log.Warn("empty prID provided")
+		return nil, fmt.Errorf("empty prID")
+	}
+
+	exists, err := s.prRepo.Exists(ctx, prID)
+	if err != nil {
+		log.Error("failed to check PR existence",
+			slog.String("prID", prID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	if !exists {
+		log.Warn("pull request not found", slog.String("prID", prID))
+		return nil, domain.ErrPRNotFound
+	}
+
+	events, err := s.eventRepo.ListByPR(ctx, prID)
+	if err != nil {
+		log.Error("failed to list pull request events",
+			slog.String("prID", prID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// recordEvents appends events to the pull request timeline. It must be called
+// in the transaction that made the change the events describe.
+func (s *PRService) recordEvents(ctx context.Context, events ...domain.PREvent) error {
+	actorID, _ := actor.FromContext(ctx)
+	now := time.Now().UTC()
+
+	for i := range events {
+		if events[i].ActorID == "" {
+			events[i].ActorID = actorID
+		}
+		if events[i].OccurredAt.IsZero() {
+			events[i].OccurredAt = now
+		}
+	}
+
+	if err := s.eventRepo.Append(ctx, events); err != nil {
+		logger.L().Error("failed to append pull request events",
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// resolvePRTeam picks the team a new pull request belongs to. An explicitly
+// requested team must be one of the author's teams; otherwise the author's
+// primary team is used, or their only team when no primary team is set.
+func resolvePRTeam(author *domain.User, requested string) (string, error) {
+	if requested != "" {
+		if author.TeamName == requested || author.IsMemberOf(requested) {
+			return requested, nil
+		}
+		return "", domain.ErrNotTeamMember
+	}
+
+	if author.TeamName != "" {
+		return author.TeamName, nil
+	}
+
+	if len(author.Teams) == 1 {
+		return author.Teams[0], nil
+	}
+
+	return "", domain.ErrTeamRequired
+}
@@ -2,8 +2,11 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
@@ -21,7 +24,7 @@ func TestPRService_CreatePR_Success(t *testing.T) {
 	userRepo := mocks.NewUserRepository(t)
 	teamRepo := mocks.NewTeamRepository(t)
 
-	svc := service.NewPRService(prRepo, userRepo, teamRepo)
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, nil)
 
 	author := &domain.User{ID: "u1", TeamName: "backend"}
 
@@ -37,7 +40,7 @@ func TestPRService_CreatePR_Success(t *testing.T) {
 
 	teamRepo.
 		On("GetByName", mock.Anything, "backend").
-		Return(&domain.Team{Name: "backend"}, nil).
+		Return(domain.NewTeam("backend"), nil).
 		Once()
 
 	userRepo.
@@ -50,7 +53,7 @@ func TestPRService_CreatePR_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	pr, err := svc.CreatePR(context.Background(), "pr1", "Fix bug", "u1")
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Fix bug", "u1", "")
 
 	require.NoError(t, err)
 	require.Equal(t, "pr1", pr.ID)
@@ -59,10 +62,49 @@ func TestPRService_CreatePR_Success(t *testing.T) {
 	prRepo.AssertExpectations(t)
 }
 
+func TestPRService_CreatePR_LostInsertRace(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", TeamName: "backend"}, nil).
+		Once()
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	userRepo.
+		On("ListActiveByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u2"}}, nil).
+		Once()
+
+	// Another request inserted the same id between Exists and Create.
+	prRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+		Return(domain.ErrPRExists).
+		Once()
+
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Fix bug", "u1", "")
+
+	require.ErrorIs(t, err, domain.ErrPRExists)
+	require.Nil(t, pr)
+}
+
 func TestPRService_CreatePR_InvalidInput(t *testing.T) {
-	svc := service.NewPRService(nil, nil, nil)
+	svc := service.NewPRService(passthroughTx(t), nil, nil, nil, acceptEvents(t), nil, nil, nil, nil)
 
-	pr, err := svc.CreatePR(context.Background(), "", "name", "u1")
+	pr, err := svc.CreatePR(context.Background(), "", "name", "u1", "")
 
 	require.Error(t, err)
 	require.Nil(t, pr)
@@ -72,7 +114,7 @@ func TestPRService_CreatePR_AuthorNotFound(t *testing.T) {
 	prRepo := mocks.NewPRRepository(t)
 	userRepo := mocks.NewUserRepository(t)
 
-	svc := service.NewPRService(prRepo, userRepo, nil)
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, nil, acceptEvents(t), nil, nil, nil, nil)
 
 	prRepo.
 		On("Exists", mock.Anything, "pr1").
@@ -84,7 +126,7 @@ func TestPRService_CreatePR_AuthorNotFound(t *testing.T) {
 		Return(nil, domain.ErrUserNotFound).
 		Once()
 
-	pr, err := svc.CreatePR(context.Background(), "pr1", "Test", "u1")
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Test", "u1", "")
 
 	require.Error(t, err)
 	require.Equal(t, domain.ErrUserNotFound, err)
@@ -98,7 +140,7 @@ func TestPRService_CreatePR_TeamNotFound(t *testing.T) {
 	userRepo := mocks.NewUserRepository(t)
 	teamRepo := mocks.NewTeamRepository(t)
 
-	svc := service.NewPRService(prRepo, userRepo, teamRepo)
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, nil)
 
 	userRepo.
 		On("GetByID", mock.Anything, "u1").
@@ -115,7 +157,7 @@ func TestPRService_CreatePR_TeamNotFound(t *testing.T) {
 		Return(nil, domain.ErrTeamNotFound).
 		Once()
 
-	pr, err := svc.CreatePR(context.Background(), "pr1", "Test", "u1")
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Test", "u1", "")
 
 	require.Error(t, err)
 	require.Equal(t, domain.ErrTeamNotFound, err)
@@ -124,14 +166,144 @@ func TestPRService_CreatePR_TeamNotFound(t *testing.T) {
 	teamRepo.AssertExpectations(t)
 }
 
+func TestPRService_CreatePR_ExplicitTeam(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, nil)
+
+	author := &domain.User{ID: "u1", TeamName: "backend", Teams: []string{"backend", "devops"}}
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(author, nil).
+		Once()
+
+	teamRepo.
+		On("GetByName", mock.Anything, "devops").
+		Return(domain.NewTeam("devops"), nil).
+		Once()
+
+	userRepo.
+		On("ListActiveByTeam", mock.Anything, "devops").
+		Return([]domain.User{{ID: "u1"}, {ID: "u7"}}, nil).
+		Once()
+
+	prRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+		Return(nil).
+		Once()
+
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Fix pipeline", "u1", "devops")
+
+	require.NoError(t, err)
+	require.Equal(t, "devops", pr.TeamName)
+	require.Equal(t, []string{"u7"}, pr.AssignedReviewers)
+
+	prRepo.AssertExpectations(t)
+}
+
+func TestPRService_CreatePR_ReviewerPolicy(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, nil)
+
+	team := domain.NewTeam("backend")
+	team.Leads = []string{"u2"}
+	team.ReviewerPolicy = domain.ReviewerPolicy{ReviewersCount: 1, ExcludeLeads: true}
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", TeamName: "backend"}, nil).
+		Once()
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(team, nil).
+		Once()
+
+	userRepo.
+		On("ListActiveByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}, {ID: "u4"}}, nil).
+		Once()
+
+	prRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+		Return(nil).
+		Once()
+
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Fix bug", "u1", "")
+
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 1)
+	require.NotContains(t, pr.AssignedReviewers, "u2")
+}
+
+func TestPRService_CreatePR_NotTeamMember(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", TeamName: "backend", Teams: []string{"backend"}}, nil).
+		Once()
+
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Test", "u1", "qa")
+
+	require.ErrorIs(t, err, domain.ErrNotTeamMember)
+	require.Nil(t, pr)
+}
+
+func TestPRService_CreatePR_TeamRequired(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", Teams: []string{"backend", "devops"}}, nil).
+		Once()
+
+	pr, err := svc.CreatePR(context.Background(), "pr1", "Test", "u1", "")
+
+	require.ErrorIs(t, err, domain.ErrTeamRequired)
+	require.Nil(t, pr)
+}
+
 func TestPRService_MergePR_Success(t *testing.T) {
 	prRepo := mocks.NewPRRepository(t)
-	svc := service.NewPRService(prRepo, nil, nil)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
 
 	existing := &domain.PullRequest{ID: "pr1", Status: domain.PRStatusOpen}
 
 	prRepo.
-		On("GetByID", mock.Anything, "pr1").
+		On("GetByIDForUpdate", mock.Anything, "pr1").
 		Return(existing, nil).
 		Once()
 
@@ -140,7 +312,7 @@ func TestPRService_MergePR_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	pr, err := svc.MergePR(context.Background(), "pr1")
+	pr, err := svc.MergePR(context.Background(), "pr1", 0)
 
 	require.NoError(t, err)
 	require.Equal(t, domain.PRStatusMerged, pr.Status)
@@ -148,16 +320,53 @@ func TestPRService_MergePR_Success(t *testing.T) {
 	prRepo.AssertExpectations(t)
 }
 
+func TestPRService_MergePR_PublishesEvent(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	outboxRepo := mocks.NewOutboxRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, service.NewOutboxService(outboxRepo), nil, nil)
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusOpen}, nil).
+		Once()
+
+	prRepo.
+		On("UpdateStatusAndMergedAt", mock.Anything, "pr1", domain.PRStatusMerged, mock.AnythingOfType("*time.Time")).
+		Return(nil).
+		Once()
+
+	outboxRepo.
+		On("Append", mock.Anything, mock.MatchedBy(func(e *domain.OutboxEvent) bool {
+			var payload struct {
+				PullRequest struct {
+					ID     string `json:"id"`
+					Status string `json:"status"`
+				} `json:"pull_request"`
+			}
+			return e.Type == domain.EventPRMerged &&
+				json.Unmarshal(e.Payload, &payload) == nil &&
+				payload.PullRequest.ID == "pr1" &&
+				payload.PullRequest.Status == "MERGED"
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.MergePR(context.Background(), "pr1", 0)
+
+	require.NoError(t, err)
+}
+
 func TestPRService_MergePR_NotFound(t *testing.T) {
 	prRepo := mocks.NewPRRepository(t)
-	svc := service.NewPRService(prRepo, nil, nil)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
 
 	prRepo.
-		On("GetByID", mock.Anything, "pr1").
+		On("GetByIDForUpdate", mock.Anything, "pr1").
 		Return(nil, domain.ErrPRNotFound).
 		Once()
 
-	pr, err := svc.MergePR(context.Background(), "pr1")
+	pr, err := svc.MergePR(context.Background(), "pr1", 0)
 
 	require.Error(t, err)
 	require.Nil(t, pr)
@@ -168,16 +377,16 @@ func TestPRService_MergePR_NotFound(t *testing.T) {
 
 func TestPRService_MergePR_AlreadyMerged(t *testing.T) {
 	prRepo := mocks.NewPRRepository(t)
-	svc := service.NewPRService(prRepo, nil, nil)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
 
 	existing := &domain.PullRequest{ID: "pr1", Status: domain.PRStatusMerged}
 
 	prRepo.
-		On("GetByID", mock.Anything, "pr1").
+		On("GetByIDForUpdate", mock.Anything, "pr1").
 		Return(existing, nil).
 		Once()
 
-	pr, err := svc.MergePR(context.Background(), "pr1")
+	pr, err := svc.MergePR(context.Background(), "pr1", 0)
 
 	require.NoError(t, err)
 	require.Equal(t, domain.PRStatusMerged, pr.Status)
@@ -185,9 +394,101 @@ func TestPRService_MergePR_AlreadyMerged(t *testing.T) {
 	prRepo.AssertExpectations(t)
 }
 
+func TestPRService_MergePR_VersionMismatch(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	existing := &domain.PullRequest{ID: "pr1", Status: domain.PRStatusOpen, Version: 3}
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(existing, nil).
+		Once()
+
+	pr, err := svc.MergePR(context.Background(), "pr1", 2)
+
+	require.ErrorIs(t, err, domain.ErrPRVersionMismatch)
+	require.NotNil(t, pr)
+	require.Equal(t, int64(3), pr.Version)
+	require.Equal(t, domain.PRStatusOpen, pr.Status)
+
+	prRepo.AssertExpectations(t)
+}
+
+func TestPRService_MergePR_Closed(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusClosed}, nil).
+		Once()
+
+	_, err := svc.MergePR(context.Background(), "pr1", 0)
+
+	require.ErrorIs(t, err, domain.ErrPRClosed)
+}
+
+func TestPRService_ClosePR_Success(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusOpen, Version: 3}, nil).
+		Once()
+
+	prRepo.
+		On("UpdateStatusAndClosedAt", mock.Anything, "pr1", domain.PRStatusClosed, mock.AnythingOfType("*time.Time")).
+		Return(nil).
+		Once()
+
+	pr, err := svc.ClosePR(context.Background(), "pr1", 3)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusClosed, pr.Status)
+	require.NotNil(t, pr.ClosedAt)
+	require.Equal(t, int64(4), pr.Version)
+}
+
+func TestPRService_ClosePR_AlreadyMerged(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusMerged}, nil).
+		Once()
+
+	_, err := svc.ClosePR(context.Background(), "pr1", 0)
+
+	require.ErrorIs(t, err, domain.ErrPRAlreadyMerged)
+}
+
+func TestPRService_ReopenPR_Success(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusClosed}, nil).
+		Once()
+
+	prRepo.
+		On("UpdateStatusAndClosedAt", mock.Anything, "pr1", domain.PRStatusOpen, (*time.Time)(nil)).
+		Return(nil).
+		Once()
+
+	pr, err := svc.ReopenPR(context.Background(), "pr1", 0)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.PRStatusOpen, pr.Status)
+	require.Nil(t, pr.ClosedAt)
+}
+
 func TestPRService_ReassignReviewer_NotAssigned(t *testing.T) {
 	prRepo := mocks.NewPRRepository(t)
-	svc := service.NewPRService(prRepo, nil, nil)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
 
 	existing := &domain.PullRequest{
 		ID:                "pr1",
@@ -195,11 +496,11 @@ func TestPRService_ReassignReviewer_NotAssigned(t *testing.T) {
 	}
 
 	prRepo.
-		On("GetByID", mock.Anything, "pr1").
+		On("GetByIDForUpdate", mock.Anything, "pr1").
 		Return(existing, nil).
 		Once()
 
-	pr, newID, err := svc.ReassignReviewer(context.Background(), "pr1", "u10")
+	pr, newID, err := svc.ReassignReviewer(context.Background(), "pr1", "u10", 0)
 
 	require.Error(t, err)
 	require.Equal(t, domain.ErrNotAssigned, err)
@@ -212,8 +513,9 @@ func TestPRService_ReassignReviewer_NotAssigned(t *testing.T) {
 func TestPRService_ReassignReviewer_NoCandidate(t *testing.T) {
 	prRepo := mocks.NewPRRepository(t)
 	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
 
-	svc := service.NewPRService(prRepo, userRepo, nil)
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, acceptEvents(t), nil, nil, nil, nil)
 
 	existing := &domain.PullRequest{
 		ID:                "pr1",
@@ -222,7 +524,7 @@ func TestPRService_ReassignReviewer_NoCandidate(t *testing.T) {
 	}
 
 	prRepo.
-		On("GetByID", mock.Anything, "pr1").
+		On("GetByIDForUpdate", mock.Anything, "pr1").
 		Return(existing, nil).
 		Once()
 
@@ -231,13 +533,18 @@ func TestPRService_ReassignReviewer_NoCandidate(t *testing.T) {
 		Return(&domain.User{ID: "u2", TeamName: "backend"}, nil).
 		Once()
 
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
 	// no candidates
 	userRepo.
 		On("ListActiveByTeam", mock.Anything, "backend").
 		Return([]domain.User{}, nil).
 		Once()
 
-	pr, newID, err := svc.ReassignReviewer(context.Background(), "pr1", "u2")
+	pr, newID, err := svc.ReassignReviewer(context.Background(), "pr1", "u2", 0)
 
 	require.Error(t, err)
 	require.Equal(t, domain.ErrNoCandidate, err)
@@ -247,9 +554,68 @@ func TestPRService_ReassignReviewer_NoCandidate(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func TestPRService_SubmitReview_Success(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	existing := &domain.PullRequest{
+		ID:                "pr1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Version:           2,
+	}
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(existing, nil).
+		Once()
+
+	prRepo.
+		On("SetVerdict", mock.Anything, "pr1", "u2", domain.VerdictApproved, mock.AnythingOfType("time.Time")).
+		Return(nil).
+		Once()
+
+	pr, err := svc.SubmitReview(context.Background(), "pr1", "u2", domain.VerdictApproved, 2)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.VerdictApproved, pr.Verdicts["u2"])
+	require.Equal(t, int64(3), pr.Version)
+
+	prRepo.AssertExpectations(t)
+}
+
+func TestPRService_SubmitReview_NotAssigned(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusOpen}, nil).
+		Once()
+
+	prRepo.
+		On("SetVerdict", mock.Anything, "pr1", "u9", domain.VerdictChangesRequested, mock.AnythingOfType("time.Time")).
+		Return(domain.ErrNotAssigned).
+		Once()
+
+	pr, err := svc.SubmitReview(context.Background(), "pr1", "u9", domain.VerdictChangesRequested, 0)
+
+	require.ErrorIs(t, err, domain.ErrNotAssigned)
+	require.Nil(t, pr)
+}
+
+func TestPRService_SubmitReview_InvalidVerdict(t *testing.T) {
+	svc := service.NewPRService(passthroughTx(t), nil, nil, nil, acceptEvents(t), nil, nil, nil, nil)
+
+	pr, err := svc.SubmitReview(context.Background(), "pr1", "u2", "LGTM", 0)
+
+	require.ErrorIs(t, err, domain.ErrInvalidVerdict)
+	require.Nil(t, pr)
+}
+
 func TestPRService_GetPRsByReviewer_Success(t *testing.T) {
 	prRepo := mocks.NewPRRepository(t)
-	svc := service.NewPRService(prRepo, nil, nil)
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, acceptEvents(t), nil, nil, nil, nil)
 
 	prRepo.
 		On("ListByReviewer", mock.Anything, "u1").
@@ -265,10 +631,105 @@ func TestPRService_GetPRsByReviewer_Success(t *testing.T) {
 }
 
 func TestPRService_GetPRsByReviewer_InvalidInput(t *testing.T) {
-	svc := service.NewPRService(nil, nil, nil)
+	svc := service.NewPRService(passthroughTx(t), nil, nil, nil, acceptEvents(t), nil, nil, nil, nil)
 
 	prs, err := svc.GetPRsByReviewer(context.Background(), "")
 
 	require.Error(t, err)
 	require.Nil(t, prs)
 }
+
+func TestPRService_CreatePR_RecordsTimeline(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	eventRepo := mocks.NewPREventRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, userRepo, teamRepo, eventRepo, nil, nil, nil, nil)
+
+	ctx := actor.WithID(context.Background(), "admin")
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(false, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", TeamName: "backend"}, nil).
+		Once()
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	userRepo.
+		On("ListActiveByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u1"}, {ID: "u2"}}, nil).
+		Once()
+
+	prRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).
+		Return(nil).
+		Once()
+
+	eventRepo.
+		On("Append", mock.Anything, mock.MatchedBy(func(events []domain.PREvent) bool {
+			return len(events) == 2 &&
+				events[0].Type == domain.PREventCreated &&
+				events[0].ActorID == "admin" &&
+				events[1].Type == domain.PREventReviewerAssigned &&
+				events[1].ReviewerID == "u2" &&
+				events[1].Strategy == domain.AssignmentStrategyRandom &&
+				events[1].Reason != ""
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.CreatePR(ctx, "pr1", "Fix bug", "u1", "")
+
+	require.NoError(t, err)
+}
+
+func TestPRService_GetTimeline_Success(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	eventRepo := mocks.NewPREventRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, eventRepo, nil, nil, nil, nil)
+
+	prRepo.
+		On("Exists", mock.Anything, "pr1").
+		Return(true, nil).
+		Once()
+
+	eventRepo.
+		On("ListByPR", mock.Anything, "pr1").
+		Return([]domain.PREvent{
+			{ID: 1, PRID: "pr1", Type: domain.PREventCreated},
+			{ID: 2, PRID: "pr1", Type: domain.PREventMerged},
+		}, nil).
+		Once()
+
+	events, err := svc.GetTimeline(context.Background(), "pr1")
+
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, domain.PREventMerged, events[1].Type)
+}
+
+func TestPRService_GetTimeline_NotFound(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+
+	svc := service.NewPRService(passthroughTx(t), prRepo, nil, nil, mocks.NewPREventRepository(t), nil, nil, nil, nil)
+
+	prRepo.
+		On("Exists", mock.Anything, "pr9").
+		Return(false, nil).
+		Once()
+
+	events, err := svc.GetTimeline(context.Background(), "pr9")
+
+	require.ErrorIs(t, err, domain.ErrPRNotFound)
+	require.Nil(t, events)
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// ReviewSLAService enforces the review SLAs of teams. A review left
+// unanswered past the team's response time is flagged as overdue and its
+// reviewer notified; past the escalation threshold the team leads are
+// notified or the review is handed to another member of the team.
+type ReviewSLAService struct {
+	txManager     repository.TxManager
+	repo          repository.ReviewSLARepository
+	teamRepo      repository.TeamRepository
+	prService     *PRService
+	notifications *NotificationService
+}
+
+func NewReviewSLAService(
+	txManager repository.TxManager,
+	repo repository.ReviewSLARepository,
+	teamRepo repository.TeamRepository,
+	prService *PRService,
+	notifications *NotificationService,
+) *ReviewSLAService {
+	return &ReviewSLAService{
+		txManager:     txManager,
+		repo:          repo,
+		teamRepo:      teamRepo,
+		prService:     prService,
+		notifications: notifications,
+	}
+}
+
+// ListOverdue returns the open reviews flagged as overdue. An empty
+// teamName lists all teams.
+func (s *ReviewSLAService) ListOverdue(ctx context.Context, teamName string) ([]domain.PendingReview, error) {
+	log := logger.L()
+
+	if teamName != "" {
+		exists, err := s.teamRepo.ExistsByName(ctx, teamName)
+		if err != nil {
+			log.Error("failed to check team existence",
+				slog.String("teamName", teamName),
+				slog.Any("err", err),
+			)
+			return nil, err
+		}
+		if !exists {
+			log.Warn("team not found", slog.String("teamName", teamName))
+			return nil, domain.ErrTeamNotFound
+		}
+	}
+
+	reviews, err := s.repo.ListOverdue(ctx, teamName)
+	if err != nil {
+		log.Error("failed to list overdue reviews",
+			slog.String("teamName", teamName),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// Check flags and escalates the reviews that ran past their SLA. It
+// returns the number of reviews acted upon. Several replicas may check at
+// once: every review is flagged and escalated only once.
+func (s *ReviewSLAService) Check(ctx context.Context) (int, error) {
+	log := logger.L()
+
+	now := time.Now().UTC()
+	reviews, err := s.repo.ListAwaiting(ctx, now)
+	if err != nil {
+		log.Error("failed to list awaiting reviews", slog.Any("err", err))
+		return 0, err
+	}
+
+	acted := 0
+	for i := range reviews {
+		r := &reviews[i]
+
+		if r.BreachedAt == nil && !now.Before(r.DueAt()) {
+			ok, err := s.flag(ctx, r, now)
+			if err != nil {
+				log.Error("failed to flag overdue review",
+					slog.String("prID", r.PRID),
+					slog.String("reviewerID", r.ReviewerID),
+					slog.Any("err", err),
+				)
+				continue
+			}
+			if ok {
+				acted++
+			}
+		}
+
+		if r.SLA.EscalationHours > 0 && !now.Before(r.EscalateAt()) {
+			ok, err := s.escalate(ctx, r, now)
+			if err != nil {
+				log.Error("failed to escalate overdue review",
+					slog.String("prID", r.PRID),
+					slog.String("reviewerID", r.ReviewerID),
+					slog.Any("err", err),
+				)
+				continue
+			}
+			if ok {
+				acted++
+			}
+		}
+	}
+
+	return acted, nil
+}
+
+// flag marks a review as overdue and notifies the reviewer.
+func (s *ReviewSLAService) flag(ctx context.Context, r *domain.PendingReview, now time.Time) (bool, error) {
+	var flagged bool
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		flagged, err = s.repo.MarkBreached(ctx, r.PRID, r.ReviewerID, r.AssignedAt, now)
+		if err != nil || !flagged {
+			return err
+		}
+
+		if err := s.prService.recordEvents(ctx, domain.PREvent{
+			PRID:       r.PRID,
+			Type:       domain.PREventReviewOverdue,
+			OccurredAt: now,
+			ReviewerID: r.ReviewerID,
+			Reason:     fmt.Sprintf("no response within %d working hours", r.SLA.ResponseHours),
+		}); err != nil {
+			return err
+		}
+
+		return s.notifications.NotifyOverdue(ctx, r.PRID, r.ReviewerID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if flagged {
+		r.BreachedAt = &now
+		logger.L().Info("review is overdue",
+			slog.String("prID", r.PRID),
+			slog.String("reviewerID", r.ReviewerID),
+		)
+	}
+
+	return flagged, nil
+}
+
+// escalate applies the team's escalation. Reviews that can not be
+// reassigned for lack of candidates are escalated to the leads instead.
+func (s *ReviewSLAService) escalate(ctx context.Context, r *domain.PendingReview, now time.Time) (bool, error) {
+	log := logger.L()
+
+	if r.SLA.Escalation == domain.SLAReassign {
+		reason := fmt.Sprintf("review SLA escalation: no response within %d working hours", r.SLA.EscalationHours)
+		_, newReviewerID, err := s.prService.reassignReviewer(ctx, r.PRID, r.ReviewerID, 0, reason)
+		switch {
+		case err == nil:
+			log.Info("overdue review reassigned",
+				slog.String("prID", r.PRID),
+				slog.String("oldReviewerID", r.ReviewerID),
+				slog.String("newReviewerID", newReviewerID),
+			)
+			return true, nil
+		case errors.Is(err, domain.ErrNotAssigned),
+			errors.Is(err, domain.ErrPRAlreadyMerged),
+			errors.Is(err, domain.ErrPRClosed),
+			errors.Is(err, domain.ErrPRNotFound):
+			// The review was settled in the meantime.
+			return false, nil
+		case !errors.Is(err, domain.ErrNoCandidate):
+			return false, err
+		}
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, r.TeamName)
+	if err != nil {
+		return false, err
+	}
+	leads := slices.DeleteFunc(slices.Clone(team.Leads), func(id string) bool { return id == r.ReviewerID })
+
+	reason := "escalated to team leads " + strings.Join(leads, ", ")
+	if len(leads) == 0 {
+		reason = "team has no leads to escalate to"
+	}
+
+	var escalated bool
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		escalated, err = s.repo.MarkEscalated(ctx, r.PRID, r.ReviewerID, r.AssignedAt, now)
+		if err != nil || !escalated {
+			return err
+		}
+
+		if err := s.prService.recordEvents(ctx, domain.PREvent{
+			PRID:       r.PRID,
+			Type:       domain.PREventReviewEscalated,
+			OccurredAt: now,
+			ReviewerID: r.ReviewerID,
+			Reason:     reason,
+		}); err != nil {
+			return err
+		}
+
+		return s.notifications.NotifyEscalated(ctx, r.PRID, leads, r.ReviewerID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if escalated {
+		log.Info("overdue review escalated",
+			slog.String("prID", r.PRID),
+			slog.String("reviewerID", r.ReviewerID),
+			slog.Int("leads", len(leads)),
+		)
+	}
+
+	return escalated, nil
+}
@@ -0,0 +1,248 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewSLAService_Check_FlagsOverdue(t *testing.T) {
+	repo := mocks.NewReviewSLARepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	eventRepo := mocks.NewPREventRepository(t)
+	notificationRepo := mocks.NewNotificationRepository(t)
+
+	notificationSvc := service.NewNotificationService(notificationRepo, nil, nil, &recordingSender{}, testDeliveryPolicy, service.NotificationSchedule{})
+	prSvc := service.NewPRService(passthroughTx(t), prRepo, nil, teamRepo, eventRepo, nil, nil, nil, notificationSvc)
+	svc := service.NewReviewSLAService(passthroughTx(t), repo, teamRepo, prSvc, notificationSvc)
+
+	review := domain.PendingReview{
+		PRID:       "pr1",
+		PRName:     "Add search",
+		TeamName:   "backend",
+		ReviewerID: "u2",
+		AssignedAt: time.Now().UTC().AddDate(0, 0, -30).Truncate(time.Microsecond),
+		SLA:        domain.ReviewSLA{ResponseHours: 24, Escalation: domain.SLANotifyLead},
+	}
+
+	repo.
+		On("ListAwaiting", mock.Anything, mock.Anything).
+		Return([]domain.PendingReview{review}, nil).
+		Once()
+
+	repo.
+		On("MarkBreached", mock.Anything, "pr1", "u2", review.AssignedAt, mock.Anything).
+		Return(true, nil).
+		Once()
+
+	eventRepo.
+		On("Append", mock.Anything, mock.MatchedBy(func(events []domain.PREvent) bool {
+			return len(events) == 1 &&
+				events[0].Type == domain.PREventReviewOverdue &&
+				events[0].ReviewerID == "u2" &&
+				events[0].Reason == "no response within 24 working hours"
+		})).
+		Return(nil).
+		Once()
+
+	notificationRepo.
+		On("Enqueue", mock.Anything, mock.MatchedBy(func(list []domain.Notification) bool {
+			return len(list) == 1 && list[0].UserID == "u2" && list[0].Kind == domain.NotificationReviewOverdue
+		})).
+		Return(nil).
+		Once()
+
+	n, err := svc.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestReviewSLAService_Check_AlreadyFlaggedElsewhere(t *testing.T) {
+	repo := mocks.NewReviewSLARepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	eventRepo := mocks.NewPREventRepository(t)
+	notificationRepo := mocks.NewNotificationRepository(t)
+
+	notificationSvc := service.NewNotificationService(notificationRepo, nil, nil, &recordingSender{}, testDeliveryPolicy, service.NotificationSchedule{})
+	prSvc := service.NewPRService(passthroughTx(t), prRepo, nil, teamRepo, eventRepo, nil, nil, nil, notificationSvc)
+	svc := service.NewReviewSLAService(passthroughTx(t), repo, teamRepo, prSvc, notificationSvc)
+
+	review := domain.PendingReview{
+		PRID:       "pr1",
+		PRName:     "Add search",
+		TeamName:   "backend",
+		ReviewerID: "u2",
+		AssignedAt: time.Now().UTC().AddDate(0, 0, -30).Truncate(time.Microsecond),
+		SLA:        domain.ReviewSLA{ResponseHours: 24, Escalation: domain.SLANotifyLead},
+	}
+
+	repo.
+		On("ListAwaiting", mock.Anything, mock.Anything).
+		Return([]domain.PendingReview{review}, nil).
+		Once()
+
+	repo.
+		On("MarkBreached", mock.Anything, "pr1", "u2", review.AssignedAt, mock.Anything).
+		Return(false, nil).
+		Once()
+
+	n, err := svc.Check(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestReviewSLAService_Check_EscalatesToLeads(t *testing.T) {
+	repo := mocks.NewReviewSLARepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	eventRepo := mocks.NewPREventRepository(t)
+	notificationRepo := mocks.NewNotificationRepository(t)
+
+	notificationSvc := service.NewNotificationService(notificationRepo, nil, nil, &recordingSender{}, testDeliveryPolicy, service.NotificationSchedule{})
+	prSvc := service.NewPRService(passthroughTx(t), prRepo, nil, teamRepo, eventRepo, nil, nil, nil, notificationSvc)
+	svc := service.NewReviewSLAService(passthroughTx(t), repo, teamRepo, prSvc, notificationSvc)
+
+	review := domain.PendingReview{
+		PRID:       "pr1",
+		PRName:     "Add search",
+		TeamName:   "backend",
+		ReviewerID: "u2",
+		AssignedAt: time.Now().UTC().AddDate(0, 0, -30).Truncate(time.Microsecond),
+		SLA:        domain.DefaultReviewSLA(),
+	}
+	breachedAt := review.AssignedAt.Add(24 * time.Hour)
+	review.BreachedAt = &breachedAt
+
+	repo.
+		On("ListAwaiting", mock.Anything, mock.Anything).
+		Return([]domain.PendingReview{review}, nil).
+		Once()
+
+	team := domain.NewTeam("backend")
+	team.Leads = []string{"lead", "u2"}
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(team, nil).
+		Once()
+
+	repo.
+		On("MarkEscalated", mock.Anything, "pr1", "u2", review.AssignedAt, mock.Anything).
+		Return(true, nil).
+		Once()
+
+	eventRepo.
+		On("Append", mock.Anything, mock.MatchedBy(func(events []domain.PREvent) bool {
+			return len(events) == 1 &&
+				events[0].Type == domain.PREventReviewEscalated &&
+				events[0].Reason == "escalated to team leads lead"
+		})).
+		Return(nil).
+		Once()
+
+	// The reviewer is a lead too, but is not told about their own review.
+	notificationRepo.
+		On("Enqueue", mock.Anything, mock.MatchedBy(func(list []domain.Notification) bool {
+			return len(list) == 1 &&
+				list[0].UserID == "lead" &&
+				list[0].Kind == domain.NotificationReviewEscalated &&
+				list[0].PreviousReviewerID == "u2"
+		})).
+		Return(nil).
+		Once()
+
+	n, err := svc.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestReviewSLAService_Check_ReassignSkipsSettledReview(t *testing.T) {
+	repo := mocks.NewReviewSLARepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	eventRepo := mocks.NewPREventRepository(t)
+	notificationRepo := mocks.NewNotificationRepository(t)
+
+	notificationSvc := service.NewNotificationService(notificationRepo, nil, nil, &recordingSender{}, testDeliveryPolicy, service.NotificationSchedule{})
+	prSvc := service.NewPRService(passthroughTx(t), prRepo, nil, teamRepo, eventRepo, nil, nil, nil, notificationSvc)
+	svc := service.NewReviewSLAService(passthroughTx(t), repo, teamRepo, prSvc, notificationSvc)
+
+	review := domain.PendingReview{
+		PRID:       "pr1",
+		PRName:     "Add search",
+		TeamName:   "backend",
+		ReviewerID: "u2",
+		AssignedAt: time.Now().UTC().AddDate(0, 0, -30).Truncate(time.Microsecond),
+		SLA:        domain.ReviewSLA{ResponseHours: 24, EscalationHours: 48, Escalation: domain.SLAReassign},
+	}
+	breachedAt := review.AssignedAt.Add(24 * time.Hour)
+	review.BreachedAt = &breachedAt
+
+	repo.
+		On("ListAwaiting", mock.Anything, mock.Anything).
+		Return([]domain.PendingReview{review}, nil).
+		Once()
+
+	// The pull request was merged after the review was listed.
+	prRepo.
+		On("GetByIDForUpdate", mock.Anything, "pr1").
+		Return(&domain.PullRequest{ID: "pr1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2"}}, nil).
+		Once()
+
+	n, err := svc.Check(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestReviewSLAService_ListOverdue_UnknownTeam(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.NewReviewSLAService(passthroughTx(t), nil, teamRepo, nil, nil)
+
+	teamRepo.
+		On("ExistsByName", mock.Anything, "nope").
+		Return(false, nil).
+		Once()
+
+	_, err := svc.ListOverdue(context.Background(), "nope")
+	require.ErrorIs(t, err, domain.ErrTeamNotFound)
+}
+
+func TestAddWorkingHours(t *testing.T) {
+	// 2026-10-16 is a Friday.
+	friday := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		start time.Time
+		hours int
+		want  time.Time
+	}{
+		{"same day", friday.Add(-8 * time.Hour), 4, friday.Add(-4 * time.Hour)},
+		{"over the weekend", friday, 24, time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)},
+		{"started on saturday", friday.Add(24 * time.Hour), 2, time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)},
+		{"ends at friday midnight", friday, 6, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"a full week", friday, 5 * 24, time.Date(2026, 10, 23, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, domain.AddWorkingHours(tc.start, tc.hours))
+		})
+	}
+}
+
+func TestReviewSLA_Validate(t *testing.T) {
+	require.NoError(t, domain.DefaultReviewSLA().Validate())
+	require.NoError(t, domain.ReviewSLA{Escalation: domain.SLANotifyLead}.Validate())
+	require.ErrorIs(t, domain.ReviewSLA{ResponseHours: -1, Escalation: domain.SLANotifyLead}.Validate(), domain.ErrInvalidSLA)
+	require.ErrorIs(t, domain.ReviewSLA{ResponseHours: 24, EscalationHours: 24, Escalation: domain.SLANotifyLead}.Validate(), domain.ErrInvalidSLA)
+	require.ErrorIs(t, domain.ReviewSLA{ResponseHours: 24, Escalation: "page"}.Validate(), domain.ErrInvalidSLA)
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// ReviewerSyncService mirrors reviewer assignments on the Git hosting
+// provider a pull request came from. Changes are queued in the transaction
+// that makes them and pushed by a background worker, so a provider outage
+// never fails an assignment.
+type ReviewerSyncService struct {
+	repo     repository.ReviewerSyncRepository
+	prRepo   repository.PRRepository
+	userRepo repository.UserRepository
+	clients  map[string]githost.Client
+	policy   DeliveryPolicy
+}
+
+// NewReviewerSyncService takes a client per provider; providers without a
+// client are not synced.
+func NewReviewerSyncService(
+	repo repository.ReviewerSyncRepository,
+	prRepo repository.PRRepository,
+	userRepo repository.UserRepository,
+	clients map[string]githost.Client,
+	policy DeliveryPolicy,
+) *ReviewerSyncService {
+	return &ReviewerSyncService{
+		repo:     repo,
+		prRepo:   prRepo,
+		userRepo: userRepo,
+		clients:  clients,
+		policy:   policy,
+	}
+}
+
+// Enqueue queues requesting reviews from add and withdrawing them from
+// remove. It does nothing for pull requests that were not created from a
+// provider with a client, and on a nil service.
+func (s *ReviewerSyncService) Enqueue(ctx context.Context, prID string, add []string, remove []string) error {
+	if s == nil || len(s.clients) == 0 || (len(add) == 0 && len(remove) == 0) {
+		return nil
+	}
+
+	log := logger.L()
+
+	src, err := s.prRepo.GetSource(ctx, prID)
+	if err != nil {
+		log.Error("failed to get pull request source",
+			slog.String("prID", prID),
+			slog.Any("err", err),
+		)
+		return err
+	}
+	if src == nil || s.clients[src.Provider] == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	job := &domain.ReviewerSyncJob{
+		PRID:          prID,
+		Add:           add,
+		Remove:        remove,
+		Status:        domain.ReviewerSyncPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	if job.Add == nil {
+		job.Add = []string{}
+	}
+	if job.Remove == nil {
+		job.Remove = []string{}
+	}
+
+	if err := s.repo.Enqueue(ctx, job); err != nil {
+		log.Error("failed to enqueue reviewer sync",
+			slog.String("prID", prID),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	log.Info("reviewer sync enqueued",
+		slog.String("prID", prID),
+		slog.Int64("jobID", job.ID),
+	)
+
+	return nil
+}
+
+// ListJobs returns the sync jobs of a pull request, oldest first.
+func (s *ReviewerSyncService) ListJobs(ctx context.Context, prID string) ([]domain.ReviewerSyncJob, error) {
+	log := logger.L()
+
+	exists, err := s.prRepo.Exists(ctx, prID)
+	if err != nil {
+		log.Error("failed to check if pull request exists",
+			slog.String("prID", prID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+	if !exists {
+		log.Warn("pull request not found", slog.String("prID", prID))
+		return nil, domain.ErrPRNotFound
+	}
+
+	jobs, err := s.repo.ListByPR(ctx, prID)
+	if err != nil {
+		log.Error("failed to list reviewer sync jobs",
+			slog.String("prID", prID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Sync runs the jobs that are due. It returns the number of jobs run.
+func (s *ReviewerSyncService) Sync(ctx context.Context) (int, error) {
+	log := logger.L()
+
+	now := time.Now().UTC()
+	due, err := s.repo.ClaimDue(ctx, now, now.Add(s.policy.Lease), s.policy.BatchSize)
+	if err != nil {
+		log.Error("failed to claim reviewer sync jobs", slog.Any("err", err))
+		return 0, err
+	}
+
+	for i := range due {
+		s.attempt(ctx, &due[i])
+	}
+
+	return len(due), nil
+}
+
+// RunWorker calls Sync every interval until ctx is done.
+func (s *ReviewerSyncService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.Sync(ctx)
+		}
+	}
+}
+
+// attempt runs one job and stores the outcome. Errors the provider will
+// keep returning fail the job at once; others are retried with backoff.
+func (s *ReviewerSyncService) attempt(ctx context.Context, job *domain.ReviewerSyncJob) {
+	log := logger.L()
+
+	err := s.push(ctx, job)
+
+	now := time.Now().UTC()
+	job.Attempts++
+	job.LastAttemptAt = &now
+	job.LastError = ""
+
+	switch {
+	case err == nil:
+		job.Status = domain.ReviewerSyncSucceeded
+	case githost.IsPermanent(err) || job.Attempts >= s.policy.MaxAttempts:
+		job.Status = domain.ReviewerSyncFailed
+	default:
+		job.Status = domain.ReviewerSyncPending
+		job.NextAttemptAt = now.Add(s.policy.backoff(job.Attempts))
+	}
+
+	if err != nil {
+		job.LastError = err.Error()
+		if len(job.LastError) > maxDeliveryErrorLength {
+			job.LastError = job.LastError[:maxDeliveryErrorLength]
+		}
+		log.Warn("reviewer sync failed",
+			slog.Int64("jobID", job.ID),
+			slog.String("prID", job.PRID),
+			slog.Int("attempts", job.Attempts),
+			slog.String("status", string(job.Status)),
+			slog.Any("err", err),
+		)
+	}
+
+	if err := s.repo.SaveAttempt(ctx, job); err != nil {
+		log.Error("failed to save reviewer sync attempt",
+			slog.Int64("jobID", job.ID),
+			slog.Any("err", err),
+		)
+	}
+}
+
+func (s *ReviewerSyncService) push(ctx context.Context, job *domain.ReviewerSyncJob) error {
+	src, err := s.prRepo.GetSource(ctx, job.PRID)
+	if err != nil {
+		return err
+	}
+	if src == nil {
+		return nil
+	}
+
+	client := s.clients[src.Provider]
+	if client == nil {
+		return errors.New("no client configured for provider " + src.Provider)
+	}
+
+	remove, err := s.logins(ctx, src.Provider, job.Remove)
+	if err != nil {
+		return err
+	}
+	if len(remove) > 0 {
+		if err := client.RemoveReviewers(ctx, *src, remove); err != nil {
+			return err
+		}
+	}
+
+	add, err := s.logins(ctx, src.Provider, job.Add)
+	if err != nil {
+		return err
+	}
+	if len(add) > 0 {
+		if err := client.RequestReviewers(ctx, *src, add); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// logins resolves user ids to provider logins. Users without a linked
+// account, including erased ones, can not be mirrored and are skipped.
+func (s *ReviewerSyncService) logins(ctx context.Context, provider string, userIDs []string) ([]string, error) {
+	log := logger.L()
+
+	logins := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		u, err := s.userRepo.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		login := u.ExternalAccounts[provider]
+		if login == "" {
+			log.Info("reviewer has no linked account, skipping",
+				slog.String("userID", id),
+				slog.String("provider", provider),
+			)
+			continue
+		}
+		logins = append(logins, login)
+	}
+
+	return logins, nil
+}
@@ -0,0 +1,248 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSource = &domain.PRSource{
+	Provider:   domain.ProviderGitHub,
+	Repository: "acme/hello-world",
+	Number:     1347,
+}
+
+func TestReviewerSyncService_Enqueue(t *testing.T) {
+	repo := mocks.NewReviewerSyncRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+
+	svc := service.NewReviewerSyncService(repo, prRepo, nil,
+		map[string]githost.Client{domain.ProviderGitHub: githost.NewFakeClient()}, testDeliveryPolicy)
+
+	ctx := context.Background()
+
+	prRepo.
+		On("GetSource", ctx, "pr1").
+		Return(testSource, nil).
+		Once()
+
+	repo.
+		On("Enqueue", ctx, mock.MatchedBy(func(job *domain.ReviewerSyncJob) bool {
+			return job.PRID == "pr1" && job.Status == domain.ReviewerSyncPending &&
+				len(job.Add) == 2 && len(job.Remove) == 0
+		})).
+		Return(nil).
+		Once()
+
+	require.NoError(t, svc.Enqueue(ctx, "pr1", []string{"u2", "u3"}, nil))
+}
+
+func TestReviewerSyncService_Enqueue_NotFromGitHost(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+
+	svc := service.NewReviewerSyncService(nil, prRepo, nil,
+		map[string]githost.Client{domain.ProviderGitHub: githost.NewFakeClient()}, testDeliveryPolicy)
+
+	prRepo.
+		On("GetSource", mock.Anything, "pr1").
+		Return(nil, nil).
+		Once()
+
+	require.NoError(t, svc.Enqueue(context.Background(), "pr1", []string{"u2"}, nil))
+
+	var nilSvc *service.ReviewerSyncService
+	require.NoError(t, nilSvc.Enqueue(context.Background(), "pr1", []string{"u2"}, nil))
+}
+
+func TestReviewerSyncService_Sync(t *testing.T) {
+	repo := mocks.NewReviewerSyncRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	client := githost.NewFakeClient()
+
+	svc := service.NewReviewerSyncService(repo, prRepo, userRepo,
+		map[string]githost.Client{domain.ProviderGitHub: client}, testDeliveryPolicy)
+
+	require.NoError(t, client.RequestReviewers(context.Background(), *testSource, []string{"hubot"}))
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.ReviewerSyncJob{{
+			ID:     5,
+			PRID:   "pr1",
+			Add:    []string{"u2"},
+			Remove: []string{"u1"},
+			Status: domain.ReviewerSyncPending,
+		}}, nil).
+		Once()
+
+	prRepo.
+		On("GetSource", mock.Anything, "pr1").
+		Return(testSource, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u1").
+		Return(&domain.User{ID: "u1", ExternalAccounts: map[string]string{domain.ProviderGitHub: "hubot"}}, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", ExternalAccounts: map[string]string{domain.ProviderGitHub: "octocat"}}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(job *domain.ReviewerSyncJob) bool {
+			return job.Status == domain.ReviewerSyncSucceeded && job.Attempts == 1 && job.LastError == ""
+		})).
+		Return(nil).
+		Once()
+
+	n, err := svc.Sync(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{"octocat"}, client.Reviewers(*testSource))
+}
+
+func TestReviewerSyncService_Sync_RetriesWithBackoff(t *testing.T) {
+	repo := mocks.NewReviewerSyncRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	client := githost.NewFakeClient()
+
+	svc := service.NewReviewerSyncService(repo, prRepo, userRepo,
+		map[string]githost.Client{domain.ProviderGitHub: client}, testDeliveryPolicy)
+
+	client.SetErr(&githost.APIError{StatusCode: http.StatusBadGateway, Message: "bad gateway"})
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.ReviewerSyncJob{{
+			ID:       5,
+			PRID:     "pr1",
+			Add:      []string{"u2"},
+			Status:   domain.ReviewerSyncPending,
+			Attempts: 1,
+		}}, nil).
+		Once()
+
+	prRepo.
+		On("GetSource", mock.Anything, "pr1").
+		Return(testSource, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", ExternalAccounts: map[string]string{domain.ProviderGitHub: "octocat"}}, nil).
+		Once()
+
+	before := time.Now()
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(job *domain.ReviewerSyncJob) bool {
+			// The second attempt waits twice the base delay.
+			return job.Status == domain.ReviewerSyncPending && job.Attempts == 2 &&
+				job.NextAttemptAt.After(before.Add(2*time.Minute-time.Second)) &&
+				job.LastError != ""
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Sync(context.Background())
+	require.NoError(t, err)
+}
+
+func TestReviewerSyncService_Sync_PermanentFailure(t *testing.T) {
+	repo := mocks.NewReviewerSyncRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	client := githost.NewFakeClient()
+
+	svc := service.NewReviewerSyncService(repo, prRepo, userRepo,
+		map[string]githost.Client{domain.ProviderGitHub: client}, testDeliveryPolicy)
+
+	client.SetErr(&githost.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "not a collaborator"})
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.ReviewerSyncJob{{
+			ID:     5,
+			PRID:   "pr1",
+			Add:    []string{"u2"},
+			Status: domain.ReviewerSyncPending,
+		}}, nil).
+		Once()
+
+	prRepo.
+		On("GetSource", mock.Anything, "pr1").
+		Return(testSource, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", ExternalAccounts: map[string]string{domain.ProviderGitHub: "octocat"}}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(job *domain.ReviewerSyncJob) bool {
+			return job.Status == domain.ReviewerSyncFailed && job.Attempts == 1
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Sync(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, client.Calls())
+}
+
+func TestReviewerSyncService_Sync_GivesUp(t *testing.T) {
+	repo := mocks.NewReviewerSyncRepository(t)
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	client := githost.NewFakeClient()
+
+	svc := service.NewReviewerSyncService(repo, prRepo, userRepo,
+		map[string]githost.Client{domain.ProviderGitHub: client}, testDeliveryPolicy)
+
+	client.SetErr(errors.New("connection reset"))
+
+	repo.
+		On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, testDeliveryPolicy.BatchSize).
+		Return([]domain.ReviewerSyncJob{{
+			ID:       5,
+			PRID:     "pr1",
+			Add:      []string{"u2"},
+			Status:   domain.ReviewerSyncPending,
+			Attempts: testDeliveryPolicy.MaxAttempts - 1,
+		}}, nil).
+		Once()
+
+	prRepo.
+		On("GetSource", mock.Anything, "pr1").
+		Return(testSource, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", mock.Anything, "u2").
+		Return(&domain.User{ID: "u2", ExternalAccounts: map[string]string{domain.ProviderGitHub: "octocat"}}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", mock.Anything, mock.MatchedBy(func(job *domain.ReviewerSyncJob) bool {
+			return job.Status == domain.ReviewerSyncFailed && job.LastError == "connection reset"
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Sync(context.Background())
+	require.NoError(t, err)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// StalePRService finds open pull requests that sit without progress and
+// sends their reviewers and team leads a daily digest of them.
+type StalePRService struct {
+	repo          repository.StalePRRepository
+	teamRepo      repository.TeamRepository
+	userRepo      repository.UserRepository
+	notifications *NotificationService
+	policy        domain.StalePolicy
+}
+
+func NewStalePRService(
+	repo repository.StalePRRepository,
+	teamRepo repository.TeamRepository,
+	userRepo repository.UserRepository,
+	notifications *NotificationService,
+	policy domain.StalePolicy,
+) *StalePRService {
+	return &StalePRService{
+		repo:          repo,
+		teamRepo:      teamRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+		policy:        policy,
+	}
+}
+
+// List returns the stale pull requests, oldest first.
+func (s *StalePRService) List(ctx context.Context, filter domain.StalePRFilter) ([]domain.StalePR, error) {
+	log := logger.L()
+
+	if filter.TeamName != "" {
+		exists, err := s.teamRepo.ExistsByName(ctx, filter.TeamName)
+		if err != nil {
+			log.Error("failed to check team existence",
+				slog.String("teamName", filter.TeamName),
+				slog.Any("err", err),
+			)
+			return nil, err
+		}
+		if !exists {
+			log.Warn("team not found", slog.String("teamName", filter.TeamName))
+			return nil, domain.ErrTeamNotFound
+		}
+	}
+
+	if filter.ReviewerID != "" {
+		if _, err := s.userRepo.GetByID(ctx, filter.ReviewerID); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				log.Warn("user not found", slog.String("userID", filter.ReviewerID))
+				return nil, err
+			}
+			log.Error("failed to get user",
+				slog.String("userID", filter.ReviewerID),
+				slog.Any("err", err),
+			)
+			return nil, err
+		}
+	}
+
+	return s.list(ctx, filter, time.Now().UTC())
+}
+
+// Notify queues the daily digest notifications about stale pull requests.
+// It returns the number of notifications queued.
+func (s *StalePRService) Notify(ctx context.Context) (int, error) {
+	log := logger.L()
+
+	prs, err := s.list(ctx, domain.StalePRFilter{}, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	leads := map[string][]string{}
+	for _, pr := range prs {
+		if _, ok := leads[pr.TeamName]; ok || pr.TeamName == "" {
+			continue
+		}
+
+		team, err := s.teamRepo.GetByName(ctx, pr.TeamName)
+		switch {
+		case errors.Is(err, domain.ErrTeamNotFound):
+			leads[pr.TeamName] = nil
+		case err != nil:
+			log.Error("failed to get team",
+				slog.String("teamName", pr.TeamName),
+				slog.Any("err", err),
+			)
+			return 0, err
+		default:
+			leads[pr.TeamName] = team.Leads
+		}
+	}
+
+	n, err := s.notifications.NotifyStale(ctx, prs, leads)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > 0 {
+		log.Info("stale pull request notifications enqueued",
+			slog.Int("pullRequests", len(prs)),
+			slog.Int("count", n),
+		)
+	}
+
+	return n, nil
+}
+
+func (s *StalePRService) list(ctx context.Context, filter domain.StalePRFilter, now time.Time) ([]domain.StalePR, error) {
+	prs, err := s.repo.ListStale(ctx, filter, s.policy.OpenedBefore(now), s.policy.ActiveBefore(now))
+	if err != nil {
+		logger.L().Error("failed to list stale pull requests", slog.Any("err", err))
+		return nil, err
+	}
+
+	for i := range prs {
+		prs[i].Reasons = s.policy.Reasons(&prs[i], now)
+	}
+
+	return prs, nil
+}
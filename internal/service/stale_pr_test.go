@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testStalePolicy = domain.StalePolicy{MaxAge: 7 * 24 * time.Hour, MaxIdle: 3 * 24 * time.Hour}
+
+func TestStalePRService_List(t *testing.T) {
+	repo := mocks.NewStalePRRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.NewStalePRService(repo, teamRepo, nil, nil, testStalePolicy)
+
+	now := time.Now().UTC()
+	teamRepo.
+		On("ExistsByName", mock.Anything, "backend").
+		Return(true, nil).
+		Once()
+
+	repo.
+		On("ListStale", mock.Anything, domain.StalePRFilter{TeamName: "backend"},
+			mock.MatchedBy(func(openedBefore time.Time) bool {
+				return openedBefore.Sub(now.Add(-testStalePolicy.MaxAge)) < time.Minute
+			}),
+			mock.MatchedBy(func(activeBefore time.Time) bool {
+				return activeBefore.Sub(now.Add(-testStalePolicy.MaxIdle)) < time.Minute
+			})).
+		Return([]domain.StalePR{
+			{PRID: "pr1", CreatedAt: now.AddDate(0, 0, -10), LastActivityAt: now.AddDate(0, 0, -1)},
+			{PRID: "pr2", CreatedAt: now.AddDate(0, 0, -4), LastActivityAt: now.AddDate(0, 0, -4)},
+		}, nil).
+		Once()
+
+	prs, err := svc.List(context.Background(), domain.StalePRFilter{TeamName: "backend"})
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	require.Equal(t, []domain.StaleReason{domain.StaleByAge}, prs[0].Reasons)
+	require.Equal(t, []domain.StaleReason{domain.StaleByInactivity}, prs[1].Reasons)
+}
+
+func TestStalePRService_List_UnknownFilter(t *testing.T) {
+	t.Run("team", func(t *testing.T) {
+		teamRepo := mocks.NewTeamRepository(t)
+
+		svc := service.NewStalePRService(nil, teamRepo, nil, nil, testStalePolicy)
+
+		teamRepo.
+			On("ExistsByName", mock.Anything, "nope").
+			Return(false, nil).
+			Once()
+
+		_, err := svc.List(context.Background(), domain.StalePRFilter{TeamName: "nope"})
+		require.ErrorIs(t, err, domain.ErrTeamNotFound)
+	})
+
+	t.Run("reviewer", func(t *testing.T) {
+		userRepo := mocks.NewUserRepository(t)
+
+		svc := service.NewStalePRService(nil, nil, userRepo, nil, testStalePolicy)
+
+		userRepo.
+			On("GetByID", mock.Anything, "nope").
+			Return(nil, domain.ErrUserNotFound).
+			Once()
+
+		_, err := svc.List(context.Background(), domain.StalePRFilter{ReviewerID: "nope"})
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestStalePRService_Notify(t *testing.T) {
+	repo := mocks.NewStalePRRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	notificationRepo := mocks.NewNotificationRepository(t)
+
+	notificationSvc := service.NewNotificationService(notificationRepo, nil, nil, &recordingSender{}, testDeliveryPolicy, service.NotificationSchedule{})
+	svc := service.NewStalePRService(repo, teamRepo, nil, notificationSvc, testStalePolicy)
+
+	old := time.Now().UTC().AddDate(0, 0, -10)
+	repo.
+		On("ListStale", mock.Anything, domain.StalePRFilter{}, mock.Anything, mock.Anything).
+		Return([]domain.StalePR{
+			{PRID: "pr1", TeamName: "backend", CreatedAt: old, LastActivityAt: old, PendingReviewers: []string{"u2"}},
+			{PRID: "pr2", TeamName: "backend", CreatedAt: old, LastActivityAt: old, PendingReviewers: []string{"u3"}},
+		}, nil).
+		Once()
+
+	// Teams are looked up once however many of their pull requests are stale.
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(&domain.Team{Name: "backend", Leads: []string{"u1"}}, nil).
+		Once()
+
+	notificationRepo.
+		On("EnqueueMissing", mock.Anything, mock.MatchedBy(func(list []domain.Notification) bool {
+			leads := 0
+			for _, n := range list {
+				if n.Kind == domain.NotificationStalePR && n.UserID == "u1" {
+					leads++
+				}
+			}
+			return len(list) == 4 && leads == 2
+		}), mock.Anything).
+		Return(4, nil).
+		Once()
+
+	n, err := svc.Notify(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+}
+
+func TestStalePolicy_Reasons(t *testing.T) {
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	pr := &domain.StalePR{CreatedAt: now.AddDate(0, 0, -8), LastActivityAt: now.AddDate(0, 0, -5)}
+
+	require.Equal(t,
+		[]domain.StaleReason{domain.StaleByAge, domain.StaleByInactivity},
+		testStalePolicy.Reasons(pr, now),
+	)
+	require.Equal(t,
+		[]domain.StaleReason{domain.StaleByInactivity},
+		domain.StalePolicy{MaxIdle: testStalePolicy.MaxIdle}.Reasons(pr, now),
+	)
+	require.Empty(t, domain.StalePolicy{}.Reasons(pr, now))
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
@@ -10,34 +13,168 @@ import (
 )
 
 type StatsService struct {
-	statsRepo repository.StatsRepository
+	statsRepo   repository.StatsRepository
+	teamRepo    repository.TeamRepository
+	absenceRepo repository.AbsenceRepository
 }
 
-func NewStatsService(statsRepo repository.StatsRepository) *StatsService {
-	return &StatsService{statsRepo: statsRepo}
+func NewStatsService(
+	statsRepo repository.StatsRepository,
+	teamRepo repository.TeamRepository,
+	absenceRepo repository.AbsenceRepository,
+) *StatsService {
+	return &StatsService{
+		statsRepo:   statsRepo,
+		teamRepo:    teamRepo,
+		absenceRepo: absenceRepo,
+	}
 }
 
-func (s *StatsService) GetStats(ctx context.Context) ([]domain.UserAssignmentStat, []domain.PRReviewerStat, error) {
+func (s *StatsService) GetStats(ctx context.Context, filter domain.StatsFilter) (*domain.Stats, error) {
 	log := logger.L()
 	log.Info("collecting statistics")
 
-	byUser, err := s.statsRepo.CountAssignmentsByUser(ctx)
+	if err := filter.Validate(); err != nil {
+		log.Warn("invalid statistics filter", slog.Any("err", err))
+		return nil, err
+	}
+
+	if err := s.checkTeam(ctx, filter.TeamName); err != nil {
+		return nil, err
+	}
+
+	byUser, err := s.statsRepo.CountAssignmentsByUser(ctx, filter)
 	if err != nil {
 		log.Error("failed get count assignments by user",
 			slog.Any("err", err),
 		)
-		return nil, nil, err
+		return nil, err
 	}
 
-	byPR, err := s.statsRepo.CountReviewersByPR(ctx)
+	prLimit := min(filter.PRLimit, domain.MaxStatsPRLimit)
+
+	// One more pull request is asked for to tell whether the list was cut.
+	prFilter := filter
+	if prLimit > 0 {
+		prFilter.PRLimit = prLimit + 1
+	}
+	byPR, err := s.statsRepo.CountReviewersByPR(ctx, prFilter)
 	if err != nil {
 		log.Error("failed get count reviewers by pull request",
 			slog.Any("err", err),
 		)
-		return nil, nil, err
+		return nil, err
+	}
+	truncated := prLimit > 0 && len(byPR) > prLimit
+	if truncated {
+		byPR = byPR[:prLimit]
+	}
+
+	byTeam, err := s.statsRepo.CountByTeam(ctx, filter)
+	if err != nil {
+		log.Error("failed get pull request totals by team",
+			slog.Any("err", err),
+		)
+		return nil, err
 	}
 
 	log.Info("statistics successfully collected")
 
-	return byUser, byPR, nil
+	return &domain.Stats{
+		ByUser:        byUser,
+		ByPR:          byPR,
+		ByPRTruncated: truncated,
+		ByTeam:        byTeam,
+	}, nil
+}
+
+// GetCycleTime measures how fast the pull requests of the period move:
+// their time to merge and time to approval by team and by reviewer, and
+// the age of the pull requests left open at the end of it.
+func (s *StatsService) GetCycleTime(ctx context.Context, filter domain.CycleTimeFilter) (*domain.CycleTime, error) {
+	log := logger.L()
+
+	if err := filter.Validate(); err != nil {
+		log.Warn("invalid cycle time filter", slog.Any("err", err))
+		return nil, err
+	}
+
+	if err := s.checkTeam(ctx, filter.TeamName); err != nil {
+		return nil, err
+	}
+
+	asOf := time.Now().UTC()
+	if filter.To != nil && filter.To.Before(asOf) {
+		asOf = filter.To.UTC()
+	}
+
+	byTeam, err := s.statsRepo.CycleTimeByTeam(ctx, filter)
+	if err != nil {
+		log.Error("failed to get cycle time by team", slog.Any("err", err))
+		return nil, err
+	}
+
+	byReviewer, err := s.statsRepo.CycleTimeByReviewer(ctx, filter)
+	if err != nil {
+		log.Error("failed to get cycle time by reviewer", slog.Any("err", err))
+		return nil, err
+	}
+
+	openAge, err := s.statsRepo.OpenAgeByTeam(ctx, filter.TeamName, asOf, domain.OpenAgeBounds)
+	if err != nil {
+		log.Error("failed to get open pull request age by team", slog.Any("err", err))
+		return nil, err
+	}
+
+	// Teams with open pull requests only still get a row.
+	seen := make(map[string]bool, len(byTeam))
+	for _, t := range byTeam {
+		seen[t.TeamName] = true
+	}
+	for team := range openAge {
+		if !seen[team] {
+			byTeam = append(byTeam, domain.TeamCycleTime{TeamName: team})
+		}
+	}
+	slices.SortFunc(byTeam, func(a, b domain.TeamCycleTime) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+
+	for i := range byTeam {
+		byTeam[i].OpenAge = domain.NewAgeBuckets(domain.OpenAgeBounds)
+		for j, n := range openAge[byTeam[i].TeamName] {
+			byTeam[i].OpenAge[j].Count = n
+		}
+	}
+
+	log.Info("cycle time successfully collected")
+
+	return &domain.CycleTime{
+		AsOf:       asOf,
+		ByTeam:     byTeam,
+		ByReviewer: byReviewer,
+	}, nil
+}
+
+func (s *StatsService) checkTeam(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return nil
+	}
+
+	log := logger.L()
+
+	exists, err := s.teamRepo.ExistsByName(ctx, teamName)
+	if err != nil {
+		log.Error("failed to check team existence",
+			slog.String("teamName", teamName),
+			slog.Any("err", err),
+		)
+		return err
+	}
+	if !exists {
+		log.Warn("team not found", slog.String("teamName", teamName))
+		return domain.ErrTeamNotFound
+	}
+
+	return nil
 }
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// CheckCounters compares the statistics counters with the pull requests
+// and reviewers they count and rebuilds them if they drifted. It returns
+// the number of counter rows that were found wrong.
+func (s *StatsService) CheckCounters(ctx context.Context) (int, error) {
+	log := logger.L()
+
+	drift, err := s.statsRepo.CheckCounters(ctx)
+	if err != nil {
+		log.Error("failed to check statistics counters", slog.Any("err", err))
+		return 0, err
+	}
+	if drift == 0 {
+		return 0, nil
+	}
+
+	log.Warn("statistics counters drifted, rebuilding", slog.Int("rows", drift))
+	if err := s.statsRepo.RebuildCounters(ctx); err != nil {
+		log.Error("failed to rebuild statistics counters", slog.Any("err", err))
+		return 0, err
+	}
+
+	log.Info("statistics counters rebuilt")
+
+	return drift, nil
+}
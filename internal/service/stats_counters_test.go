@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsService_CheckCounters_InSync(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	statsRepo.On("CheckCounters", ctx).Return(0, nil).Once()
+
+	drift, err := svc.CheckCounters(ctx)
+
+	require.NoError(t, err)
+	require.Zero(t, drift)
+	statsRepo.AssertNotCalled(t, "RebuildCounters", ctx)
+}
+
+func TestStatsService_CheckCounters_RebuildsOnDrift(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	statsRepo.On("CheckCounters", ctx).Return(3, nil).Once()
+	statsRepo.On("RebuildCounters", ctx).Return(nil).Once()
+
+	drift, err := svc.CheckCounters(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 3, drift)
+}
+
+func TestStatsService_CheckCounters_RebuildError(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	rebuildErr := errors.New("lock timeout")
+	statsRepo.On("CheckCounters", ctx).Return(1, nil).Once()
+	statsRepo.On("RebuildCounters", ctx).Return(rebuildErr).Once()
+
+	_, err := svc.CheckCounters(ctx)
+
+	require.ErrorIs(t, err, rebuildErr)
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+)
+
+// DefaultHistoryWindow is the period /stats/history reports when the
+// request does not set one.
+const DefaultHistoryWindow = 30 * domain.Day
+
+// snapshotBatchDays is the number of days snapshotted in one transaction
+// when catching up.
+const snapshotBatchDays = 31
+
+// Snapshot saves the snapshots of the days finished since the last
+// snapshot, starting from the first pull request the first time, and
+// removes the ones older than retention. The last snapshotted day is taken
+// again to pick up late changes. It returns the number of days saved.
+func (s *StatsService) Snapshot(ctx context.Context, retention time.Duration) (int, error) {
+	log := logger.L()
+
+	today := domain.StartOfDay(time.Now())
+	oldest := domain.StartOfDay(today.Add(-retention))
+
+	from, err := s.statsRepo.LastSnapshotDay(ctx)
+	if err != nil {
+		log.Error("failed to get the last snapshot day", slog.Any("err", err))
+		return 0, err
+	}
+	if from == nil {
+		if from, err = s.statsRepo.FirstPRDay(ctx); err != nil {
+			log.Error("failed to get the first pull request day", slog.Any("err", err))
+			return 0, err
+		}
+	}
+
+	saved := 0
+	if from != nil {
+		start := *from
+		if start.Before(oldest) {
+			start = oldest
+		}
+
+		for start.Before(today) {
+			stop := start.Add(snapshotBatchDays * domain.Day)
+			if stop.After(today) {
+				stop = today
+			}
+
+			if err := s.statsRepo.SaveSnapshots(ctx, start, stop); err != nil {
+				log.Error("failed to save statistics snapshots",
+					slog.Time("from", start),
+					slog.Time("to", stop),
+					slog.Any("err", err),
+				)
+				return saved, err
+			}
+
+			saved += int(stop.Sub(start) / domain.Day)
+			start = stop
+		}
+	}
+
+	deleted, err := s.statsRepo.DeleteSnapshotsBefore(ctx, oldest)
+	if err != nil {
+		log.Error("failed to prune statistics snapshots", slog.Any("err", err))
+		return saved, err
+	}
+
+	if saved > 0 || deleted > 0 {
+		log.Info("statistics snapshots saved",
+			slog.Int("days", saved),
+			slog.Int64("pruned", deleted),
+		)
+	}
+
+	return saved, nil
+}
+
+// GetHistory reports the daily snapshots of a period as series, a value
+// per day. The period is widened to whole days; without one it is the
+// DefaultHistoryWindow before today.
+func (s *StatsService) GetHistory(ctx context.Context, filter domain.StatsHistoryFilter) (*domain.StatsHistory, error) {
+	log := logger.L()
+
+	if filter.To.IsZero() {
+		filter.To = domain.StartOfDay(time.Now())
+	} else if to := domain.StartOfDay(filter.To); !to.Equal(filter.To) {
+		filter.To = to.Add(domain.Day)
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-DefaultHistoryWindow)
+	} else {
+		filter.From = domain.StartOfDay(filter.From)
+	}
+
+	if err := filter.Validate(); err != nil {
+		log.Warn("invalid statistics history filter", slog.Any("err", err))
+		return nil, err
+	}
+
+	if err := s.checkTeam(ctx, filter.TeamName); err != nil {
+		return nil, err
+	}
+
+	history := &domain.StatsHistory{
+		From:  filter.From,
+		To:    filter.To,
+		Days:  filter.Days(),
+		Teams: []domain.TeamHistory{},
+		Users: []domain.UserHistory{},
+	}
+	day := func(t time.Time) int {
+		return int(t.Sub(filter.From) / domain.Day)
+	}
+
+	// The team series are left out when a user is asked for.
+	if filter.UserID == "" {
+		teams, err := s.statsRepo.TeamSnapshots(ctx, filter)
+		if err != nil {
+			log.Error("failed to get team snapshots", slog.Any("err", err))
+			return nil, err
+		}
+
+		n := len(history.Days)
+		for _, snap := range teams {
+			last := len(history.Teams) - 1
+			if last < 0 || history.Teams[last].TeamName != snap.TeamName {
+				history.Teams = append(history.Teams, domain.TeamHistory{
+					TeamName:    snap.TeamName,
+					Open:        make([]int, n),
+					Opened:      make([]int, n),
+					Merged:      make([]int, n),
+					Closed:      make([]int, n),
+					Assignments: make([]int, n),
+				})
+				last++
+			}
+
+			t, i := &history.Teams[last], day(snap.Day)
+			t.Open[i] = snap.Open
+			t.Opened[i] = snap.Opened
+			t.Merged[i] = snap.Merged
+			t.Closed[i] = snap.Closed
+			t.Assignments[i] = snap.Assignments
+		}
+	}
+
+	users, err := s.statsRepo.UserSnapshots(ctx, filter)
+	if err != nil {
+		log.Error("failed to get user snapshots", slog.Any("err", err))
+		return nil, err
+	}
+
+	n := len(history.Days)
+	for _, snap := range users {
+		last := len(history.Users) - 1
+		if last < 0 || history.Users[last].UserID != snap.UserID {
+			history.Users = append(history.Users, domain.UserHistory{
+				UserID:      snap.UserID,
+				Assignments: make([]int, n),
+				OpenReviews: make([]int, n),
+			})
+			last++
+		}
+
+		u, i := &history.Users[last], day(snap.Day)
+		u.Assignments[i] = snap.Assignments
+		u.OpenReviews[i] = snap.OpenReviews
+	}
+
+	log.Info("statistics history successfully collected")
+
+	return history, nil
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsService_Snapshot_BackfillsFromFirstPR(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	today := domain.StartOfDay(time.Now())
+	first := today.Add(-40 * domain.Day)
+
+	statsRepo.On("LastSnapshotDay", ctx).Return(nil, nil).Once()
+	statsRepo.On("FirstPRDay", ctx).Return(&first, nil).Once()
+	statsRepo.On("SaveSnapshots", ctx, first, first.Add(31*domain.Day)).Return(nil).Once()
+	statsRepo.On("SaveSnapshots", ctx, first.Add(31*domain.Day), today).Return(nil).Once()
+	statsRepo.On("DeleteSnapshotsBefore", ctx, today.Add(-365*domain.Day)).Return(int64(0), nil).Once()
+
+	saved, err := svc.Snapshot(ctx, 365*domain.Day)
+
+	require.NoError(t, err)
+	require.Equal(t, 40, saved)
+}
+
+func TestStatsService_Snapshot_ResumesWithinRetention(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	today := domain.StartOfDay(time.Now())
+	last := today.Add(-20 * domain.Day)
+	oldest := today.Add(-7 * domain.Day)
+
+	statsRepo.On("LastSnapshotDay", ctx).Return(&last, nil).Once()
+	statsRepo.On("SaveSnapshots", ctx, oldest, today).Return(nil).Once()
+	statsRepo.On("DeleteSnapshotsBefore", ctx, oldest).Return(int64(26), nil).Once()
+
+	saved, err := svc.Snapshot(ctx, 7*domain.Day)
+
+	require.NoError(t, err)
+	require.Equal(t, 7, saved)
+	statsRepo.AssertNotCalled(t, "FirstPRDay", ctx)
+}
+
+func TestStatsService_Snapshot_NothingToSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	statsRepo.On("LastSnapshotDay", ctx).Return(nil, nil).Once()
+	statsRepo.On("FirstPRDay", ctx).Return(nil, nil).Once()
+	statsRepo.On("DeleteSnapshotsBefore", ctx, mock.Anything).Return(int64(0), nil).Once()
+
+	saved, err := svc.Snapshot(ctx, 365*domain.Day)
+
+	require.NoError(t, err)
+	require.Zero(t, saved)
+}
+
+func TestStatsService_GetHistory_FillsSeries(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(statsRepo, teamRepo, mocks.NewAbsenceRepository(t))
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	// A period ending within a day covers the whole day.
+	filter := domain.StatsHistoryFilter{
+		From:     from.Add(9 * time.Hour),
+		To:       from.Add(2*domain.Day + time.Hour),
+		TeamName: "backend",
+	}
+	widened := domain.StatsHistoryFilter{
+		From:     from,
+		To:       from.Add(3 * domain.Day),
+		TeamName: "backend",
+	}
+
+	teamRepo.On("ExistsByName", ctx, "backend").Return(true, nil).Once()
+	statsRepo.
+		On("TeamSnapshots", ctx, widened).
+		Return([]domain.TeamSnapshot{
+			{Day: from, TeamName: "backend", Open: 2, Opened: 2, Assignments: 4},
+			{Day: from.Add(2 * domain.Day), TeamName: "backend", Open: 1, Merged: 1},
+		}, nil).
+		Once()
+	statsRepo.
+		On("UserSnapshots", ctx, widened).
+		Return([]domain.UserSnapshot{
+			{Day: from, UserID: "u1", Assignments: 2, OpenReviews: 2},
+			{Day: from.Add(domain.Day), UserID: "u1", OpenReviews: 2},
+			{Day: from.Add(2 * domain.Day), UserID: "u2", OpenReviews: 1},
+		}, nil).
+		Once()
+
+	history, err := svc.GetHistory(ctx, filter)
+
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{from, from.Add(domain.Day), from.Add(2 * domain.Day)}, history.Days)
+	require.Equal(t, []domain.TeamHistory{{
+		TeamName:    "backend",
+		Open:        []int{2, 0, 1},
+		Opened:      []int{2, 0, 0},
+		Merged:      []int{0, 0, 1},
+		Closed:      []int{0, 0, 0},
+		Assignments: []int{4, 0, 0},
+	}}, history.Teams)
+	require.Equal(t, []domain.UserHistory{
+		{UserID: "u1", Assignments: []int{2, 0, 0}, OpenReviews: []int{2, 2, 0}},
+		{UserID: "u2", Assignments: []int{0, 0, 0}, OpenReviews: []int{0, 0, 1}},
+	}, history.Users)
+}
+
+func TestStatsService_GetHistory_TooLong(t *testing.T) {
+	ctx := context.Background()
+
+	svc := service.NewStatsService(
+		mocks.NewStatsRepository(t),
+		mocks.NewTeamRepository(t),
+		mocks.NewAbsenceRepository(t),
+	)
+
+	to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	_, err := svc.GetHistory(ctx, domain.StatsHistoryFilter{
+		From: to.AddDate(-2, 0, 0),
+		To:   to,
+	})
+
+	require.ErrorIs(t, err, domain.ErrInvalidStatsFilter)
+}
@@ -3,8 +3,10 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
@@ -21,7 +23,10 @@ func TestStatsService_GetStats_Success(t *testing.T) {
 	ctx := context.Background()
 
 	statsRepo := mocks.NewStatsRepository(t)
-	svc := service.NewStatsService(statsRepo)
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(statsRepo, teamRepo, mocks.NewAbsenceRepository(t))
+
+	filter := domain.StatsFilter{}
 
 	expectedByUser := []domain.UserAssignmentStat{
 		{UserID: "u1", Assignments: 5},
@@ -31,53 +36,183 @@ func TestStatsService_GetStats_Success(t *testing.T) {
 		{PRID: "pr-101", Reviewers: 2},
 		{PRID: "pr-102", Reviewers: 1},
 	}
+	expectedByTeam := []domain.TeamStat{
+		{TeamName: "backend", PullRequests: 2, Open: 1, Merged: 1, Assignments: 3},
+	}
 
 	statsRepo.
-		On("CountAssignmentsByUser", ctx).
+		On("CountAssignmentsByUser", ctx, filter).
 		Return(expectedByUser, nil).
 		Once()
 
 	statsRepo.
-		On("CountReviewersByPR", ctx).
+		On("CountReviewersByPR", ctx, filter).
 		Return(expectedByPR, nil).
 		Once()
 
-	byUser, byPR, err := svc.GetStats(ctx)
+	statsRepo.
+		On("CountByTeam", ctx, filter).
+		Return(expectedByTeam, nil).
+		Once()
+
+	stats, err := svc.GetStats(ctx, filter)
 
 	require.NoError(t, err)
-	require.Equal(t, expectedByUser, byUser)
-	require.Equal(t, expectedByPR, byPR)
+	require.Equal(t, expectedByUser, stats.ByUser)
+	require.Equal(t, expectedByPR, stats.ByPR)
+	require.False(t, stats.ByPRTruncated)
+	require.Equal(t, expectedByTeam, stats.ByTeam)
+}
+
+func TestStatsService_GetStats_TruncatesByPR(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	filter := domain.StatsFilter{PRLimit: 2}
+
+	statsRepo.On("CountAssignmentsByUser", ctx, filter).Return([]domain.UserAssignmentStat{}, nil).Once()
+	statsRepo.
+		On("CountReviewersByPR", ctx, domain.StatsFilter{PRLimit: 3}).
+		Return([]domain.PRReviewerStat{
+			{PRID: "pr-101", Reviewers: 2},
+			{PRID: "pr-102", Reviewers: 2},
+			{PRID: "pr-103", Reviewers: 1},
+		}, nil).
+		Once()
+	statsRepo.On("CountByTeam", ctx, filter).Return([]domain.TeamStat{}, nil).Once()
+
+	stats, err := svc.GetStats(ctx, filter)
+
+	require.NoError(t, err)
+	require.Equal(t, []domain.PRReviewerStat{{PRID: "pr-101", Reviewers: 2}, {PRID: "pr-102", Reviewers: 2}}, stats.ByPR)
+	require.True(t, stats.ByPRTruncated)
+}
+
+func TestStatsService_GetStats_CapsPRLimit(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	filter := domain.StatsFilter{PRLimit: domain.MaxStatsPRLimit * 10}
+
+	statsRepo.On("CountAssignmentsByUser", ctx, filter).Return([]domain.UserAssignmentStat{}, nil).Once()
+	statsRepo.
+		On("CountReviewersByPR", ctx, domain.StatsFilter{PRLimit: domain.MaxStatsPRLimit + 1}).
+		Return([]domain.PRReviewerStat{}, nil).
+		Once()
+	statsRepo.On("CountByTeam", ctx, filter).Return([]domain.TeamStat{}, nil).Once()
+
+	_, err := svc.GetStats(ctx, filter)
+
+	require.NoError(t, err)
+}
+
+func TestStatsService_GetStats_WithFilter(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(statsRepo, teamRepo, mocks.NewAbsenceRepository(t))
+
+	from := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	filter := domain.StatsFilter{
+		From:     &from,
+		To:       &to,
+		TeamName: "backend",
+		Status:   domain.PRStatusMerged,
+	}
+
+	teamRepo.
+		On("ExistsByName", ctx, "backend").
+		Return(true, nil).
+		Once()
+
+	statsRepo.On("CountAssignmentsByUser", ctx, filter).Return([]domain.UserAssignmentStat{}, nil).Once()
+	statsRepo.On("CountReviewersByPR", ctx, filter).Return([]domain.PRReviewerStat{}, nil).Once()
+	statsRepo.On("CountByTeam", ctx, filter).Return([]domain.TeamStat{}, nil).Once()
+
+	_, err := svc.GetStats(ctx, filter)
+
+	require.NoError(t, err)
+}
+
+func TestStatsService_GetStats_InvalidFilter(t *testing.T) {
+	ctx := context.Background()
+
+	from := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter domain.StatsFilter
+	}{
+		{"from after to", domain.StatsFilter{From: &from, To: &to}},
+		{"from equals to", domain.StatsFilter{From: &from, To: &from}},
+		{"unknown status", domain.StatsFilter{Status: "DRAFT"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewStatsService(mocks.NewStatsRepository(t), mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+			stats, err := svc.GetStats(ctx, tt.filter)
+
+			require.ErrorIs(t, err, domain.ErrInvalidStatsFilter)
+			require.Nil(t, stats)
+		})
+	}
+}
+
+func TestStatsService_GetStats_TeamNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(statsRepo, teamRepo, mocks.NewAbsenceRepository(t))
+
+	teamRepo.
+		On("ExistsByName", ctx, "ghost").
+		Return(false, nil).
+		Once()
+
+	stats, err := svc.GetStats(ctx, domain.StatsFilter{TeamName: "ghost"})
+
+	require.ErrorIs(t, err, domain.ErrTeamNotFound)
+	require.Nil(t, stats)
 }
 
 func TestStatsService_GetStats_CountAssignmentsError(t *testing.T) {
 	ctx := context.Background()
 
 	statsRepo := mocks.NewStatsRepository(t)
-	svc := service.NewStatsService(statsRepo)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
 
 	expectedErr := assert.AnError
 
 	statsRepo.
-		On("CountAssignmentsByUser", ctx).
+		On("CountAssignmentsByUser", ctx, domain.StatsFilter{}).
 		Return(nil, expectedErr).
 		Once()
 
-	byUser, byPR, err := svc.GetStats(ctx)
+	stats, err := svc.GetStats(ctx, domain.StatsFilter{})
 
 	require.Error(t, err)
 	require.Same(t, expectedErr, err)
-	require.Nil(t, byUser)
-	require.Nil(t, byPR)
+	require.Nil(t, stats)
 }
 
 func TestStatsService_GetStats_CountReviewersError(t *testing.T) {
 	ctx := context.Background()
 
 	statsRepo := mocks.NewStatsRepository(t)
-	svc := service.NewStatsService(statsRepo)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
 
 	statsRepo.
-		On("CountAssignmentsByUser", ctx).
+		On("CountAssignmentsByUser", ctx, domain.StatsFilter{}).
 		Return([]domain.UserAssignmentStat{
 			{UserID: "u1", Assignments: 5},
 		}, nil).
@@ -86,14 +221,138 @@ func TestStatsService_GetStats_CountReviewersError(t *testing.T) {
 	expectedErr := assert.AnError
 
 	statsRepo.
-		On("CountReviewersByPR", ctx).
+		On("CountReviewersByPR", ctx, domain.StatsFilter{}).
 		Return(nil, expectedErr).
 		Once()
 
-	byUser, byPR, err := svc.GetStats(ctx)
+	stats, err := svc.GetStats(ctx, domain.StatsFilter{})
 
 	require.Error(t, err)
-	require.Nil(t, byUser)
-	require.Nil(t, byPR)
+	require.Nil(t, stats)
 	require.Same(t, expectedErr, err)
 }
+
+func TestStatsService_GetStats_CountByTeamError(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	svc := service.NewStatsService(statsRepo, mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	statsRepo.On("CountAssignmentsByUser", ctx, domain.StatsFilter{}).Return([]domain.UserAssignmentStat{}, nil).Once()
+	statsRepo.On("CountReviewersByPR", ctx, domain.StatsFilter{}).Return([]domain.PRReviewerStat{}, nil).Once()
+	statsRepo.On("CountByTeam", ctx, domain.StatsFilter{}).Return(nil, assert.AnError).Once()
+
+	stats, err := svc.GetStats(ctx, domain.StatsFilter{})
+
+	require.ErrorIs(t, err, assert.AnError)
+	require.Nil(t, stats)
+}
+
+func TestStatsService_GetCycleTime(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(statsRepo, teamRepo, mocks.NewAbsenceRepository(t))
+
+	from := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	filter := domain.CycleTimeFilter{From: &from, To: &to}
+
+	byTeam := []domain.TeamCycleTime{
+		{
+			TeamName:    "payments",
+			TimeToMerge: domain.DurationStat{Count: 3, Median: 5 * time.Hour, P90: 20 * time.Hour},
+		},
+	}
+	byReviewer := []domain.ReviewerCycleTime{
+		{UserID: "u1", TimeToApproval: domain.DurationStat{Count: 1, Median: time.Hour, P90: time.Hour}},
+	}
+
+	statsRepo.On("CycleTimeByTeam", ctx, filter).Return(byTeam, nil).Once()
+	statsRepo.On("CycleTimeByReviewer", ctx, filter).Return(byReviewer, nil).Once()
+	// The open pull requests are counted at the end of a past period.
+	statsRepo.
+		On("OpenAgeByTeam", ctx, "", to, domain.OpenAgeBounds).
+		Return(map[string][]int{
+			"payments": {0, 1, 0, 0, 0, 2},
+			"backend":  {3, 0, 0, 0, 0, 0},
+		}, nil).
+		Once()
+
+	ct, err := svc.GetCycleTime(ctx, filter)
+
+	require.NoError(t, err)
+	require.Equal(t, to, ct.AsOf)
+	require.Equal(t, byReviewer, ct.ByReviewer)
+
+	// Teams with open pull requests only are reported too.
+	require.Len(t, ct.ByTeam, 2)
+	require.Equal(t, "backend", ct.ByTeam[0].TeamName)
+	require.Zero(t, ct.ByTeam[0].TimeToMerge)
+	require.Equal(t, 3, ct.ByTeam[0].OpenAge[0].Count)
+
+	payments := ct.ByTeam[1]
+	require.Equal(t, byTeam[0].TimeToMerge, payments.TimeToMerge)
+	require.Equal(t, []domain.AgeBucket{
+		{From: 0, To: 24 * time.Hour},
+		{From: 24 * time.Hour, To: 72 * time.Hour, Count: 1},
+		{From: 72 * time.Hour, To: 168 * time.Hour},
+		{From: 168 * time.Hour, To: 336 * time.Hour},
+		{From: 336 * time.Hour, To: 720 * time.Hour},
+		{From: 720 * time.Hour, Count: 2},
+	}, payments.OpenAge)
+}
+
+func TestStatsService_GetCycleTime_CurrentPeriod(t *testing.T) {
+	ctx := context.Background()
+
+	statsRepo := mocks.NewStatsRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(statsRepo, teamRepo, mocks.NewAbsenceRepository(t))
+
+	filter := domain.CycleTimeFilter{TeamName: "payments"}
+
+	teamRepo.On("ExistsByName", ctx, "payments").Return(true, nil).Once()
+	statsRepo.On("CycleTimeByTeam", ctx, filter).Return([]domain.TeamCycleTime{}, nil).Once()
+	statsRepo.On("CycleTimeByReviewer", ctx, filter).Return([]domain.ReviewerCycleTime{}, nil).Once()
+
+	before := time.Now().UTC()
+	statsRepo.
+		On("OpenAgeByTeam", ctx, "payments", mock.MatchedBy(func(asOf time.Time) bool {
+			return !asOf.Before(before) && !asOf.After(time.Now().UTC())
+		}), domain.OpenAgeBounds).
+		Return(map[string][]int{}, nil).
+		Once()
+
+	ct, err := svc.GetCycleTime(ctx, filter)
+
+	require.NoError(t, err)
+	require.Empty(t, ct.ByTeam)
+}
+
+func TestStatsService_GetCycleTime_InvalidFilter(t *testing.T) {
+	ctx := context.Background()
+
+	svc := service.NewStatsService(mocks.NewStatsRepository(t), mocks.NewTeamRepository(t), mocks.NewAbsenceRepository(t))
+
+	from := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+	ct, err := svc.GetCycleTime(ctx, domain.CycleTimeFilter{From: &from, To: &from})
+
+	require.ErrorIs(t, err, domain.ErrInvalidStatsFilter)
+	require.Nil(t, ct)
+}
+
+func TestStatsService_GetCycleTime_TeamNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewStatsService(mocks.NewStatsRepository(t), teamRepo, mocks.NewAbsenceRepository(t))
+
+	teamRepo.On("ExistsByName", ctx, "ghost").Return(false, nil).Once()
+
+	ct, err := svc.GetCycleTime(ctx, domain.CycleTimeFilter{TeamName: "ghost"})
+
+	require.ErrorIs(t, err, domain.ErrTeamNotFound)
+	require.Nil(t, ct)
+}
@@ -6,18 +6,30 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
 )
 
 type TeamService struct {
-	teamRepo repository.TeamRepository
+	txManager repository.TxManager
+	teamRepo  repository.TeamRepository
+	userRepo  repository.UserRepository
+	audit     *AuditService
 }
 
-func NewTeamService(teamRepo repository.TeamRepository) *TeamService {
+func NewTeamService(
+	txManager repository.TxManager,
+	teamRepo repository.TeamRepository,
+	userRepo repository.UserRepository,
+	audit *AuditService,
+) *TeamService {
 	return &TeamService{
-		teamRepo: teamRepo,
+		txManager: txManager,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		audit:     audit,
 	}
 }
 
@@ -31,28 +43,33 @@ func (s *TeamService) CreateTeam(ctx context.Context, teamName string) (*domain.
 		return nil, fmt.Errorf("team name is required")
 	}
 
-	exists, err := s.teamRepo.ExistsByName(ctx, teamName)
-	if err != nil {
-		log.Error("failed to check if team exists",
-			slog.String("teamName", teamName),
-			slog.Any("err", err),
-		)
-		return nil, err
-	}
-	if exists {
-		log.Warn("team already exists", slog.String("teamName", teamName))
-		return nil, domain.ErrTeamExists
-	}
+	team := domain.NewTeam(teamName)
 
-	team := &domain.Team{
-		Name: teamName,
-	}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		exists, err := s.teamRepo.ExistsByName(ctx, teamName)
+		if err != nil {
+			log.Error("failed to check if team exists",
+				slog.String("teamName", teamName),
+				slog.Any("err", err),
+			)
+			return err
+		}
+		if exists {
+			log.Warn("team already exists", slog.String("teamName", teamName))
+			return domain.ErrTeamExists
+		}
 
-	if err := s.teamRepo.Create(ctx, team); err != nil {
-		log.Error("failed to create team",
-			slog.String("teamName", teamName),
-			slog.Any("err", err),
-		)
+		if err := s.teamRepo.Create(ctx, team); err != nil {
+			log.Error("failed to create team",
+				slog.String("teamName", teamName),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		return s.audit.Record(ctx, domain.AuditTeamCreated, domain.AuditEntityTeam, team.Name, nil, auditTeam(team))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -88,3 +105,139 @@ func (s *TeamService) GetTeam(ctx context.Context, name string) (*domain.Team, e
 
 	return team, nil
 }
+
+// UpdateSettings applies a partial update to team settings. Once a team has
+// leads, only they are allowed to change the settings; requests without an
+// identified actor are accepted until authentication is in place.
+func (s *TeamService) UpdateSettings(
+	ctx context.Context,
+	name string,
+	patch domain.TeamSettingsPatch,
+) (*domain.Team, error) {
+	log := logger.L()
+
+	log.Info("updating team settings", slog.String("teamName", name))
+
+	if name == "" {
+		log.Warn("empty team name provided")
+		return nil, fmt.Errorf("%w: empty team name", domain.ErrInvalidTeamSettings)
+	}
+
+	var team *domain.Team
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		team, err = s.teamRepo.GetByName(ctx, name)
+		if err != nil {
+			if errors.Is(err, domain.ErrTeamNotFound) {
+				log.Warn("team not found", slog.String("teamName", name))
+				return err
+			}
+			log.Error("failed to fetch team",
+				slog.String("teamName", name),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		before := auditTeam(team)
+
+		if actorID, ok := actor.FromContext(ctx); ok && len(team.Leads) > 0 && !team.IsLead(actorID) {
+			log.Warn("team settings change is not allowed",
+				slog.String("teamName", name),
+				slog.String("actorID", actorID),
+			)
+			return domain.ErrForbidden
+		}
+
+		if patch.Description != nil {
+			team.Description = *patch.Description
+		}
+		if patch.SlackChannelID != nil {
+			team.SlackChannelID = *patch.SlackChannelID
+		}
+		if patch.ReviewersCount != nil {
+			if *patch.ReviewersCount < 0 || *patch.ReviewersCount > domain.MaxReviewersCount {
+				log.Warn("invalid reviewers count",
+					slog.String("teamName", name),
+					slog.Int("reviewersCount", *patch.ReviewersCount),
+				)
+				return fmt.Errorf("%w: reviewers count must be between 0 and %d", domain.ErrInvalidTeamSettings, domain.MaxReviewersCount)
+			}
+			team.ReviewerPolicy.ReviewersCount = *patch.ReviewersCount
+		}
+		if patch.ExcludeLeads != nil {
+			team.ReviewerPolicy.ExcludeLeads = *patch.ExcludeLeads
+		}
+		if patch.SLAResponseHours != nil || patch.SLAEscalationHours != nil || patch.SLAEscalation != nil {
+			if patch.SLAResponseHours != nil {
+				team.ReviewSLA.ResponseHours = *patch.SLAResponseHours
+			}
+			if patch.SLAEscalationHours != nil {
+				team.ReviewSLA.EscalationHours = *patch.SLAEscalationHours
+			}
+			if patch.SLAEscalation != nil {
+				team.ReviewSLA.Escalation = *patch.SLAEscalation
+			}
+			if err := team.ReviewSLA.Validate(); err != nil {
+				log.Warn("invalid review SLA",
+					slog.String("teamName", name),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+		if patch.Leads != nil {
+			if err := s.validateLeads(ctx, name, *patch.Leads); err != nil {
+				return err
+			}
+			team.Leads = *patch.Leads
+		}
+
+		if err := s.teamRepo.UpdateSettings(ctx, team); err != nil {
+			log.Error("failed to update team settings",
+				slog.String("teamName", name),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		return s.audit.Record(ctx, domain.AuditTeamSettingsUpdated, domain.AuditEntityTeam, name, before, auditTeam(team))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team settings successfully updated", slog.String("teamName", name))
+
+	return team, nil
+}
+
+func (s *TeamService) validateLeads(ctx context.Context, teamName string, leads []string) error {
+	log := logger.L()
+
+	members, err := s.userRepo.ListByTeam(ctx, teamName)
+	if err != nil {
+		log.Error("failed to list team members",
+			slog.String("teamName", teamName),
+			slog.Any("err", err),
+		)
+		return err
+	}
+
+	memberSet := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		memberSet[m.ID] = struct{}{}
+	}
+
+	for _, lead := range leads {
+		if _, ok := memberSet[lead]; !ok {
+			log.Warn("team lead is not a team member",
+				slog.String("teamName", teamName),
+				slog.String("userID", lead),
+			)
+			return domain.ErrNotTeamMember
+		}
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/actor"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
@@ -19,7 +20,7 @@ func init() {
 
 func TestTeamService_CreateTeam_Success(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	teamRepo.
 		On("ExistsByName", mock.Anything, "backend").
@@ -40,9 +41,40 @@ func TestTeamService_CreateTeam_Success(t *testing.T) {
 	teamRepo.AssertExpectations(t)
 }
 
+func TestTeamService_CreateTeam_RecordsAudit(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	auditRepo := mocks.NewAuditRepository(t)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, service.NewAuditService(auditRepo))
+
+	teamRepo.
+		On("ExistsByName", mock.Anything, "backend").
+		Return(false, nil).
+		Once()
+
+	teamRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*domain.Team")).
+		Return(nil).
+		Once()
+
+	auditRepo.
+		On("Append", mock.Anything, mock.MatchedBy(func(e *domain.AuditEvent) bool {
+			return e.Action == domain.AuditTeamCreated &&
+				e.EntityType == domain.AuditEntityTeam &&
+				e.EntityID == "backend" &&
+				e.Before == nil && len(e.After) > 0
+		})).
+		Return(nil).
+		Once()
+
+	team, err := svc.CreateTeam(context.Background(), "backend")
+
+	require.NoError(t, err)
+	require.Equal(t, "backend", team.Name)
+}
+
 func TestTeamService_CreateTeam_EmptyName(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	team, err := svc.CreateTeam(context.Background(), "")
 
@@ -52,7 +84,7 @@ func TestTeamService_CreateTeam_EmptyName(t *testing.T) {
 
 func TestTeamService_CreateTeam_ExistsErr(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	expectedErr := errors.New("db failure")
 
@@ -72,7 +104,7 @@ func TestTeamService_CreateTeam_ExistsErr(t *testing.T) {
 
 func TestTeamService_CreateTeam_AlreadyExists(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	teamRepo.
 		On("ExistsByName", mock.Anything, "mobile").
@@ -90,7 +122,7 @@ func TestTeamService_CreateTeam_AlreadyExists(t *testing.T) {
 
 func TestTeamService_CreateTeam_CreateErr(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	expectedErr := errors.New("insert failed")
 
@@ -115,7 +147,7 @@ func TestTeamService_CreateTeam_CreateErr(t *testing.T) {
 
 func TestTeamService_GetTeam_Success(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	expected := &domain.Team{Name: "backend"}
 
@@ -134,7 +166,7 @@ func TestTeamService_GetTeam_Success(t *testing.T) {
 
 func TestTeamService_GetTeam_EmptyName(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	team, err := svc.GetTeam(context.Background(), "")
 
@@ -144,7 +176,7 @@ func TestTeamService_GetTeam_EmptyName(t *testing.T) {
 
 func TestTeamService_GetTeam_NotFound(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	teamRepo.
 		On("GetByName", mock.Anything, "mobile").
@@ -162,7 +194,7 @@ func TestTeamService_GetTeam_NotFound(t *testing.T) {
 
 func TestTeamService_GetTeam_RepoErr(t *testing.T) {
 	teamRepo := mocks.NewTeamRepository(t)
-	svc := service.NewTeamService(teamRepo)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
 
 	expectedErr := errors.New("db error")
 
@@ -179,3 +211,159 @@ func TestTeamService_GetTeam_RepoErr(t *testing.T) {
 
 	teamRepo.AssertExpectations(t)
 }
+
+func TestTeamService_UpdateSettings_Success(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, userRepo, nil)
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	userRepo.
+		On("ListByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u1"}, {ID: "u2"}}, nil).
+		Once()
+
+	teamRepo.
+		On("UpdateSettings", mock.Anything, mock.MatchedBy(func(team *domain.Team) bool {
+			return team.Description == "Core API" &&
+				team.ReviewerPolicy.ReviewersCount == 1 &&
+				team.ReviewerPolicy.ExcludeLeads &&
+				len(team.Leads) == 1 && team.Leads[0] == "u1"
+		})).
+		Return(nil).
+		Once()
+
+	description := "Core API"
+	leads := []string{"u1"}
+	count := 1
+	excludeLeads := true
+
+	team, err := svc.UpdateSettings(context.Background(), "backend", domain.TeamSettingsPatch{
+		Description:    &description,
+		Leads:          &leads,
+		ReviewersCount: &count,
+		ExcludeLeads:   &excludeLeads,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "Core API", team.Description)
+	require.Equal(t, []string{"u1"}, team.Leads)
+}
+
+func TestTeamService_UpdateSettings_NotLead(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
+
+	existing := domain.NewTeam("backend")
+	existing.Leads = []string{"u1"}
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(existing, nil).
+		Once()
+
+	ctx := actor.WithID(context.Background(), "u2")
+	description := "hijacked"
+
+	team, err := svc.UpdateSettings(ctx, "backend", domain.TeamSettingsPatch{
+		Description: &description,
+	})
+
+	require.ErrorIs(t, err, domain.ErrForbidden)
+	require.Nil(t, team)
+}
+
+func TestTeamService_UpdateSettings_LeadNotMember(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, userRepo, nil)
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	userRepo.
+		On("ListByTeam", mock.Anything, "backend").
+		Return([]domain.User{{ID: "u1"}}, nil).
+		Once()
+
+	leads := []string{"u9"}
+
+	team, err := svc.UpdateSettings(context.Background(), "backend", domain.TeamSettingsPatch{
+		Leads: &leads,
+	})
+
+	require.ErrorIs(t, err, domain.ErrNotTeamMember)
+	require.Nil(t, team)
+}
+
+func TestTeamService_UpdateSettings_InvalidReviewersCount(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	count := domain.MaxReviewersCount + 1
+
+	team, err := svc.UpdateSettings(context.Background(), "backend", domain.TeamSettingsPatch{
+		ReviewersCount: &count,
+	})
+
+	require.ErrorIs(t, err, domain.ErrInvalidTeamSettings)
+	require.Nil(t, team)
+}
+
+func TestTeamService_UpdateSettings_ReviewSLA(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	teamRepo.
+		On("UpdateSettings", mock.Anything, mock.MatchedBy(func(team *domain.Team) bool {
+			return team.ReviewSLA == domain.ReviewSLA{ResponseHours: 8, EscalationHours: 48, Escalation: domain.SLAReassign}
+		})).
+		Return(nil).
+		Once()
+
+	hours := 8
+	escalation := domain.SLAReassign
+
+	team, err := svc.UpdateSettings(context.Background(), "backend", domain.TeamSettingsPatch{
+		SLAResponseHours: &hours,
+		SLAEscalation:    &escalation,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 8, team.ReviewSLA.ResponseHours)
+}
+
+func TestTeamService_UpdateSettings_InvalidReviewSLA(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	svc := service.NewTeamService(passthroughTx(t), teamRepo, nil, nil)
+
+	teamRepo.
+		On("GetByName", mock.Anything, "backend").
+		Return(domain.NewTeam("backend"), nil).
+		Once()
+
+	// Escalating before the response time is up makes no sense.
+	hours := 72
+
+	_, err := svc.UpdateSettings(context.Background(), "backend", domain.TeamSettingsPatch{
+		SLAResponseHours: &hours,
+	})
+
+	require.ErrorIs(t, err, domain.ErrInvalidSLA)
+}
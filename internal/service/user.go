@@ -8,84 +8,186 @@ import (
 
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/cursor"
 	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
 )
 
 type UserService struct {
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
+	txManager repository.TxManager
+	userRepo  repository.UserRepository
+	teamRepo  repository.TeamRepository
+	audit     *AuditService
+	outbox    *OutboxService
 }
 
 func NewUserService(
+	txManager repository.TxManager,
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
+	audit *AuditService,
+	outbox *OutboxService,
 ) *UserService {
 	return &UserService{
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		txManager: txManager,
+		userRepo:  userRepo,
+		teamRepo:  teamRepo,
+		audit:     audit,
+		outbox:    outbox,
 	}
 }
 
-func (s *UserService) UpsertUser(
-	ctx context.Context,
-	userID string,
-	username string,
-	teamName string,
-	isActive bool,
-) (*domain.User, error) {
+func (s *UserService) UpsertUser(ctx context.Context, in domain.UserUpsert) (*domain.User, error) {
 	log := logger.L()
 
 	log.Info("upserting user",
-		slog.String("userID", userID),
-		slog.String("username", username),
-		slog.String("teamName", teamName),
-		slog.Bool("isActive", isActive),
+		slog.String("userID", in.ID),
+		slog.String("username", in.Username),
+		slog.String("teamName", in.TeamName),
+		slog.Bool("isActive", in.IsActive),
+		slog.Bool("isPrimary", in.IsPrimary),
 	)
 
-	if userID == "" || username == "" || teamName == "" {
+	if in.ID == "" || in.Username == "" || in.TeamName == "" {
 		log.Warn("invalid input: missing required fields",
-			slog.String("userID", userID),
-			slog.String("username", username),
-			slog.String("teamName", teamName),
+			slog.String("userID", in.ID),
+			slog.String("username", in.Username),
+			slog.String("teamName", in.TeamName),
 		)
 		return nil, fmt.Errorf("invalid input: missing required fields")
 	}
 
-	exists, err := s.teamRepo.ExistsByName(ctx, teamName)
+	for provider := range in.ExternalAccounts {
+		if !domain.IsKnownProvider(provider) {
+			log.Warn("unknown external account provider",
+				slog.String("userID", in.ID),
+				slog.String("provider", provider),
+			)
+			return nil, fmt.Errorf("invalid input: unknown provider %q", provider)
+		}
+	}
+
+	var user *domain.User
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		exists, err := s.teamRepo.ExistsByName(ctx, in.TeamName)
+		if err != nil {
+			log.Error("failed to check if team exists",
+				slog.String("teamName", in.TeamName),
+				slog.Any("err", err),
+			)
+			return err
+		}
+		if !exists {
+			log.Warn("team does not exist",
+				slog.String("teamName", in.TeamName),
+			)
+			return domain.ErrTeamNotFound
+		}
+
+		before, err := s.userRepo.GetByID(ctx, in.ID)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			log.Error("failed to fetch user",
+				slog.String("userID", in.ID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		if err := s.userRepo.Upsert(ctx, &in); err != nil {
+			log.Error("failed to upsert user",
+				slog.String("userID", in.ID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		if err := s.userRepo.AddMembership(ctx, in.ID, in.TeamName, in.IsPrimary); err != nil {
+			log.Error("failed to add user to team",
+				slog.String("userID", in.ID),
+				slog.String("teamName", in.TeamName),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		if len(in.ExternalAccounts) > 0 {
+			if err := s.userRepo.SetExternalAccounts(ctx, in.ID, in.ExternalAccounts); err != nil {
+				if errors.Is(err, domain.ErrExternalAccountTaken) {
+					log.Warn("external account is linked to another user",
+						slog.String("userID", in.ID),
+					)
+					return err
+				}
+				log.Error("failed to set external accounts",
+					slog.String("userID", in.ID),
+					slog.Any("err", err),
+				)
+				return err
+			}
+		}
+
+		user, err = s.userRepo.GetByID(ctx, in.ID)
+		if err != nil {
+			log.Error("failed to fetch upserted user",
+				slog.String("userID", in.ID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		if err := s.audit.Record(ctx, domain.AuditUserUpserted, domain.AuditEntityUser, in.ID, auditUser(before), auditUser(user)); err != nil {
+			return err
+		}
+
+		return s.outbox.Publish(ctx, domain.EventUserUpserted, userPayload(user))
+	})
 	if err != nil {
-		log.Error("failed to check if team exists",
-			slog.String("teamName", teamName),
-			slog.Any("err", err),
-		)
 		return nil, err
 	}
-	if !exists {
-		log.Warn("team does not exist",
-			slog.String("teamName", teamName),
-		)
-		return nil, domain.ErrTeamNotFound
-	}
 
-	user := &domain.User{
-		ID:       userID,
-		Username: username,
-		TeamName: teamName,
-		IsActive: isActive,
+	log.Info("user successfully upserted",
+		slog.String("userID", in.ID),
+	)
+
+	return user, nil
+}
+
+// ResolveByExternalLogin finds the user linked to a Git hosting account.
+func (s *UserService) ResolveByExternalLogin(ctx context.Context, provider string, login string) (*domain.User, error) {
+	log := logger.L()
+
+	log.Info("resolving user by external login",
+		slog.String("provider", provider),
+		slog.String("login", login),
+	)
+
+	if provider == "" || login == "" {
+		log.Warn("invalid input: empty provider or login")
+		return nil, fmt.Errorf("invalid input: empty provider or login")
 	}
 
-	if err := s.userRepo.Upsert(ctx, user); err != nil {
-		log.Error("failed to upsert user",
-			slog.String("userID", userID),
+	u, err := s.userRepo.GetByExternalLogin(ctx, provider, login)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Warn("no user linked to external login",
+				slog.String("provider", provider),
+				slog.String("login", login),
+			)
+			return nil, err
+		}
+		log.Error("failed to resolve user by external login",
+			slog.String("provider", provider),
+			slog.String("login", login),
 			slog.Any("err", err),
 		)
 		return nil, err
 	}
 
-	log.Info("user successfully upserted",
-		slog.String("userID", userID),
+	log.Info("user resolved by external login",
+		slog.String("provider", provider),
+		slog.String("userID", u.ID),
 	)
 
-	return user, nil
+	return u, nil
 }
 
 func (s *UserService) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
@@ -131,16 +233,46 @@ func (s *UserService) SetUserActive(ctx context.Context, userID string, isActive
 		return nil, fmt.Errorf("empty user id")
 	}
 
-	u, err := s.userRepo.SetIsActive(ctx, userID, isActive)
-	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			log.Warn("user not found", slog.String("userID", userID))
-			return nil, err
+	var u *domain.User
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				log.Warn("user not found", slog.String("userID", userID))
+				return err
+			}
+			log.Error("failed to fetch user",
+				slog.String("userID", userID),
+				slog.Any("err", err),
+			)
+			return err
 		}
-		log.Error("failed to update user active status",
-			slog.String("userID", userID),
-			slog.Any("err", err),
-		)
+
+		u, err = s.userRepo.SetIsActive(ctx, userID, isActive)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				log.Warn("user not found", slog.String("userID", userID))
+				return err
+			}
+			log.Error("failed to update user active status",
+				slog.String("userID", userID),
+				slog.Any("err", err),
+			)
+			return err
+		}
+
+		action, event := domain.AuditUserDeactivated, domain.EventUserDeactivated
+		if isActive {
+			action, event = domain.AuditUserActivated, domain.EventUserActivated
+		}
+
+		if err := s.audit.Record(ctx, action, domain.AuditEntityUser, userID, auditUser(before), auditUser(u)); err != nil {
+			return err
+		}
+
+		return s.outbox.Publish(ctx, event, userPayload(u))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -178,3 +310,65 @@ func (s *UserService) ListUsersByTeam(ctx context.Context, teamName string) ([]d
 
 	return users, nil
 }
+
+// ListUsers returns a page of the user directory. The cursor is the opaque
+// token returned as NextCursor by the previous page.
+func (s *UserService) ListUsers(
+	ctx context.Context,
+	filter domain.UserFilter,
+	pageCursor string,
+) (*domain.UserPage, error) {
+	log := logger.L()
+
+	log.Info("listing users",
+		slog.String("teamName", filter.TeamName),
+		slog.String("usernamePrefix", filter.UsernamePrefix),
+		slog.Int("limit", filter.Limit),
+	)
+
+	afterID, err := cursor.Decode(pageCursor)
+	if err != nil {
+		log.Warn("invalid users cursor", slog.String("cursor", pageCursor))
+		return nil, domain.ErrInvalidCursor
+	}
+	filter.AfterID = afterID
+
+	if filter.Limit <= 0 {
+		filter.Limit = domain.DefaultUsersPageSize
+	}
+	if filter.Limit > domain.MaxUsersPageSize {
+		filter.Limit = domain.MaxUsersPageSize
+	}
+
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	items, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		log.Error("failed to list users", slog.Any("err", err))
+		return nil, err
+	}
+
+	total, err := s.userRepo.Count(ctx, filter)
+	if err != nil {
+		log.Error("failed to count users", slog.Any("err", err))
+		return nil, err
+	}
+
+	page := &domain.UserPage{
+		Items: items,
+		Total: total,
+	}
+
+	if len(items) > pageSize {
+		page.Items = items[:pageSize]
+		page.NextCursor = cursor.Encode(page.Items[pageSize-1].ID)
+	}
+
+	log.Info("users successfully listed",
+		slog.Int("count", len(page.Items)),
+		slog.Int("total", total),
+	)
+
+	return page, nil
+}
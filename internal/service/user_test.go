@@ -23,17 +23,37 @@ func TestUserService_UpsertUser_Success(t *testing.T) {
 	userRepo := mocks.NewUserRepository(t)
 	teamRepo := mocks.NewTeamRepository(t)
 
-	svc := service.NewUserService(userRepo, teamRepo)
+	svc := service.NewUserService(passthroughTx(t), userRepo, teamRepo, nil, nil)
 
 	teamRepo.
 		On("ExistsByName", ctx, "backend").
 		Return(true, nil)
 
 	userRepo.
-		On("Upsert", ctx, mock.AnythingOfType("*domain.User")).
+		On("Upsert", ctx, mock.AnythingOfType("*domain.UserUpsert")).
 		Return(nil)
 
-	user, err := svc.UpsertUser(ctx, "u1", "Alice", "backend", true)
+	userRepo.
+		On("AddMembership", ctx, "u1", "backend", false).
+		Return(nil)
+
+	userRepo.
+		On("GetByID", ctx, "u1").
+		Return(&domain.User{
+			ID:       "u1",
+			Username: "Alice",
+			TeamName: "backend",
+			Teams:    []string{"backend"},
+			IsActive: true,
+		}, nil)
+
+	user, err := svc.UpsertUser(ctx, domain.UserUpsert{
+		ID:        "u1",
+		Username:  "Alice",
+		TeamName:  "backend",
+		IsActive:  true,
+		IsPrimary: false,
+	})
 
 	require.NoError(t, err)
 	require.Equal(t, "u1", user.ID)
@@ -49,14 +69,20 @@ func TestUserService_UpsertUser_TeamNotFound(t *testing.T) {
 	userRepo := mocks.NewUserRepository(t)
 	teamRepo := mocks.NewTeamRepository(t)
 
-	svc := service.NewUserService(userRepo, teamRepo)
+	svc := service.NewUserService(passthroughTx(t), userRepo, teamRepo, nil, nil)
 
 	teamRepo.
 		On("ExistsByName", mock.Anything, "mobile").
 		Return(false, nil).
 		Once()
 
-	user, err := svc.UpsertUser(context.Background(), "u2", "Bob", "mobile", true)
+	user, err := svc.UpsertUser(context.Background(), domain.UserUpsert{
+		ID:        "u2",
+		Username:  "Bob",
+		TeamName:  "mobile",
+		IsActive:  true,
+		IsPrimary: false,
+	})
 
 	require.Error(t, err)
 	require.Nil(t, user)
@@ -70,7 +96,7 @@ func TestUserService_UpsertUser_ExistsErr(t *testing.T) {
 	userRepo := mocks.NewUserRepository(t)
 	teamRepo := mocks.NewTeamRepository(t)
 
-	svc := service.NewUserService(userRepo, teamRepo)
+	svc := service.NewUserService(passthroughTx(t), userRepo, teamRepo, nil, nil)
 
 	expectedErr := errors.New("db failure")
 
@@ -79,7 +105,13 @@ func TestUserService_UpsertUser_ExistsErr(t *testing.T) {
 		Return(false, expectedErr).
 		Once()
 
-	user, err := svc.UpsertUser(context.Background(), "u1", "Alice", "backend", true)
+	user, err := svc.UpsertUser(context.Background(), domain.UserUpsert{
+		ID:        "u1",
+		Username:  "Alice",
+		TeamName:  "backend",
+		IsActive:  true,
+		IsPrimary: false,
+	})
 
 	require.Error(t, err)
 	require.Nil(t, user)
@@ -88,3 +120,205 @@ func TestUserService_UpsertUser_ExistsErr(t *testing.T) {
 	teamRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 }
+
+func TestUserService_UpsertUser_SecondTeam(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.NewUserService(passthroughTx(t), userRepo, teamRepo, nil, nil)
+
+	teamRepo.
+		On("ExistsByName", ctx, "devops").
+		Return(true, nil).
+		Once()
+
+	userRepo.
+		On("Upsert", ctx, mock.MatchedBy(func(u *domain.UserUpsert) bool {
+			return u.ID == "u1" && u.TeamName == "devops"
+		})).
+		Return(nil).
+		Once()
+
+	userRepo.
+		On("AddMembership", ctx, "u1", "devops", false).
+		Return(nil).
+		Once()
+
+	userRepo.
+		On("GetByID", ctx, "u1").
+		Return(&domain.User{
+			ID:       "u1",
+			Username: "Alice",
+			TeamName: "backend",
+			Teams:    []string{"backend", "devops"},
+			IsActive: true,
+		}, nil).
+		Twice()
+
+	user, err := svc.UpsertUser(ctx, domain.UserUpsert{
+		ID:        "u1",
+		Username:  "Alice",
+		TeamName:  "devops",
+		IsActive:  true,
+		IsPrimary: false,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "backend", user.TeamName)
+	require.Equal(t, []string{"backend", "devops"}, user.Teams)
+}
+
+func TestUserService_ListUsers_NextCursor(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := mocks.NewUserRepository(t)
+	svc := service.NewUserService(passthroughTx(t), userRepo, nil, nil, nil)
+
+	isActive := true
+	filter := domain.UserFilter{TeamName: "backend", IsActive: &isActive, Limit: 2}
+
+	userRepo.
+		On("List", ctx, mock.MatchedBy(func(f domain.UserFilter) bool {
+			return f.Limit == 3 && f.AfterID == "" && f.TeamName == "backend"
+		})).
+		Return([]domain.UserListItem{
+			{User: domain.User{ID: "u1"}, OpenReviews: 2},
+			{User: domain.User{ID: "u2"}},
+			{User: domain.User{ID: "u3"}},
+		}, nil).
+		Once()
+
+	userRepo.
+		On("Count", ctx, mock.AnythingOfType("domain.UserFilter")).
+		Return(5, nil).
+		Once()
+
+	page, err := svc.ListUsers(ctx, filter, "")
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, 5, page.Total)
+	require.Equal(t, 2, page.Items[0].OpenReviews)
+	require.NotEmpty(t, page.NextCursor)
+
+	userRepo.
+		On("List", ctx, mock.MatchedBy(func(f domain.UserFilter) bool {
+			return f.AfterID == "u2"
+		})).
+		Return([]domain.UserListItem{{User: domain.User{ID: "u4"}}}, nil).
+		Once()
+
+	userRepo.
+		On("Count", ctx, mock.AnythingOfType("domain.UserFilter")).
+		Return(5, nil).
+		Once()
+
+	next, err := svc.ListUsers(ctx, filter, page.NextCursor)
+
+	require.NoError(t, err)
+	require.Len(t, next.Items, 1)
+	require.Empty(t, next.NextCursor)
+}
+
+func TestUserService_ListUsers_InvalidCursor(t *testing.T) {
+	svc := service.NewUserService(passthroughTx(t), nil, nil, nil, nil)
+
+	page, err := svc.ListUsers(context.Background(), domain.UserFilter{}, "%%%")
+
+	require.ErrorIs(t, err, domain.ErrInvalidCursor)
+	require.Nil(t, page)
+}
+
+func TestUserService_UpsertUser_ExternalAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	svc := service.NewUserService(passthroughTx(t), userRepo, teamRepo, nil, nil)
+
+	email := "alice@example.com"
+	accounts := map[string]string{domain.ProviderGitLab: "alice.gl"}
+
+	teamRepo.
+		On("ExistsByName", ctx, "backend").
+		Return(true, nil).
+		Once()
+
+	userRepo.
+		On("GetByID", ctx, "u1").
+		Return(nil, domain.ErrUserNotFound).
+		Once()
+
+	userRepo.
+		On("Upsert", ctx, mock.MatchedBy(func(u *domain.UserUpsert) bool {
+			return u.Email != nil && *u.Email == email && u.DisplayName == nil
+		})).
+		Return(nil).
+		Once()
+
+	userRepo.
+		On("AddMembership", ctx, "u1", "backend", false).
+		Return(nil).
+		Once()
+
+	userRepo.
+		On("SetExternalAccounts", ctx, "u1", accounts).
+		Return(domain.ErrExternalAccountTaken).
+		Once()
+
+	user, err := svc.UpsertUser(ctx, domain.UserUpsert{
+		ID:               "u1",
+		Username:         "Alice",
+		TeamName:         "backend",
+		IsActive:         true,
+		Email:            &email,
+		ExternalAccounts: accounts,
+	})
+
+	require.ErrorIs(t, err, domain.ErrExternalAccountTaken)
+	require.Nil(t, user)
+}
+
+func TestUserService_UpsertUser_UnknownProvider(t *testing.T) {
+	svc := service.NewUserService(passthroughTx(t), nil, nil, nil, nil)
+
+	user, err := svc.UpsertUser(context.Background(), domain.UserUpsert{
+		ID:               "u1",
+		Username:         "Alice",
+		TeamName:         "backend",
+		ExternalAccounts: map[string]string{"bitbucket": "alice"},
+	})
+
+	require.Error(t, err)
+	require.Nil(t, user)
+}
+
+func TestUserService_ResolveByExternalLogin(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := mocks.NewUserRepository(t)
+	svc := service.NewUserService(passthroughTx(t), userRepo, nil, nil, nil)
+
+	userRepo.
+		On("GetByExternalLogin", ctx, domain.ProviderGitHub, "octocat").
+		Return(&domain.User{ID: "u1"}, nil).
+		Once()
+
+	user, err := svc.ResolveByExternalLogin(ctx, domain.ProviderGitHub, "octocat")
+
+	require.NoError(t, err)
+	require.Equal(t, "u1", user.ID)
+
+	userRepo.
+		On("GetByExternalLogin", ctx, domain.ProviderGitHub, "ghost").
+		Return(nil, domain.ErrUserNotFound).
+		Once()
+
+	user, err = svc.ResolveByExternalLogin(ctx, domain.ProviderGitHub, "ghost")
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	require.Nil(t, user)
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/cursor"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/signature"
+)
+
+// Headers sent with every webhook request. The signature covers the
+// timestamp and the body joined with a dot, so a captured request can not be
+// replayed with a fresh timestamp.
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+const maxDeliveryErrorLength = 500
+
+// DeliveryPolicy controls how background workers retry outgoing requests,
+// such as webhooks.
+type DeliveryPolicy struct {
+	BatchSize   int
+	MaxAttempts int
+	// The n-th retry waits BackoffBase * 2^(n-1), but never more than
+	// BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// Lease is how long a claimed delivery is hidden from other
+	// dispatchers. It must be longer than the HTTP client timeout.
+	Lease time.Duration
+}
+
+type WebhookService struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	policy DeliveryPolicy
+}
+
+func NewWebhookService(repo repository.WebhookRepository, client *http.Client, policy DeliveryPolicy) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		client: client,
+		policy: policy,
+	}
+}
+
+// CreateSubscription registers a webhook receiver. An empty secret is
+// replaced with a random one; the secret is returned so that the receiver
+// can verify signatures.
+func (s *WebhookService) CreateSubscription(
+	ctx context.Context,
+	rawURL string,
+	secret string,
+	eventTypes []domain.EventType,
+) (*domain.WebhookSubscription, error) {
+	log := logger.L()
+
+	log.Info("creating webhook subscription", slog.String("url", rawURL))
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		log.Warn("invalid webhook url", slog.String("url", rawURL))
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) url", domain.ErrInvalidWebhook)
+	}
+
+	for _, t := range eventTypes {
+		if !domain.IsKnownEventType(t) {
+			log.Warn("unknown webhook event type", slog.String("eventType", string(t)))
+			return nil, fmt.Errorf("%w: unknown event type %q", domain.ErrInvalidWebhook, t)
+		}
+	}
+
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := &domain.WebhookSubscription{
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   true,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		log.Error("failed to create webhook subscription", slog.Any("err", err))
+		return nil, err
+	}
+
+	log.Info("webhook subscription successfully created", slog.Int64("subscriptionID", sub.ID))
+
+	return sub, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		logger.L().Error("failed to list webhook subscriptions", slog.Any("err", err))
+		return nil, err
+	}
+	return subs, nil
+}
+
+// ListDeliveries returns the delivery log of a subscription, newest first.
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context,
+	subscriptionID int64,
+	limit int,
+	pageCursor string,
+) (*domain.DeliveryPage, error) {
+	log := logger.L()
+
+	position, err := cursor.Decode(pageCursor)
+	if err != nil {
+		log.Warn("invalid delivery cursor", slog.String("cursor", pageCursor))
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var beforeID int64
+	if position != "" {
+		beforeID, err = strconv.ParseInt(position, 10, 64)
+		if err != nil || beforeID <= 0 {
+			log.Warn("invalid delivery cursor", slog.String("cursor", pageCursor))
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+
+	if limit <= 0 {
+		limit = domain.DefaultDeliveryPageSize
+	}
+	if limit > domain.MaxDeliveryPageSize {
+		limit = domain.MaxDeliveryPageSize
+	}
+
+	if _, err := s.repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListDeliveries(ctx, subscriptionID, beforeID, limit+1)
+	if err != nil {
+		log.Error("failed to list webhook deliveries",
+			slog.Int64("subscriptionID", subscriptionID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	page := &domain.DeliveryPage{Items: items}
+
+	if len(items) > limit {
+		page.Items = items[:limit]
+		last := page.Items[limit-1].ID
+		page.NextCursor = cursor.Encode(strconv.FormatInt(last, 10))
+	}
+
+	return page, nil
+}
+
+// Redeliver queues the event of a past delivery again. The original delivery
+// keeps its log; the new one references it.
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error) {
+	log := logger.L()
+
+	log.Info("redelivering webhook", slog.Int64("deliveryID", deliveryID))
+
+	original, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrDeliveryNotFound) {
+			log.Error("failed to get webhook delivery",
+				slog.Int64("deliveryID", deliveryID),
+				slog.Any("err", err),
+			)
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	d := &domain.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		RedeliveryOf:   &original.ID,
+		Status:         domain.DeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+
+	if err := s.repo.CreateDelivery(ctx, d); err != nil {
+		log.Error("failed to create webhook delivery",
+			slog.Int64("deliveryID", deliveryID),
+			slog.Any("err", err),
+		)
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Dispatch moves new outbox events into deliveries and sends the deliveries
+// that are due. It returns the number of requests made.
+func (s *WebhookService) Dispatch(ctx context.Context) (int, error) {
+	log := logger.L()
+
+	if _, err := s.repo.FanOut(ctx, s.policy.BatchSize); err != nil {
+		log.Error("failed to fan out outbox events", slog.Any("err", err))
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	due, err := s.repo.ClaimDue(ctx, now, now.Add(s.policy.Lease), s.policy.BatchSize)
+	if err != nil {
+		log.Error("failed to claim webhook deliveries", slog.Any("err", err))
+		return 0, err
+	}
+
+	for i := range due {
+		s.attempt(ctx, &due[i])
+	}
+
+	return len(due), nil
+}
+
+// RunDispatcher calls Dispatch every interval until ctx is done.
+func (s *WebhookService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.Dispatch(ctx)
+		}
+	}
+}
+
+// attempt sends one delivery and stores the outcome. A failed delivery that
+// has attempts left stays pending until its backoff has passed.
+func (s *WebhookService) attempt(ctx context.Context, dispatch *domain.WebhookDispatch) {
+	log := logger.L()
+
+	d := &dispatch.Delivery
+	status, err := s.send(ctx, dispatch)
+
+	now := time.Now().UTC()
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.LastStatusCode = status
+	d.LastError = ""
+
+	switch {
+	case err == nil:
+		d.Status = domain.DeliverySucceeded
+	case d.Attempts >= s.policy.MaxAttempts:
+		d.Status = domain.DeliveryFailed
+	default:
+		d.Status = domain.DeliveryPending
+		d.NextAttemptAt = now.Add(s.policy.backoff(d.Attempts))
+	}
+
+	if err != nil {
+		d.LastError = err.Error()
+		if len(d.LastError) > maxDeliveryErrorLength {
+			d.LastError = d.LastError[:maxDeliveryErrorLength]
+		}
+		log.Warn("webhook delivery failed",
+			slog.Int64("deliveryID", d.ID),
+			slog.Int("attempts", d.Attempts),
+			slog.String("status", string(d.Status)),
+			slog.Any("err", err),
+		)
+	}
+
+	if err := s.repo.SaveAttempt(ctx, d); err != nil {
+		log.Error("failed to save webhook delivery attempt",
+			slog.Int64("deliveryID", d.ID),
+			slog.Any("err", err),
+		)
+	}
+}
+
+func (s *WebhookService) send(ctx context.Context, dispatch *domain.WebhookDispatch) (int, error) {
+	body, err := json.Marshal(webhookEnvelope{
+		ID:         dispatch.Event.ID,
+		Type:       dispatch.Event.Type,
+		OccurredAt: dispatch.Event.CreatedAt,
+		Data:       dispatch.Event.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signed := append([]byte(timestamp+"."), body...)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(dispatch.Event.Type))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(dispatch.Delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, signature.Sign(dispatch.Secret, signed))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns how long to wait before the retry that follows the given
+// number of attempts.
+func (p DeliveryPolicy) backoff(attempts int) time.Duration {
+	delay := p.BackoffBase
+	for i := 1; i < attempts && delay < p.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, p.BackoffMax)
+}
+
+type webhookEnvelope struct {
+	ID         int64            `json:"id"`
+	Type       domain.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data"`
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
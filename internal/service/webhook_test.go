@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/mocks"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/logger"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/signature"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Setup("test")
+}
+
+var testDeliveryPolicy = service.DeliveryPolicy{
+	BatchSize:   10,
+	MaxAttempts: 3,
+	BackoffBase: time.Minute,
+	BackoffMax:  time.Hour,
+	Lease:       time.Minute,
+}
+
+func claimed(url string, attempts int) domain.WebhookDispatch {
+	return domain.WebhookDispatch{
+		Delivery: domain.WebhookDelivery{
+			ID:             7,
+			SubscriptionID: 1,
+			EventID:        42,
+			EventType:      domain.EventPRMerged,
+			Status:         domain.DeliveryPending,
+			Attempts:       attempts,
+		},
+		URL:    url,
+		Secret: "s3cret",
+		Event: domain.OutboxEvent{
+			ID:      42,
+			Type:    domain.EventPRMerged,
+			Payload: json.RawMessage(`{"pull_request":{"id":"pr1"}}`),
+		},
+	}
+}
+
+func TestWebhookService_Dispatch_SignedDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		gotBody    []byte
+		gotHeaders http.Header
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeaders = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	repo := mocks.NewWebhookRepository(t)
+	svc := service.NewWebhookService(repo, receiver.Client(), testDeliveryPolicy)
+
+	repo.
+		On("FanOut", ctx, 10).
+		Return(int64(1), nil).
+		Once()
+
+	repo.
+		On("ClaimDue", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 10).
+		Return([]domain.WebhookDispatch{claimed(receiver.URL, 0)}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", ctx, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.ID == 7 &&
+				d.Status == domain.DeliverySucceeded &&
+				d.Attempts == 1 &&
+				d.LastStatusCode == http.StatusNoContent &&
+				d.LastError == ""
+		})).
+		Return(nil).
+		Once()
+
+	sent, err := svc.Dispatch(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	require.Equal(t, "pr.merged", gotHeaders.Get(service.WebhookEventHeader))
+	require.Equal(t, "7", gotHeaders.Get(service.WebhookDeliveryHeader))
+
+	signed := append([]byte(gotHeaders.Get(service.WebhookTimestampHeader)+"."), gotBody...)
+	require.True(t, signature.Verify("s3cret", signed, gotHeaders.Get(service.WebhookSignatureHeader)))
+
+	var envelope struct {
+		ID   int64           `json:"id"`
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(gotBody, &envelope))
+	require.Equal(t, int64(42), envelope.ID)
+	require.JSONEq(t, `{"pull_request":{"id":"pr1"}}`, string(envelope.Data))
+}
+
+func TestWebhookService_Dispatch_FailureBacksOff(t *testing.T) {
+	ctx := context.Background()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer receiver.Close()
+
+	repo := mocks.NewWebhookRepository(t)
+	svc := service.NewWebhookService(repo, receiver.Client(), testDeliveryPolicy)
+
+	repo.
+		On("FanOut", ctx, 10).
+		Return(int64(0), nil).
+		Once()
+
+	repo.
+		On("ClaimDue", ctx, mock.Anything, mock.Anything, 10).
+		Return([]domain.WebhookDispatch{claimed(receiver.URL, 1)}, nil).
+		Once()
+
+	start := time.Now()
+
+	repo.
+		On("SaveAttempt", ctx, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			// The second attempt failed, so the next one waits 2 * BackoffBase.
+			wait := d.NextAttemptAt.Sub(start)
+			return d.Status == domain.DeliveryPending &&
+				d.Attempts == 2 &&
+				d.LastStatusCode == http.StatusBadGateway &&
+				d.LastError != "" &&
+				wait >= 2*time.Minute && wait < 2*time.Minute+time.Second
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(ctx)
+
+	require.NoError(t, err)
+}
+
+func TestWebhookService_Dispatch_GivesUp(t *testing.T) {
+	ctx := context.Background()
+
+	repo := mocks.NewWebhookRepository(t)
+	svc := service.NewWebhookService(repo, http.DefaultClient, testDeliveryPolicy)
+
+	repo.
+		On("FanOut", ctx, 10).
+		Return(int64(0), nil).
+		Once()
+
+	// Nothing listens on this address.
+	repo.
+		On("ClaimDue", ctx, mock.Anything, mock.Anything, 10).
+		Return([]domain.WebhookDispatch{claimed("http://127.0.0.1:1", 2)}, nil).
+		Once()
+
+	repo.
+		On("SaveAttempt", ctx, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.Status == domain.DeliveryFailed && d.Attempts == 3 && d.LastStatusCode == 0
+		})).
+		Return(nil).
+		Once()
+
+	_, err := svc.Dispatch(ctx)
+
+	require.NoError(t, err)
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	repo := mocks.NewWebhookRepository(t)
+	svc := service.NewWebhookService(repo, http.DefaultClient, testDeliveryPolicy)
+
+	repo.
+		On("CreateSubscription", ctx, mock.MatchedBy(func(s *domain.WebhookSubscription) bool {
+			return s.URL == "https://hooks.example.com/pr" && len(s.Secret) == 64 && s.IsActive
+		})).
+		Return(nil).
+		Once()
+
+	sub, err := svc.CreateSubscription(ctx, "https://hooks.example.com/pr", "", []domain.EventType{domain.EventPRMerged})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, sub.Secret)
+
+	_, err = svc.CreateSubscription(ctx, "ftp://hooks.example.com", "", nil)
+	require.ErrorIs(t, err, domain.ErrInvalidWebhook)
+
+	_, err = svc.CreateSubscription(ctx, "https://hooks.example.com", "", []domain.EventType{"pr.deleted"})
+	require.ErrorIs(t, err, domain.ErrInvalidWebhook)
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	ctx := context.Background()
+
+	repo := mocks.NewWebhookRepository(t)
+	svc := service.NewWebhookService(repo, http.DefaultClient, testDeliveryPolicy)
+
+	repo.
+		On("GetDelivery", ctx, int64(7)).
+		Return(&domain.WebhookDelivery{ID: 7, SubscriptionID: 1, EventID: 42, Status: domain.DeliveryFailed}, nil).
+		Once()
+
+	repo.
+		On("CreateDelivery", ctx, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.SubscriptionID == 1 &&
+				d.EventID == 42 &&
+				d.RedeliveryOf != nil && *d.RedeliveryOf == 7 &&
+				d.Status == domain.DeliveryPending
+		})).
+		Return(nil).
+		Once()
+
+	d, err := svc.Redeliver(ctx, 7)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.DeliveryPending, d.Status)
+
+	repo.
+		On("GetDelivery", ctx, int64(8)).
+		Return(nil, domain.ErrDeliveryNotFound).
+		Once()
+
+	_, err = svc.Redeliver(ctx, 8)
+	require.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+}
+
+func TestOutboxService_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	outboxRepo := mocks.NewOutboxRepository(t)
+	svc := service.NewOutboxService(outboxRepo)
+
+	outboxRepo.
+		On("Append", ctx, mock.MatchedBy(func(e *domain.OutboxEvent) bool {
+			return e.Type == domain.EventUserErased && string(e.Payload) == `{"id":"u1"}`
+		})).
+		Return(nil).
+		Once()
+
+	require.NoError(t, svc.Publish(ctx, domain.EventUserErased, map[string]string{"id": "u1"}))
+
+	var nilSvc *service.OutboxService
+	require.NoError(t, nilSvc.Publish(ctx, domain.EventUserErased, nil))
+}
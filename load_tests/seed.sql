@@ -0,0 +1,51 @@
+-- Fills an empty database with about a million reviewer assignments for the
+-- /stats load test: 50 teams of 100 members and 500 000 pull requests with
+-- two reviewers each.
+--
+--   psql "$DATABASE_URL" -f load_tests/seed.sql
+--
+-- Triggers are skipped while loading and the statistics counters are
+-- rebuilt once at the end.
+
+BEGIN;
+
+SET LOCAL session_replication_role = replica;
+
+INSERT INTO teams (name)
+SELECT 'load-team-' || t
+FROM generate_series(1, 50) t
+ON CONFLICT DO NOTHING;
+
+INSERT INTO users (id, username, team_name, is_active)
+SELECT 'load-u' || u, 'load user ' || u, 'load-team-' || (u % 50 + 1), TRUE
+FROM generate_series(1, 5000) u
+ON CONFLICT DO NOTHING;
+
+INSERT INTO team_members (user_id, team_name, is_primary)
+SELECT id, team_name, TRUE
+FROM users
+WHERE id LIKE 'load-u%'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO pull_requests (id, name, author_id, team_name, status, created_at, merged_at)
+SELECT 'load-pr-' || p,
+       'load pr ' || p,
+       'load-u' || (p % 5000 + 1),
+       'load-team-' || ((p % 5000 + 1) % 50 + 1),
+       CASE WHEN p % 3 = 0 THEN 'OPEN' ELSE 'MERGED' END,
+       now() - (p % 365) * interval '1 day',
+       CASE WHEN p % 3 = 0 THEN NULL ELSE now() - (p % 365) * interval '1 day' + interval '1 day' END
+FROM generate_series(1, 500000) p
+ON CONFLICT DO NOTHING;
+
+-- The reviewers are the next two members of the author's team.
+INSERT INTO pull_request_reviewers (pr_id, reviewer_id)
+SELECT 'load-pr-' || p, 'load-u' || ((p + 50 * k) % 5000 + 1)
+FROM generate_series(1, 500000) p, generate_series(1, 2) k
+ON CONFLICT DO NOTHING;
+
+SELECT stats_rebuild_counters();
+
+COMMIT;
+
+ANALYZE;
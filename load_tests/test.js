@@ -7,6 +7,10 @@ export let options = {
         { duration: "1m",  target: 200 },
         { duration: "30s", target: 0 },
     ],
+    // Run against a database filled by seed.sql.
+    thresholds: {
+        http_req_duration: ["p(95)<50"],
+    },
 };
 
 export default function () {
@@ -0,0 +1,26 @@
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
+
+CREATE TABLE IF NOT EXISTS team_members (
+    user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team_name  TEXT NOT NULL REFERENCES teams(name) ON DELETE CASCADE,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (user_id, team_name)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_members_primary ON team_members(user_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS idx_team_members_team ON team_members(team_name);
+
+INSERT INTO team_members (user_id, team_name, is_primary)
+SELECT id, team_name, TRUE
+FROM users
+WHERE team_name IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS team_name TEXT REFERENCES teams(name) ON DELETE SET NULL;
+
+UPDATE pull_requests pr
+SET team_name = u.team_name
+FROM users u
+WHERE pr.author_id = u.id AND pr.team_name IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_team_name ON pull_requests(team_name);
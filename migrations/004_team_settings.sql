@@ -0,0 +1,12 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS slack_channel_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS reviewers_count INT NOT NULL DEFAULT 2 CHECK (reviewers_count BETWEEN 0 AND 10);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS exclude_leads BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS team_leads (
+    team_name TEXT NOT NULL REFERENCES teams(name) ON DELETE CASCADE,
+    user_id   TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (team_name, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_leads_user ON team_leads(user_id);
@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS user_external_accounts (
+    user_id  TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    login    TEXT NOT NULL,
+    PRIMARY KEY (user_id, provider)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_external_accounts_login
+    ON user_external_accounts (provider, lower(login));
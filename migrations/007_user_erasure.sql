@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS user_erasures (
+    id                 BIGSERIAL PRIMARY KEY,
+    user_id            TEXT NOT NULL UNIQUE REFERENCES users(id) ON DELETE RESTRICT,
+    requested_by       TEXT NOT NULL DEFAULT '',
+    reassigned_reviews INT NOT NULL DEFAULT 0,
+    removed_reviews    INT NOT NULL DEFAULT 0,
+    erased_at          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
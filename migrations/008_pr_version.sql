@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS verdict TEXT;
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS verdict_at TIMESTAMPTZ;
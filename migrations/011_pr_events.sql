@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS pr_events (
+    id                   BIGSERIAL PRIMARY KEY,
+    pr_id                TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    type                 TEXT NOT NULL,
+    occurred_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    actor_id             TEXT NOT NULL DEFAULT '',
+    reviewer_id          TEXT NOT NULL DEFAULT '',
+    previous_reviewer_id TEXT NOT NULL DEFAULT '',
+    strategy             TEXT NOT NULL DEFAULT '',
+    reason               TEXT NOT NULL DEFAULT '',
+    verdict              TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr ON pr_events(pr_id, id);
+
+-- Pull requests created before the event store existed get a timeline built
+-- from what is known about them. PRs that already have events are skipped, so
+-- this runs once per PR.
+INSERT INTO pr_events (pr_id, type, occurred_at, reviewer_id, strategy, reason)
+SELECT e.pr_id, e.type, e.occurred_at, e.reviewer_id, e.strategy, e.reason
+FROM (
+    SELECT pr.id AS pr_id, 'created' AS type, pr.created_at AS occurred_at,
+           '' AS reviewer_id, '' AS strategy, '' AS reason, 1 AS ord
+    FROM pull_requests pr
+    UNION ALL
+    SELECT r.pr_id, 'reviewer_assigned', pr.created_at,
+           r.reviewer_id, 'unknown', 'assigned before the timeline was recorded', 2
+    FROM pull_request_reviewers r
+    JOIN pull_requests pr ON pr.id = r.pr_id
+    UNION ALL
+    SELECT pr.id, 'merged', pr.merged_at, '', '', '', 3
+    FROM pull_requests pr
+    WHERE pr.status = 'MERGED' AND pr.merged_at IS NOT NULL
+) e
+WHERE NOT EXISTS (SELECT 1 FROM pr_events x WHERE x.pr_id = e.pr_id)
+ORDER BY e.pr_id, e.ord, e.reviewer_id;
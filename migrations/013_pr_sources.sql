@@ -0,0 +1,14 @@
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED'));
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS pr_sources (
+    pr_id      TEXT PRIMARY KEY REFERENCES pull_requests(id) ON DELETE CASCADE,
+    provider   TEXT NOT NULL,
+    repository TEXT NOT NULL,
+    number     BIGINT NOT NULL,
+    url        TEXT NOT NULL DEFAULT '',
+    UNIQUE (provider, repository, number)
+);
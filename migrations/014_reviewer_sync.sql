@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS reviewer_sync_jobs (
+    id               BIGSERIAL PRIMARY KEY,
+    pr_id            TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    add_reviewers    TEXT[] NOT NULL DEFAULT '{}',
+    remove_reviewers TEXT[] NOT NULL DEFAULT '{}',
+    status           TEXT NOT NULL DEFAULT 'PENDING',
+    attempts         INT NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_attempt_at  TIMESTAMPTZ,
+    last_error       TEXT NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_sync_jobs_due
+    ON reviewer_sync_jobs(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_reviewer_sync_jobs_pr
+    ON reviewer_sync_jobs(pr_id, id);
@@ -0,0 +1,13 @@
+-- Every committed timeline event is announced on the pr_events channel so
+-- that each replica can push it to its live subscribers.
+CREATE OR REPLACE FUNCTION notify_pr_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('pr_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS pr_events_notify ON pr_events;
+CREATE TRIGGER pr_events_notify
+    AFTER INSERT ON pr_events
+    FOR EACH ROW EXECUTE FUNCTION notify_pr_event();
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id            TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    channels           TEXT[] NOT NULL DEFAULT '{email}',
+    language           TEXT NOT NULL DEFAULT 'en',
+    mode               TEXT NOT NULL DEFAULT 'immediate',
+    quiet_hours_start  INT,
+    quiet_hours_end    INT,
+    timezone           TEXT NOT NULL DEFAULT 'UTC',
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id                   BIGSERIAL PRIMARY KEY,
+    user_id              TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind                 TEXT NOT NULL,
+    pr_id                TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    previous_reviewer_id TEXT NOT NULL DEFAULT '',
+    status               TEXT NOT NULL DEFAULT 'PENDING',
+    attempts             INT NOT NULL DEFAULT 0,
+    next_attempt_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at              TIMESTAMPTZ,
+    last_error           TEXT NOT NULL DEFAULT '',
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_due
+    ON notifications(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_notifications_user_pr
+    ON notifications(user_id, pr_id, created_at);
@@ -0,0 +1,26 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS sla_response_hours INT NOT NULL DEFAULT 24 CHECK (sla_response_hours >= 0);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS sla_escalation_hours INT NOT NULL DEFAULT 48 CHECK (sla_escalation_hours >= 0);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS sla_escalation TEXT NOT NULL DEFAULT 'notify_lead';
+
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ;
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS sla_breached_at TIMESTAMPTZ;
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMPTZ;
+
+-- Reviewers assigned before assignment times were stored take them from the
+-- timeline, or from the pull request itself when it predates the timeline.
+UPDATE pull_request_reviewers r
+SET assigned_at = COALESCE(
+    (SELECT max(e.occurred_at)
+     FROM pr_events e
+     WHERE e.pr_id = r.pr_id
+       AND e.reviewer_id = r.reviewer_id
+       AND e.type IN ('reviewer_assigned', 'reviewer_reassigned')),
+    (SELECT p.created_at FROM pull_requests p WHERE p.id = r.pr_id)
+)
+WHERE r.assigned_at IS NULL;
+
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at SET DEFAULT now();
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_awaiting
+    ON pull_request_reviewers(assigned_at) WHERE verdict IS NULL AND escalated_at IS NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+    id           BIGSERIAL PRIMARY KEY,
+    job_name     TEXT NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    instance     TEXT NOT NULL,
+    status       TEXT NOT NULL,
+    started_at   TIMESTAMPTZ NOT NULL,
+    finished_at  TIMESTAMPTZ,
+    error        TEXT NOT NULL DEFAULT '',
+    UNIQUE (job_name, scheduled_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_started ON job_runs(started_at);
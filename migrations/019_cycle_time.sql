@@ -0,0 +1,7 @@
+-- Cycle time metrics look pull requests up by merge time and approvals by
+-- verdict time.
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged
+    ON pull_requests(merged_at) WHERE status = 'MERGED';
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_approved
+    ON pull_request_reviewers(verdict_at) WHERE verdict = 'APPROVED';
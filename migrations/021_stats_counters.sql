@@ -0,0 +1,193 @@
+-- Counters behind /stats. Triggers keep them up to date in the transaction
+-- that changes pull requests and reviewers; stats_rebuild_counters()
+-- recomputes them from scratch and the consistency check job calls it when
+-- they drift.
+CREATE TABLE IF NOT EXISTS stats_reviewer_counts (
+    team_name   TEXT NOT NULL,
+    reviewer_id TEXT NOT NULL,
+    assignments INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (team_name, reviewer_id)
+);
+
+CREATE TABLE IF NOT EXISTS stats_pr_counts (
+    pr_id     TEXT PRIMARY KEY REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewers INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_stats_pr_counts_reviewers
+    ON stats_pr_counts(reviewers DESC, pr_id) WHERE reviewers > 0;
+
+CREATE TABLE IF NOT EXISTS stats_team_counts (
+    team_name     TEXT PRIMARY KEY,
+    pull_requests INT NOT NULL DEFAULT 0,
+    open          INT NOT NULL DEFAULT 0,
+    merged        INT NOT NULL DEFAULT 0,
+    closed        INT NOT NULL DEFAULT 0,
+    assignments   INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS stats_counters_state (
+    id         BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    rebuilt_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION stats_rebuild_counters() RETURNS void AS $$
+BEGIN
+    -- Writers wait until the counters are rebuilt.
+    LOCK TABLE pull_requests, pull_request_reviewers IN SHARE MODE;
+
+    TRUNCATE stats_reviewer_counts, stats_pr_counts, stats_team_counts;
+
+    INSERT INTO stats_reviewer_counts (team_name, reviewer_id, assignments)
+    SELECT COALESCE(p.team_name, ''), r.reviewer_id, COUNT(*)
+    FROM pull_request_reviewers r
+    JOIN pull_requests p ON p.id = r.pr_id
+    GROUP BY 1, 2;
+
+    INSERT INTO stats_pr_counts (pr_id, reviewers)
+    SELECT pr_id, COUNT(*)
+    FROM pull_request_reviewers
+    GROUP BY pr_id;
+
+    INSERT INTO stats_team_counts (team_name, pull_requests, open, merged, closed, assignments)
+    SELECT p.team_name,
+           COUNT(*),
+           COUNT(*) FILTER (WHERE p.status = 'OPEN'),
+           COUNT(*) FILTER (WHERE p.status = 'MERGED'),
+           COUNT(*) FILTER (WHERE p.status = 'CLOSED'),
+           COALESCE(SUM(c.reviewers), 0)
+    FROM pull_requests p
+    LEFT JOIN stats_pr_counts c ON c.pr_id = p.id
+    WHERE p.team_name IS NOT NULL
+    GROUP BY p.team_name;
+
+    INSERT INTO stats_counters_state (id, rebuilt_at)
+    VALUES (TRUE, now())
+    ON CONFLICT (id) DO UPDATE SET rebuilt_at = EXCLUDED.rebuilt_at;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The team row is always updated first so that concurrent transactions
+-- lock the counters in the same order.
+CREATE OR REPLACE FUNCTION stats_count_reviewer() RETURNS trigger AS $$
+DECLARE
+    team TEXT;
+BEGIN
+    IF TG_OP IN ('DELETE', 'UPDATE') THEN
+        SELECT p.team_name INTO team FROM pull_requests p WHERE p.id = OLD.pr_id;
+
+        IF team IS NOT NULL THEN
+            UPDATE stats_team_counts SET assignments = assignments - 1 WHERE team_name = team;
+        END IF;
+        UPDATE stats_pr_counts SET reviewers = reviewers - 1 WHERE pr_id = OLD.pr_id;
+        UPDATE stats_reviewer_counts SET assignments = assignments - 1
+        WHERE team_name = COALESCE(team, '') AND reviewer_id = OLD.reviewer_id;
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        SELECT p.team_name INTO team FROM pull_requests p WHERE p.id = NEW.pr_id;
+
+        IF team IS NOT NULL THEN
+            UPDATE stats_team_counts SET assignments = assignments + 1 WHERE team_name = team;
+        END IF;
+        INSERT INTO stats_pr_counts (pr_id, reviewers)
+        VALUES (NEW.pr_id, 1)
+        ON CONFLICT (pr_id) DO UPDATE SET reviewers = stats_pr_counts.reviewers + 1;
+        INSERT INTO stats_reviewer_counts (team_name, reviewer_id, assignments)
+        VALUES (COALESCE(team, ''), NEW.reviewer_id, 1)
+        ON CONFLICT (team_name, reviewer_id) DO UPDATE SET assignments = stats_reviewer_counts.assignments + 1;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Pull requests are never deleted by the service; should one be, the
+-- consistency check repairs the assignment counters of its reviewers.
+CREATE OR REPLACE FUNCTION stats_count_pr() RETURNS trigger AS $$
+DECLARE
+    moved INT := 0;
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.team_name IS DISTINCT FROM NEW.team_name THEN
+        SELECT COUNT(*) INTO moved FROM pull_request_reviewers WHERE pr_id = NEW.id;
+    END IF;
+
+    IF TG_OP IN ('DELETE', 'UPDATE') AND OLD.team_name IS NOT NULL THEN
+        UPDATE stats_team_counts
+        SET pull_requests = pull_requests - 1,
+            open = open - (OLD.status = 'OPEN')::int,
+            merged = merged - (OLD.status = 'MERGED')::int,
+            closed = closed - (OLD.status = 'CLOSED')::int,
+            assignments = assignments - moved
+        WHERE team_name = OLD.team_name;
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.team_name IS NOT NULL THEN
+        INSERT INTO stats_team_counts AS c (team_name, pull_requests, open, merged, closed, assignments)
+        VALUES (
+            NEW.team_name,
+            1,
+            (NEW.status = 'OPEN')::int,
+            (NEW.status = 'MERGED')::int,
+            (NEW.status = 'CLOSED')::int,
+            moved
+        )
+        ON CONFLICT (team_name) DO UPDATE
+        SET pull_requests = c.pull_requests + 1,
+            open = c.open + EXCLUDED.open,
+            merged = c.merged + EXCLUDED.merged,
+            closed = c.closed + EXCLUDED.closed,
+            assignments = c.assignments + EXCLUDED.assignments;
+    END IF;
+
+    IF moved > 0 THEN
+        UPDATE stats_reviewer_counts c
+        SET assignments = c.assignments - 1
+        FROM pull_request_reviewers r
+        WHERE r.pr_id = NEW.id
+          AND c.team_name = COALESCE(OLD.team_name, '')
+          AND c.reviewer_id = r.reviewer_id;
+
+        INSERT INTO stats_reviewer_counts AS c (team_name, reviewer_id, assignments)
+        SELECT COALESCE(NEW.team_name, ''), r.reviewer_id, 1
+        FROM pull_request_reviewers r
+        WHERE r.pr_id = NEW.id
+        ON CONFLICT (team_name, reviewer_id) DO UPDATE SET assignments = c.assignments + 1;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS pull_requests_stats ON pull_requests;
+CREATE TRIGGER pull_requests_stats
+    AFTER INSERT OR DELETE ON pull_requests
+    FOR EACH ROW EXECUTE FUNCTION stats_count_pr();
+
+DROP TRIGGER IF EXISTS pull_requests_stats_update ON pull_requests;
+CREATE TRIGGER pull_requests_stats_update
+    AFTER UPDATE OF status, team_name ON pull_requests
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status OR OLD.team_name IS DISTINCT FROM NEW.team_name)
+    EXECUTE FUNCTION stats_count_pr();
+
+DROP TRIGGER IF EXISTS pull_request_reviewers_stats ON pull_request_reviewers;
+CREATE TRIGGER pull_request_reviewers_stats
+    AFTER INSERT OR DELETE ON pull_request_reviewers
+    FOR EACH ROW EXECUTE FUNCTION stats_count_reviewer();
+
+DROP TRIGGER IF EXISTS pull_request_reviewers_stats_update ON pull_request_reviewers;
+CREATE TRIGGER pull_request_reviewers_stats_update
+    AFTER UPDATE OF pr_id, reviewer_id ON pull_request_reviewers
+    FOR EACH ROW
+    WHEN (OLD.pr_id IS DISTINCT FROM NEW.pr_id OR OLD.reviewer_id IS DISTINCT FROM NEW.reviewer_id)
+    EXECUTE FUNCTION stats_count_reviewer();
+
+-- The counters are built once; the triggers keep them from then on.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM stats_counters_state) THEN
+        PERFORM stats_rebuild_counters();
+    END IF;
+END;
+$$;
@@ -0,0 +1,31 @@
+-- Daily snapshots behind /stats/history. The snapshot job fills in the days
+-- since the last snapshot, starting from the first pull request on its
+-- first run.
+CREATE TABLE IF NOT EXISTS stats_team_snapshots (
+    day         DATE NOT NULL,
+    team_name   TEXT NOT NULL,
+    open        INT NOT NULL DEFAULT 0,
+    opened      INT NOT NULL DEFAULT 0,
+    merged      INT NOT NULL DEFAULT 0,
+    closed      INT NOT NULL DEFAULT 0,
+    assignments INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, team_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stats_team_snapshots_team ON stats_team_snapshots(team_name, day);
+
+-- team_name is the team of the reviewed pull requests, '' for the ones
+-- without a team.
+CREATE TABLE IF NOT EXISTS stats_user_snapshots (
+    day          DATE NOT NULL,
+    team_name    TEXT NOT NULL,
+    user_id      TEXT NOT NULL,
+    assignments  INT NOT NULL DEFAULT 0,
+    open_reviews INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, team_name, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stats_user_snapshots_user ON stats_user_snapshots(user_id, day);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_assigned ON pull_request_reviewers(assigned_at);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created ON pull_requests(created_at);
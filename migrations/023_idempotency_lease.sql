@@ -0,0 +1,7 @@
+-- A request holds its key only while it keeps renewing locked_until; a retry
+-- may take over the key of a request that died without releasing it.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
+
+UPDATE idempotency_keys
+SET locked_until = created_at
+WHERE status_code IS NULL AND locked_until IS NULL;
@@ -0,0 +1,16 @@
+-- Streamed events are ordered by the transaction that appended them and
+-- served only once every older transaction has finished, so a transaction
+-- committing late can not be overtaken. Ids alone are taken on insert: an
+-- event with a lower id may commit after a higher one was streamed.
+-- Events stored before get 0 and stay ordered by id.
+ALTER TABLE pr_events ADD COLUMN IF NOT EXISTS tx_id xid8 NOT NULL DEFAULT '0';
+ALTER TABLE pr_events ALTER COLUMN tx_id SET DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_stream ON pr_events(tx_id, id);
+
+-- The first version of this migration numbered events at commit under a
+-- global lock.
+DROP TRIGGER IF EXISTS pr_events_stream_pos ON pr_events;
+DROP FUNCTION IF EXISTS set_pr_event_stream_pos();
+ALTER TABLE pr_events DROP COLUMN IF EXISTS stream_pos;
+DROP SEQUENCE IF EXISTS pr_events_stream_pos_seq;
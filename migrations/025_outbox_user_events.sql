@@ -0,0 +1,3 @@
+-- Finds the outbox events of a user when their personal data is erased.
+CREATE INDEX IF NOT EXISTS idx_outbox_events_user
+    ON outbox_events ((payload->>'id')) WHERE event_type LIKE 'user.%';
@@ -0,0 +1,3 @@
+-- Daily snapshots count reviewer assignments from the timeline.
+CREATE INDEX IF NOT EXISTS idx_pr_events_assignments
+    ON pr_events(occurred_at) WHERE type IN ('reviewer_assigned', 'reviewer_reassigned');
@@ -0,0 +1,29 @@
+// Package cursor encodes keyset pagination positions into opaque tokens.
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+var ErrInvalid = errors.New("invalid cursor")
+
+func Encode(position string) string {
+	if position == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+func Decode(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) == 0 {
+		return "", ErrInvalid
+	}
+
+	return string(raw), nil
+}
@@ -0,0 +1,27 @@
+// Package signature signs and verifies payloads with HMAC-SHA256 in the
+// "sha256=<hex>" form used by GitHub webhooks.
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const prefix = "sha256="
+
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return prefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether sig is the signature of payload. The comparison
+// takes constant time.
+func Verify(secret string, payload []byte, sig string) bool {
+	if !strings.HasPrefix(sig, prefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(sig))
+}
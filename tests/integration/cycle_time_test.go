@@ -0,0 +1,124 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCycleTime_SeededTeam(t *testing.T) {
+	srv, db := setup(t)
+	openID, authorID, _ := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	// Four pull requests merged five days ago, after one to four hours, all
+	// their reviewers approving an hour after the assignment.
+	for i := 1; i <= 4; i++ {
+		prID := fmt.Sprintf("%s-merged-%d", openID, i)
+		status, body := post(t, srv, "/pullRequest/create", dto.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "merged",
+			AuthorID:        authorID,
+		})
+		require.Equal(t, http.StatusCreated, status, string(body))
+
+		_, err := db.Exec(`
+            UPDATE pull_requests
+            SET status = 'MERGED',
+                created_at = now() - interval '5 days',
+                merged_at = now() - interval '5 days' + make_interval(hours => $2)
+            WHERE id = $1
+        `, prID, i)
+		require.NoError(t, err)
+
+		_, err = db.Exec(`
+            UPDATE pull_request_reviewers
+            SET assigned_at = now() - interval '5 days',
+                verdict = 'APPROVED',
+                verdict_at = now() - interval '5 days' + interval '1 hour'
+            WHERE pr_id = $1
+        `, prID)
+		require.NoError(t, err)
+	}
+
+	_, err := db.Exec(`UPDATE pull_requests SET created_at = now() - interval '2 days' WHERE id = $1`, openID)
+	require.NoError(t, err)
+
+	cycleTime := func(params url.Values) (int, []byte) {
+		resp, err := http.Get(srv.URL + "/stats/cycleTime?" + params.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var raw json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+		return resp.StatusCode, raw
+	}
+
+	status, body := cycleTime(url.Values{"team_name": {teamName}})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var out dto.CycleTimeResponse
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.Len(t, out.ByTeam, 1)
+
+	team := out.ByTeam[0]
+	require.Equal(t, teamName, team.TeamName)
+	require.Equal(t, dto.DurationStatDTO{Count: 4, MedianSeconds: 9000, P90Seconds: 13320}, team.TimeToMerge)
+	require.Equal(t, dto.DurationStatDTO{Count: 8, MedianSeconds: 3600, P90Seconds: 3600}, team.TimeToApproval)
+
+	require.Len(t, team.OpenAge, 6)
+	require.Equal(t, 24, team.OpenAge[1].FromHours)
+	require.Equal(t, 1, team.OpenAge[1].Count)
+	require.Nil(t, team.OpenAge[5].ToHours)
+
+	approvals := 0
+	for _, r := range out.ByReviewer {
+		require.Equal(t, int64(3600), r.TimeToApproval.MedianSeconds, r.UserID)
+		approvals += r.TimeToApproval.Count
+	}
+	require.Equal(t, 8, approvals)
+
+	// Nothing was merged or approved in the last day; the open pull
+	// request is still counted.
+	status, body = cycleTime(url.Values{
+		"team_name": {teamName},
+		"from":      {time.Now().UTC().Add(-24 * time.Hour).Format(time.RFC3339)},
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+	out = dto.CycleTimeResponse{}
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.Len(t, out.ByTeam, 1)
+	require.Zero(t, out.ByTeam[0].TimeToMerge.Count)
+	require.Zero(t, out.ByTeam[0].TimeToApproval.Count)
+	require.Equal(t, 1, out.ByTeam[0].OpenAge[1].Count)
+	require.Empty(t, out.ByReviewer)
+
+	// Four days ago the open pull request did not exist yet.
+	status, body = cycleTime(url.Values{
+		"team_name": {teamName},
+		"to":        {time.Now().UTC().Add(-4 * 24 * time.Hour).Format(time.RFC3339)},
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+	out = dto.CycleTimeResponse{}
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.Len(t, out.ByTeam, 1)
+	require.Equal(t, 4, out.ByTeam[0].TimeToMerge.Count)
+	for _, b := range out.ByTeam[0].OpenAge {
+		require.Zero(t, b.Count)
+	}
+
+	status, body = cycleTime(url.Values{"to": {"soon"}})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, dto.ErrorCodeInvalidStatsFilter, errorCode(t, body))
+
+	status, _ = cycleTime(url.Values{"team_name": {"no-such-team"}})
+	require.Equal(t, http.StatusNotFound, status)
+}
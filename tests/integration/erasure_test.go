@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErasure_ScrubsOutboxEvents(t *testing.T) {
+	srv, db := setup(t)
+	ctx := context.Background()
+
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	teamName := "erasure-" + suffix
+	userID := "erasure-user-" + suffix
+	username := "erasure-name-" + suffix
+
+	status, body := post(t, srv, "/webhooks/create", dto.CreateWebhookRequest{
+		// Nothing listens here; the deliveries only have to stay pending.
+		URL:        "http://127.0.0.1:1/hook",
+		EventTypes: []string{"user.upserted", "user.deactivated"},
+	})
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	status, body = post(t, srv, "/team/add", dto.AddTeamRequest{
+		TeamName: teamName,
+		Members:  []dto.TeamMemberDTO{{UserID: userID, Username: username, IsActive: true}},
+	})
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	status, body = post(t, srv, "/users/upsert", dto.UpsertUserRequest{
+		UserID:    userID,
+		Username:  username,
+		TeamName:  teamName,
+		IsActive:  true,
+		IsPrimary: true,
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = post(t, srv, "/users/setIsActive", dto.SetIsActiveUserRequest{UserID: userID, IsActive: false})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	_, err := postgres.NewWebhookPostgres(db).FanOut(ctx, 1000)
+	require.NoError(t, err)
+
+	count := func(q string) int {
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, q, userID).Scan(&n))
+		return n
+	}
+	const pending = `
+        SELECT COUNT(*)
+        FROM webhook_deliveries d
+        JOIN outbox_events o ON o.id = d.event_id
+        WHERE o.payload->>'id' = $1 AND d.status = 'PENDING'
+    `
+	require.Positive(t, count(pending))
+
+	status, body = post(t, srv, "/users/erase", dto.EraseUserRequest{UserID: userID})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var left int
+	require.NoError(t, db.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM outbox_events WHERE payload::text LIKE '%' || $1 || '%'
+    `, username).Scan(&left))
+	require.Zero(t, left, "the username survived in the outbox")
+
+	require.Positive(t, count(`SELECT COUNT(*) FROM outbox_events WHERE payload->>'id' = $1`))
+	require.Zero(t, count(pending))
+}
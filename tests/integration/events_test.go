@@ -0,0 +1,179 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/app"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	ID   string
+	Type string
+	Data dto.StreamEventDTO
+}
+
+// openStream connects to /events/stream and returns the received events.
+func openStream(t *testing.T, srv *httptest.Server, query string, lastEventID string) <-chan sseEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events/stream?"+query, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 64)
+	go func() {
+		defer resp.Body.Close()
+		defer close(events)
+
+		var ev sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				ev.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				ev.Type = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev.Data)
+			case line == "" && ev.ID != "":
+				events <- ev
+				ev = sseEvent{}
+			}
+		}
+	}()
+
+	return events
+}
+
+// nextFor returns the next event about any of prIDs.
+func nextFor(t *testing.T, events <-chan sseEvent, prIDs ...string) sseEvent {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev, ok := <-events:
+			require.True(t, ok, "stream closed")
+			if slices.Contains(prIDs, ev.Data.PullRequestID) {
+				return ev
+			}
+		case <-timeout:
+			t.Fatalf("no event for %v", prIDs)
+		}
+	}
+}
+
+func TestEvents_StreamAndResume(t *testing.T) {
+	_, db := setup(t)
+
+	// The stream needs the background listener, which setup does not run.
+	server := app.NewServer(db, os.Getenv("TEST_POSTGRES_URL"))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	server.RunBackground(ctx)
+
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+
+	live := openStream(t, srv, "", "")
+	// Give the listener time to subscribe before anything is committed.
+	time.Sleep(500 * time.Millisecond)
+
+	prID, authorID, reviewers := seedPR(t, srv)
+
+	first := nextFor(t, live, prID)
+	second := nextFor(t, live, prID)
+	require.Equal(t, "reviewer_assigned", first.Type)
+	require.Equal(t, "reviewer_assigned", second.Type)
+	require.ElementsMatch(t, reviewers, []string{first.Data.ReviewerID, second.Data.ReviewerID})
+	require.Equal(t, authorID, first.Data.AuthorID)
+
+	status, body := post(t, srv, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.Equal(t, "merged", nextFor(t, live, prID).Type)
+
+	// A client that saw only the first event resumes with the rest.
+	resumed := openStream(t, srv, "user_id="+authorID, first.ID)
+	require.Equal(t, second.ID, nextFor(t, resumed, prID).ID)
+	require.Equal(t, "merged", nextFor(t, resumed, prID).Type)
+}
+
+func TestEvents_ResumeAfterLateCommit(t *testing.T) {
+	_, db := setup(t)
+
+	server := app.NewServer(db, os.Getenv("TEST_POSTGRES_URL"))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	server.RunBackground(ctx)
+
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+
+	prB, _, _ := seedPR(t, srv)
+
+	live := openStream(t, srv, "", "")
+	time.Sleep(500 * time.Millisecond)
+
+	prA, _, _ := seedPR(t, srv)
+	nextFor(t, live, prA)
+	assigned := nextFor(t, live, prA)
+
+	// The transaction starts before the merge below but commits after it.
+	// The late event goes on another PR: the merge locks its own PR, which
+	// would wait for this transaction.
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO pr_events (pr_id, type, reviewer_id)
+        VALUES ($1, 'reviewer_assigned', 'late-reviewer')
+    `, prB)
+	require.NoError(t, err)
+
+	status, body := post(t, srv, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: prA})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	// The merge is held back while the older transaction may still append
+	// events before it.
+	select {
+	case ev := <-live:
+		require.NotEqual(t, prA, ev.Data.PullRequestID, "merge streamed before an older transaction ended")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	require.NoError(t, tx.Commit())
+
+	late := nextFor(t, live, prA, prB)
+	require.Equal(t, "late-reviewer", late.Data.ReviewerID)
+	merged := nextFor(t, live, prA, prB)
+	require.Equal(t, "merged", merged.Type)
+
+	// A client that disconnected before either still gets both, in order.
+	resumed := openStream(t, srv, "", assigned.ID)
+	require.Equal(t, late.ID, nextFor(t, resumed, prA, prB).ID)
+	require.Equal(t, merged.ID, nextFor(t, resumed, prA, prB).ID)
+}
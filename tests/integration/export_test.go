@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExport_StatsAsCSV(t *testing.T) {
+	srv, db := setup(t)
+	_, authorID, reviewers := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	params := url.Values{
+		"team_name": {teamName},
+		"format":    {"csv"},
+		"table":     {"by_team"},
+		"lang":      {"ru"},
+	}
+	resp, err := http.Get(srv.URL + "/stats?" + params.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	require.Contains(t, resp.Header.Get("Content-Disposition"), "stats-by_team.csv")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(body), "\ufeff"))).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"Команда", "PR", "Открыто", "Смёржено", "Закрыто", "Назначения"},
+		{teamName, "1", "1", "0", "0", strconv.Itoa(len(reviewers))},
+	}, records)
+
+	params.Set("table", "by_nobody")
+	resp, err = http.Get(srv.URL + "/stats?" + params.Encode())
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestExport_ReviewListAsXLSX(t *testing.T) {
+	srv, _ := setup(t)
+	prID, authorID, reviewers := seedPR(t, srv)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/users/getReview?user_id="+url.QueryEscape(reviewers[0]), nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	f, err := excelize.OpenReader(resp.Body)
+	require.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows("Pull requests")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"Pull request ID", "Pull request", "Author ID", "Status"},
+		{prID, "race", authorID, "OPEN"},
+	}, rows)
+}
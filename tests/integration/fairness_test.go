@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairness_LeavesOutAbsentMembers(t *testing.T) {
+	srv, db := setup(t)
+	prID, authorID, reviewers := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	for i := 1; i <= 4; i++ {
+		status, body := post(t, srv, "/pullRequest/create", dto.CreatePRRequest{
+			PullRequestID:   fmt.Sprintf("%s-%d", prID, i),
+			PullRequestName: "fairness",
+			AuthorID:        authorID,
+		})
+		require.Equal(t, http.StatusCreated, status, string(body))
+	}
+
+	now := time.Now().UTC()
+	absent := reviewers[0]
+
+	status, body := post(t, srv, "/users/addAbsence", dto.AddAbsenceRequest{
+		UserID: absent,
+		From:   now.Add(-2 * time.Hour),
+		To:     now.Add(24 * time.Hour),
+		Reason: "vacation",
+	})
+	require.Equal(t, http.StatusCreated, status, string(body))
+	var added dto.AddAbsenceResponse
+	require.NoError(t, json.Unmarshal(body, &added))
+
+	status, body = post(t, srv, "/users/addAbsence", dto.AddAbsenceRequest{
+		UserID: absent,
+		From:   now,
+		To:     now.Add(-time.Hour),
+	})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, dto.ErrorCodeInvalidAbsence, errorCode(t, body))
+
+	resp, err := http.Get(srv.URL + "/users/absences?user_id=" + absent)
+	require.NoError(t, err)
+	var list dto.AbsencesResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	require.Len(t, list.Absences, 1)
+	require.Equal(t, added.Absence.ID, list.Absences[0].ID)
+
+	resp, err = http.Get(srv.URL + "/stats/fairness?" + url.Values{
+		"team_name": {teamName},
+		"from":      {now.Add(-time.Hour).Format(time.RFC3339)},
+	}.Encode())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report dto.FairnessResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	resp.Body.Close()
+
+	require.Len(t, report.Teams, 1)
+	team := report.Teams[0]
+	require.Equal(t, teamName, team.TeamName)
+	require.Len(t, team.Members, 6)
+	require.False(t, slices.ContainsFunc(team.Members, func(m dto.FairnessMemberDTO) bool { return m.UserID == absent }))
+
+	var assignments int
+	var expected float64
+	for _, m := range team.Members {
+		assignments += m.Assignments
+		expected += m.ExpectedShare
+	}
+	require.Equal(t, team.Assignments, assignments)
+	require.InDelta(t, 1, expected, 1e-3)
+	require.GreaterOrEqual(t, team.Gini, 0.0)
+	require.Less(t, team.Gini, 1.0)
+
+	status, _ = post(t, srv, "/users/removeAbsence", dto.RemoveAbsenceRequest{ID: added.Absence.ID})
+	require.Equal(t, http.StatusNoContent, status)
+
+	status, body = post(t, srv, "/users/removeAbsence", dto.RemoveAbsenceRequest{ID: added.Absence.ID})
+	require.Equal(t, http.StatusNotFound, status)
+	require.Equal(t, dto.ErrorCodeNotFound, errorCode(t, body))
+}
@@ -0,0 +1,121 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/config"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/githost"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+const testGitHubSecret = "integration-secret"
+
+// gitHubDelivery sends a signed GitHub pull_request delivery.
+func gitHubDelivery(t *testing.T, srv *httptest.Server, payload map[string]any) (int, []byte) {
+	t.Helper()
+
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	status, _, respBody := postWithHeaders(t, srv, "/integrations/github/webhook", map[string]string{
+		githost.GitHubEventHeader:     "pull_request",
+		githost.GitHubSignatureHeader: signature.Sign(testGitHubSecret, body),
+	}, json.RawMessage(body))
+	return status, respBody
+}
+
+func TestGitHost_GitHubLifecycle(t *testing.T) {
+	srv, _ := setup(t)
+	config.C().GitHubWebhookSecret = testGitHubSecret
+
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	teamName := "githost-" + suffix
+	authorID := "githost-author-" + suffix
+	login := "gh-" + suffix
+	repo := "acme/repo-" + suffix
+
+	status, body := post(t, srv, "/team/add", dto.AddTeamRequest{
+		TeamName: teamName,
+		Members: []dto.TeamMemberDTO{
+			{UserID: authorID, Username: "author", IsActive: true},
+			{UserID: "githost-reviewer-" + suffix, Username: "reviewer", IsActive: true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	status, body = post(t, srv, "/users/upsert", dto.UpsertUserRequest{
+		UserID:           authorID,
+		Username:         "author",
+		TeamName:         teamName,
+		IsActive:         true,
+		IsPrimary:        true,
+		ExternalAccounts: map[string]string{"github": login},
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	event := func(action string, merged bool) map[string]any {
+		return map[string]any{
+			"action": action,
+			"pull_request": map[string]any{
+				"number":   7,
+				"title":    "Add search",
+				"html_url": "https://github.com/" + repo + "/pull/7",
+				"merged":   merged,
+				"user":     map[string]any{"login": login},
+			},
+			"repository": map[string]any{"full_name": repo},
+			"sender":     map[string]any{"login": login},
+		}
+	}
+
+	opened := event("opened", false)
+	for i := 0; i < 2; i++ {
+		status, body = gitHubDelivery(t, srv, opened)
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var resp dto.GitHostWebhookResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Equal(t, dto.GitHostResultApplied, resp.Result)
+		require.Equal(t, "github:"+repo+"#7", resp.PR.PullRequestID)
+		require.Equal(t, authorID, resp.PR.AuthorID)
+	}
+
+	status, body = gitHubDelivery(t, srv, event("closed", false))
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = post(t, srv, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: "github:" + repo + "#7"})
+	require.Equal(t, http.StatusConflict, status, string(body))
+	require.Equal(t, dto.ErrorCodePRClosed, errorCode(t, body))
+
+	status, body = gitHubDelivery(t, srv, event("reopened", false))
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = gitHubDelivery(t, srv, event("closed", true))
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var resp dto.GitHostWebhookResponse
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Equal(t, "MERGED", resp.PR.Status)
+}
+
+func TestGitHost_RejectsBadSignature(t *testing.T) {
+	srv, _ := setup(t)
+	config.C().GitHubWebhookSecret = testGitHubSecret
+
+	status, _, body := postWithHeaders(t, srv, "/integrations/github/webhook", map[string]string{
+		githost.GitHubEventHeader:     "pull_request",
+		githost.GitHubSignatureHeader: "sha256=00",
+	}, map[string]any{"action": "opened"})
+
+	require.Equal(t, http.StatusUnauthorized, status)
+	require.Equal(t, dto.ErrorCodeInvalidSignature, errorCode(t, body))
+}
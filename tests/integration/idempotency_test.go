@@ -0,0 +1,115 @@
+//go:build integration
+
+package integration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency_RetriedReassignReplaysResponse(t *testing.T) {
+	srv, _ := setup(t)
+	prID, _, reviewers := seedPR(t, srv)
+
+	headers := map[string]string{"Idempotency-Key": fmt.Sprintf("reassign-%d", time.Now().UnixNano())}
+	req := dto.ReassignReviewerRequest{PullRequestID: prID, OldReviewerID: reviewers[0]}
+
+	status, _, first := postWithHeaders(t, srv, "/pullRequest/reassign", headers, req)
+	require.Equal(t, http.StatusOK, status, string(first))
+
+	status, respHeaders, second := postWithHeaders(t, srv, "/pullRequest/reassign", headers, req)
+	require.Equal(t, http.StatusOK, status, string(second))
+	require.Equal(t, "true", respHeaders.Get("Idempotent-Replayed"))
+	require.JSONEq(t, string(first), string(second))
+}
+
+func TestIdempotency_KeyReusedWithDifferentBody(t *testing.T) {
+	srv, _ := setup(t)
+	prID, _, reviewers := seedPR(t, srv)
+
+	headers := map[string]string{"Idempotency-Key": fmt.Sprintf("reuse-%d", time.Now().UnixNano())}
+
+	status, _, body := postWithHeaders(t, srv, "/pullRequest/reassign", headers, dto.ReassignReviewerRequest{
+		PullRequestID: prID,
+		OldReviewerID: reviewers[0],
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, _, body = postWithHeaders(t, srv, "/pullRequest/reassign", headers, dto.ReassignReviewerRequest{
+		PullRequestID: prID,
+		OldReviewerID: reviewers[1],
+	})
+	require.Equal(t, http.StatusUnprocessableEntity, status)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Equal(t, dto.ErrorCodeIdempotencyKeyReused, resp.Error.Code)
+}
+
+func TestIdempotency_RejectsOversizedBody(t *testing.T) {
+	srv, _ := setup(t)
+
+	headers := map[string]string{"Idempotency-Key": fmt.Sprintf("oversized-%d", time.Now().UnixNano())}
+	status, _, body := postWithHeaders(t, srv, "/pullRequest/reassign", headers, dto.ReassignReviewerRequest{
+		PullRequestID: strings.Repeat("x", 2<<20),
+	})
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+	require.Equal(t, dto.ErrorCodeRequestTooLarge, errorCode(t, body))
+}
+
+func TestIdempotency_TakesOverStaleReservation(t *testing.T) {
+	srv, db := setup(t)
+	prID, _, reviewers := seedPR(t, srv)
+
+	key := fmt.Sprintf("stale-%d", time.Now().UnixNano())
+	headers := map[string]string{"Idempotency-Key": key}
+	req := dto.ReassignReviewerRequest{PullRequestID: prID, OldReviewerID: reviewers[0]}
+
+	payload, err := json.Marshal(req)
+	require.NoError(t, err)
+	hash := sha256.Sum256(append([]byte{0}, payload...))
+
+	// A request reserved the key and died without releasing it.
+	_, err = db.Exec(`
+        INSERT INTO idempotency_keys (key, method, path, request_hash, expires_at, locked_until)
+        VALUES ($1, 'POST', '/pullRequest/reassign', $2, now() + interval '1 day', now() + interval '1 minute')
+    `, key, hex.EncodeToString(hash[:]))
+	require.NoError(t, err)
+
+	status, _, body := postWithHeaders(t, srv, "/pullRequest/reassign", headers, req)
+	require.Equal(t, http.StatusConflict, status, string(body))
+	require.Equal(t, dto.ErrorCodeIdempotencyInProgress, errorCode(t, body))
+
+	// Once its lease runs out, a retry takes the key over.
+	_, err = db.Exec(`UPDATE idempotency_keys SET locked_until = now() - interval '1 second' WHERE key = $1`, key)
+	require.NoError(t, err)
+
+	status, _, first := postWithHeaders(t, srv, "/pullRequest/reassign", headers, req)
+	require.Equal(t, http.StatusOK, status, string(first))
+
+	status, respHeaders, second := postWithHeaders(t, srv, "/pullRequest/reassign", headers, req)
+	require.Equal(t, http.StatusOK, status, string(second))
+	require.Equal(t, "true", respHeaders.Get("Idempotent-Replayed"))
+	require.JSONEq(t, string(first), string(second))
+
+	// The stale reservation of a different request is not taken over.
+	other := fmt.Sprintf("stale-other-%d", time.Now().UnixNano())
+	_, err = db.Exec(`
+        INSERT INTO idempotency_keys (key, method, path, request_hash, expires_at, locked_until)
+        VALUES ($1, 'POST', '/pullRequest/reassign', 'other', now() + interval '1 day', now() - interval '1 second')
+    `, other)
+	require.NoError(t, err)
+
+	status, _, body = postWithHeaders(t, srv, "/pullRequest/reassign", map[string]string{"Idempotency-Key": other}, req)
+	require.Equal(t, http.StatusUnprocessableEntity, status, string(body))
+	require.Equal(t, dto.ErrorCodeIdempotencyKeyReused, errorCode(t, body))
+}
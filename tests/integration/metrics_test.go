@@ -0,0 +1,62 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_ExposesRequestsQueriesAndGauges(t *testing.T) {
+	srv, db := setup(t)
+	prID, authorID, reviewers := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	// Nobody is left to replace a reviewer with once the rest of the team
+	// is away.
+	suffix := strings.TrimPrefix(authorID, "race-author-")
+	for i := 0; i < 6; i++ {
+		id := fmt.Sprintf("race-u%d-%s", i, suffix)
+		if slices.Contains(reviewers, id) {
+			continue
+		}
+		status, body := post(t, srv, "/users/setIsActive", dto.SetIsActiveUserRequest{UserID: id, IsActive: false})
+		require.Equal(t, http.StatusOK, status, string(body))
+	}
+
+	status, body := post(t, srv, "/pullRequest/reassign", dto.ReassignReviewerRequest{
+		PullRequestID: prID,
+		OldReviewerID: reviewers[0],
+	})
+	require.Equal(t, http.StatusConflict, status, string(body))
+	require.Equal(t, dto.ErrorCodeNoCandidate, errorCode(t, body))
+
+	resp, err := http.Get(srv.URL + "/stats")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	text := string(raw)
+
+	require.Contains(t, text, `pr_service_http_request_duration_seconds_count{method="GET",route="/stats",status="200"}`)
+	require.Contains(t, text, `pr_service_db_query_duration_seconds_count{method="CountByTeam",repository="StatsPostgres"}`)
+	require.Contains(t, text, "go_sql_open_connections")
+	require.Contains(t, text, fmt.Sprintf(`pr_service_open_pull_requests{team_name=%q} 1`, teamName))
+	for _, id := range reviewers {
+		require.Contains(t, text, fmt.Sprintf(`pr_service_pending_reviews{user_id=%q} 1`, id))
+	}
+	require.Contains(t, text, fmt.Sprintf(`pr_service_review_no_candidate_total{team_name=%q} 1`, teamName))
+}
@@ -0,0 +1,142 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify/smtptest"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifications_Preferences(t *testing.T) {
+	srv, _ := setup(t)
+	_, _, reviewers := seedPR(t, srv)
+	userID := reviewers[0]
+
+	getPrefs := func() dto.NotificationPreferencesDTO {
+		resp, err := http.Get(srv.URL + "/users/notificationPreferences?user_id=" + userID)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var prefs dto.NotificationPreferencesDTO
+		require.NoError(t, json.Unmarshal(body, &prefs))
+		return prefs
+	}
+
+	defaults := getPrefs()
+	require.Equal(t, []string{"email"}, defaults.Channels)
+	require.Equal(t, "en", defaults.Language)
+	require.Equal(t, "immediate", defaults.Mode)
+	require.Nil(t, defaults.QuietHours)
+
+	status, body := post(t, srv, "/users/notificationPreferences", dto.UpdateNotificationPreferencesRequest{
+		UserID:     userID,
+		Channels:   []string{"email"},
+		Language:   "ru",
+		Mode:       "digest",
+		QuietHours: &dto.QuietHoursDTO{Start: "22:00", End: "08:00"},
+		Timezone:   "Europe/Moscow",
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	saved := getPrefs()
+	require.Equal(t, "ru", saved.Language)
+	require.Equal(t, "digest", saved.Mode)
+	require.Equal(t, &dto.QuietHoursDTO{Start: "22:00", End: "08:00"}, saved.QuietHours)
+	require.Equal(t, "Europe/Moscow", saved.Timezone)
+
+	status, body = post(t, srv, "/users/notificationPreferences", dto.UpdateNotificationPreferencesRequest{
+		UserID:     userID,
+		QuietHours: &dto.QuietHoursDTO{Start: "25:00", End: "08:00"},
+	})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, dto.ErrorCodeInvalidPreferences, errorCode(t, body))
+
+	status, body = post(t, srv, "/users/notificationPreferences", dto.UpdateNotificationPreferencesRequest{
+		UserID: "no-such-user",
+	})
+	require.Equal(t, http.StatusNotFound, status)
+	require.Equal(t, dto.ErrorCodeNotFound, errorCode(t, body))
+}
+
+func TestNotifications_EmailDelivery(t *testing.T) {
+	srv, db := setup(t)
+	prID, _, reviewers := seedPR(t, srv)
+
+	email := reviewers[0] + "@example.com"
+	status, body := post(t, srv, "/users/upsert", dto.UpsertUserRequest{
+		UserID:   reviewers[0],
+		Username: "reviewer",
+		TeamName: teamOf(t, db, reviewers[0]),
+		IsActive: true,
+		Email:    &email,
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = post(t, srv, "/users/notificationPreferences", dto.UpdateNotificationPreferencesRequest{
+		UserID:   reviewers[0],
+		Language: "ru",
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	smtpSrv, err := smtptest.NewServer()
+	require.NoError(t, err)
+	defer smtpSrv.Close()
+
+	host, port := smtpSrv.Addr()
+	svc := service.NewNotificationService(
+		postgres.NewNotificationPostgres(db),
+		postgres.NewUserPostgres(db),
+		postgres.NewPRPostgres(db),
+		notify.NewSMTPSender(notify.SMTPConfig{Host: host, Port: port, From: "reviews@example.com", Timeout: 5 * time.Second}),
+		service.DeliveryPolicy{BatchSize: 1000, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour, Lease: time.Minute},
+		service.NotificationSchedule{DigestAt: 9 * 60, RemindAfter: 24 * time.Hour},
+	)
+
+	ctx := context.Background()
+	require.NoError(t, svc.NotifyAssigned(ctx, prID, reviewers))
+	_, err = svc.Dispatch(ctx)
+	require.NoError(t, err)
+
+	msgs := smtpSrv.Messages()
+	i := slices.IndexFunc(msgs, func(m smtptest.Message) bool { return slices.Contains(m.To, email) })
+	require.GreaterOrEqual(t, i, 0, "no email sent to %s", email)
+	require.Equal(t, "Запрошено ревью: race", msgs[i].Subject)
+	require.Contains(t, msgs[i].Body, prID)
+
+	// The other reviewer has no email address.
+	var statuses []string
+	rows, err := db.Query(`SELECT status FROM notifications WHERE pr_id = $1 ORDER BY user_id = $2 DESC`, prID, reviewers[0])
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var s string
+		require.NoError(t, rows.Scan(&s))
+		statuses = append(statuses, s)
+	}
+	require.Equal(t, []string{"SENT", "SKIPPED"}, statuses)
+}
+
+func teamOf(t *testing.T, db *sql.DB, userID string) string {
+	t.Helper()
+
+	var team string
+	require.NoError(t, db.QueryRow(`SELECT team_name FROM users WHERE id = $1`, userID).Scan(&team))
+	return team
+}
@@ -0,0 +1,115 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func patch(t *testing.T, srv *httptest.Server, path string, body any) (int, []byte) {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPatch, srv.URL+path, bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, respBody
+}
+
+func TestReviewSLA_FlagsAndEscalatesOverdueReviews(t *testing.T) {
+	srv, db := setup(t)
+	prID, _, reviewers := seedPR(t, srv)
+
+	var teamName string
+	require.NoError(t, db.QueryRow(`SELECT team_name FROM pull_requests WHERE id = $1`, prID).Scan(&teamName))
+
+	responseHours, escalationHours, escalation := 1, 2, "notify_lead"
+	status, body := patch(t, srv, "/team/settings", dto.UpdateTeamSettingsRequest{
+		TeamName: teamName,
+		ReviewSLA: &dto.ReviewSLAPatchDTO{
+			ResponseHours:   &responseHours,
+			EscalationHours: &escalationHours,
+			Escalation:      &escalation,
+		},
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var settings dto.UpdateTeamSettingsResponse
+	require.NoError(t, json.Unmarshal(body, &settings))
+	require.Equal(t, dto.ReviewSLADTO{ResponseHours: 1, EscalationHours: 2, Escalation: "notify_lead"}, settings.Settings.ReviewSLA)
+
+	escalationHours = 1
+	status, body = patch(t, srv, "/team/settings", dto.UpdateTeamSettingsRequest{
+		TeamName:  teamName,
+		ReviewSLA: &dto.ReviewSLAPatchDTO{EscalationHours: &escalationHours},
+	})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, dto.ErrorCodeInvalidSLA, errorCode(t, body))
+
+	_, err := db.Exec(`UPDATE pull_request_reviewers SET assigned_at = now() - interval '30 days' WHERE pr_id = $1`, prID)
+	require.NoError(t, err)
+
+	txManager := postgres.NewTxManager(db)
+	teamRepo := postgres.NewTeamPostgres(db)
+	prSvc := service.NewPRService(
+		txManager,
+		postgres.NewPRPostgres(db),
+		postgres.NewUserPostgres(db),
+		teamRepo,
+		postgres.NewPREventPostgres(db),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	slaSvc := service.NewReviewSLAService(txManager, postgres.NewReviewSLAPostgres(db), teamRepo, prSvc, nil)
+
+	_, err = slaSvc.Check(context.Background())
+	require.NoError(t, err)
+
+	// A second run, like one on another replica, changes nothing.
+	_, err = slaSvc.Check(context.Background())
+	require.NoError(t, err)
+
+	resp, err := http.Get(srv.URL + "/pullRequest/overdueReviews?team_name=" + teamName)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var overdue dto.OverdueReviewsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&overdue))
+	require.Len(t, overdue.Reviews, len(reviewers))
+	for _, r := range overdue.Reviews {
+		require.Equal(t, prID, r.PullRequestID)
+		require.NotNil(t, r.EscalatedAt)
+	}
+
+	var overdueEvents, escalatedEvents int
+	require.NoError(t, db.QueryRow(`
+        SELECT count(*) FILTER (WHERE type = 'review_overdue'),
+               count(*) FILTER (WHERE type = 'review_escalated')
+        FROM pr_events WHERE pr_id = $1`, prID).Scan(&overdueEvents, &escalatedEvents))
+	require.Equal(t, len(reviewers), overdueEvents)
+	require.Equal(t, len(reviewers), escalatedEvents)
+}
@@ -0,0 +1,69 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_RunsJobOnce(t *testing.T) {
+	_, db := setup(t)
+
+	name := fmt.Sprintf("test.%d", time.Now().UnixNano())
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	calls := 0
+
+	// Two replicas share the database.
+	replicas := make([]*scheduler.Scheduler, 2)
+	for i := range replicas {
+		replicas[i] = scheduler.New(postgres.NewAdvisoryLocker(db), postgres.NewJobRunPostgres(db), time.Minute)
+		require.NoError(t, replicas[i].Register(scheduler.Job{
+			Name:     name,
+			Schedule: scheduler.Every(time.Hour),
+			Run: func(context.Context) error {
+				calls++
+				close(started)
+				<-finish
+				return nil
+			},
+		}))
+	}
+
+	ctx := context.Background()
+	scheduledAt := time.Now().UTC().Truncate(time.Hour)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := replicas[0].RunJob(ctx, name, scheduledAt)
+		done <- err
+	}()
+	<-started
+
+	// The lock is held while the job runs.
+	ran, err := replicas[1].RunJob(ctx, name, scheduledAt)
+	require.NoError(t, err)
+	require.False(t, ran)
+
+	close(finish)
+	require.NoError(t, <-done)
+
+	// The run is recorded, so the same time is not run again.
+	ran, err = replicas[1].RunJob(ctx, name, scheduledAt)
+	require.NoError(t, err)
+	require.False(t, ran)
+	require.Equal(t, 1, calls)
+
+	var status string
+	require.NoError(t, db.QueryRow(
+		`SELECT status FROM job_runs WHERE job_name = $1 AND scheduled_at = $2`, name, scheduledAt,
+	).Scan(&status))
+	require.Equal(t, "SUCCEEDED", status)
+}
@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/notify/smtptest"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStalePRs(t *testing.T) {
+	srv, db := setup(t)
+	prID, _, reviewers := seedPR(t, srv)
+	teamName := teamOf(t, db, reviewers[0])
+
+	_, err := db.Exec(`UPDATE pull_requests SET created_at = now() - interval '30 days' WHERE id = $1`, prID)
+	require.NoError(t, err)
+
+	stale := func(query string) (int, dto.StalePRsResponse) {
+		resp, err := http.Get(srv.URL + "/pullRequest/stale?" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var out dto.StalePRsResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		}
+		return resp.StatusCode, out
+	}
+
+	status, out := stale("team_name=" + teamName)
+	require.Equal(t, http.StatusOK, status)
+	i := slices.IndexFunc(out.PullRequests, func(pr dto.StalePRDTO) bool { return pr.PullRequestID == prID })
+	require.GreaterOrEqual(t, i, 0)
+	require.Equal(t, []string{"age", "inactivity"}, out.PullRequests[i].Reasons)
+	require.ElementsMatch(t, reviewers, out.PullRequests[i].PendingReviewers)
+
+	status, out = stale("reviewer_id=" + reviewers[0])
+	require.Equal(t, http.StatusOK, status)
+	require.True(t, slices.ContainsFunc(out.PullRequests, func(pr dto.StalePRDTO) bool { return pr.PullRequestID == prID }))
+
+	status, _ = stale("team_name=no-such-team")
+	require.Equal(t, http.StatusNotFound, status)
+
+	smtpSrv, err := smtptest.NewServer()
+	require.NoError(t, err)
+	defer smtpSrv.Close()
+
+	host, port := smtpSrv.Addr()
+	notifications := service.NewNotificationService(
+		postgres.NewNotificationPostgres(db),
+		postgres.NewUserPostgres(db),
+		postgres.NewPRPostgres(db),
+		notify.NewSMTPSender(notify.SMTPConfig{Host: host, Port: port, From: "reviews@example.com", Timeout: 5 * time.Second}),
+		service.DeliveryPolicy{BatchSize: 1000, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour, Lease: time.Minute},
+		service.NotificationSchedule{DigestAt: 9 * 60, RemindAfter: 24 * time.Hour},
+	)
+	svc := service.NewStalePRService(
+		postgres.NewStalePRPostgres(db),
+		postgres.NewTeamPostgres(db),
+		postgres.NewUserPostgres(db),
+		notifications,
+		domain.StalePolicy{MaxAge: 7 * 24 * time.Hour, MaxIdle: 3 * 24 * time.Hour},
+	)
+
+	// The second run finds the digest notifications still pending.
+	ctx := context.Background()
+	_, err = svc.Notify(ctx)
+	require.NoError(t, err)
+	_, err = svc.Notify(ctx)
+	require.NoError(t, err)
+
+	var queued int
+	require.NoError(t, db.QueryRow(
+		`SELECT count(*) FROM notifications WHERE pr_id = $1 AND kind = 'stale_review'`, prID,
+	).Scan(&queued))
+	require.Equal(t, len(reviewers), queued)
+}
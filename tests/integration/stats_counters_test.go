@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCounters_MatchLiveQueries(t *testing.T) {
+	srv, db := setup(t)
+	prID, authorID, reviewers := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	status, body := post(t, srv, "/pullRequest/create", dto.CreatePRRequest{
+		PullRequestID:   prID + "-second",
+		PullRequestName: "second",
+		AuthorID:        authorID,
+	})
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	status, body = post(t, srv, "/pullRequest/reassign", dto.ReassignReviewerRequest{
+		PullRequestID: prID,
+		OldReviewerID: reviewers[0],
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = post(t, srv, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	stats := func(params url.Values) dto.StatsResponse {
+		resp, err := http.Get(srv.URL + "/stats?" + params.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var out dto.StatsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return out
+	}
+
+	// Without a window the counters answer; a window wide enough to hold
+	// every pull request makes the same request count them live.
+	now := time.Now().UTC()
+	counted := stats(url.Values{"team_name": {teamName}})
+	live := stats(url.Values{
+		"team_name": {teamName},
+		"from":      {now.AddDate(-10, 0, 0).Format(time.RFC3339)},
+		"to":        {now.Add(time.Hour).Format(time.RFC3339)},
+	})
+	require.Equal(t, live, counted)
+	require.Equal(t, dto.TeamStatsDTO{
+		TeamName:     teamName,
+		PullRequests: 2,
+		Open:         1,
+		Merged:       1,
+		Assignments:  4,
+	}, counted.ByTeam[0])
+
+	ctx := context.Background()
+	repo := postgres.NewStatsPostgres(db)
+
+	_, err := db.Exec(`UPDATE stats_team_counts SET open = open + 5 WHERE team_name = $1`, teamName)
+	require.NoError(t, err)
+
+	drift, err := repo.CheckCounters(ctx)
+	require.NoError(t, err)
+	require.Positive(t, drift)
+
+	require.NoError(t, repo.RebuildCounters(ctx))
+
+	drift, err = repo.CheckCounters(ctx)
+	require.NoError(t, err)
+	require.Zero(t, drift)
+	require.Equal(t, live, stats(url.Values{"team_name": {teamName}}))
+}
@@ -0,0 +1,132 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsHistory_SnapshotsFromPullRequests(t *testing.T) {
+	srv, db := setup(t)
+	prID, authorID, reviewers := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	// The pull request was opened yesterday and merged today.
+	today := domain.StartOfDay(time.Now())
+	yesterday := today.Add(-domain.Day)
+	_, err := db.Exec(`UPDATE pull_requests SET created_at = $2 WHERE id = $1`, prID, yesterday.Add(time.Hour))
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE pr_events SET occurred_at = $2 WHERE pr_id = $1 AND type = 'reviewer_assigned'`, prID, yesterday.Add(time.Hour))
+	require.NoError(t, err)
+
+	status, body := post(t, srv, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	repo := postgres.NewStatsPostgres(db)
+	require.NoError(t, repo.SaveSnapshots(context.Background(), yesterday, today.Add(domain.Day)))
+
+	params := url.Values{
+		"team_name": {teamName},
+		"from":      {yesterday.Format(time.RFC3339)},
+		"to":        {today.Add(domain.Day).Format(time.RFC3339)},
+	}
+	resp, err := http.Get(srv.URL + "/stats/history?" + params.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var out dto.StatsHistoryResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, []string{yesterday.Format(time.DateOnly), today.Format(time.DateOnly)}, out.Days)
+	require.Equal(t, []dto.TeamHistoryDTO{{
+		TeamName:    teamName,
+		Open:        []int{1, 0},
+		Opened:      []int{1, 0},
+		Merged:      []int{0, 1},
+		Closed:      []int{0, 0},
+		Assignments: []int{len(reviewers), 0},
+	}}, out.Teams)
+	require.Len(t, out.Users, len(reviewers))
+	for _, u := range out.Users {
+		require.Equal(t, []int{1, 0}, u.Assignments)
+		require.Equal(t, []int{1, 0}, u.OpenReviews)
+	}
+
+	resp, err = http.Get(srv.URL + "/stats/history?from=yesterday")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestStatsHistory_BackfillKeepsReplacedReviewers(t *testing.T) {
+	srv, db := setup(t)
+	prID, authorID, reviewers := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	// The reviewers were assigned yesterday and the first one is replaced
+	// today.
+	today := domain.StartOfDay(time.Now())
+	yesterday := today.Add(-domain.Day)
+	_, err := db.Exec(`UPDATE pull_requests SET created_at = $2 WHERE id = $1`, prID, yesterday.Add(time.Hour))
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE pr_events SET occurred_at = $2 WHERE pr_id = $1 AND type = 'reviewer_assigned'`, prID, yesterday.Add(time.Hour))
+	require.NoError(t, err)
+
+	status, body := post(t, srv, "/pullRequest/reassign", dto.ReassignReviewerRequest{
+		PullRequestID: prID,
+		OldReviewerID: reviewers[0],
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+	var reassigned dto.ReassignReviewerResponse
+	require.NoError(t, json.Unmarshal(body, &reassigned))
+
+	repo := postgres.NewStatsPostgres(db)
+	require.NoError(t, repo.SaveSnapshots(context.Background(), yesterday, today.Add(domain.Day)))
+
+	params := url.Values{
+		"team_name": {teamName},
+		"from":      {yesterday.Format(time.RFC3339)},
+		"to":        {today.Add(domain.Day).Format(time.RFC3339)},
+	}
+	resp, err := http.Get(srv.URL + "/stats/history?" + params.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var out dto.StatsHistoryResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Len(t, out.Teams, 1)
+	require.Equal(t, []int{2, 1}, out.Teams[0].Assignments)
+
+	user := func(id string) dto.UserHistoryDTO {
+		i := slices.IndexFunc(out.Users, func(u dto.UserHistoryDTO) bool { return u.UserID == id })
+		require.NotEqual(t, -1, i, "no history for %s", id)
+		return out.Users[i]
+	}
+	require.Equal(t, dto.UserHistoryDTO{
+		UserID:      reviewers[0],
+		Assignments: []int{1, 0},
+		OpenReviews: []int{1, 0},
+	}, user(reviewers[0]))
+	require.Equal(t, dto.UserHistoryDTO{
+		UserID:      reviewers[1],
+		Assignments: []int{1, 0},
+		OpenReviews: []int{1, 1},
+	}, user(reviewers[1]))
+	require.Equal(t, dto.UserHistoryDTO{
+		UserID:      reassigned.ReplacedBy,
+		Assignments: []int{0, 1},
+		OpenReviews: []int{0, 1},
+	}, user(reassigned.ReplacedBy))
+}
@@ -0,0 +1,117 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats_FiltersByTeamWindowAndStatus(t *testing.T) {
+	srv, db := setup(t)
+	prID, authorID, _ := seedPR(t, srv)
+	teamName := teamOf(t, db, authorID)
+
+	// A second pull request of the team falls into the previous sprint.
+	status, body := post(t, srv, "/pullRequest/create", dto.CreatePRRequest{
+		PullRequestID:   prID + "-old",
+		PullRequestName: "old",
+		AuthorID:        authorID,
+	})
+	require.Equal(t, http.StatusCreated, status, string(body))
+	_, err := db.Exec(`UPDATE pull_requests SET created_at = now() - interval '20 days' WHERE id = $1`, prID+"-old")
+	require.NoError(t, err)
+
+	status, body = post(t, srv, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	stats := func(params url.Values) (int, []byte) {
+		resp, err := http.Get(srv.URL + "/stats?" + params.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var raw json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+		return resp.StatusCode, raw
+	}
+	team := func(body []byte) dto.TeamStatsDTO {
+		var out dto.StatsResponse
+		require.NoError(t, json.Unmarshal(body, &out))
+		require.Len(t, out.ByTeam, 1)
+		return out.ByTeam[0]
+	}
+
+	status, body = stats(url.Values{"team_name": {teamName}})
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.Equal(t, dto.TeamStatsDTO{
+		TeamName:     teamName,
+		PullRequests: 2,
+		Open:         1,
+		Merged:       1,
+		Assignments:  4,
+	}, team(body))
+
+	// Without pr_limit every pull request is listed.
+	var all dto.StatsResponse
+	require.NoError(t, json.Unmarshal(body, &all))
+	require.Len(t, all.ByPR, 2)
+	require.False(t, all.ByPRTruncated)
+
+	now := time.Now().UTC()
+	sprint := url.Values{
+		"team_name": {teamName},
+		"from":      {now.AddDate(0, 0, -14).Format(time.RFC3339)},
+		"to":        {now.Add(time.Hour).Format(time.RFC3339)},
+	}
+	status, body = stats(sprint)
+	require.Equal(t, http.StatusOK, status, string(body))
+	got := team(body)
+	require.Equal(t, 1, got.PullRequests)
+	require.Equal(t, 1, got.Merged)
+
+	var out dto.StatsResponse
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.Len(t, out.ByPR, 1)
+	require.Equal(t, prID, out.ByPR[0].PullRequestID)
+
+	status, body = stats(url.Values{"team_name": {teamName}, "status": {"OPEN"}})
+	require.Equal(t, http.StatusOK, status, string(body))
+	got = team(body)
+	require.Equal(t, 1, got.PullRequests)
+	require.Equal(t, 1, got.Open)
+
+	// The team has two pull requests with reviewers; by_pr keeps the one
+	// with the most reviewers, counted both ways.
+	for _, params := range []url.Values{
+		{"team_name": {teamName}, "pr_limit": {"1"}},
+		{"team_name": {teamName}, "pr_limit": {"1"}, "to": {now.Add(time.Hour).Format(time.RFC3339)}},
+	} {
+		status, body = stats(params)
+		require.Equal(t, http.StatusOK, status, string(body))
+		out = dto.StatsResponse{}
+		require.NoError(t, json.Unmarshal(body, &out))
+		require.Len(t, out.ByPR, 1)
+		require.True(t, out.ByPRTruncated)
+	}
+
+	status, body = stats(url.Values{"pr_limit": {"0"}})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, dto.ErrorCodeInvalidStatsFilter, errorCode(t, body))
+
+	status, body = stats(url.Values{"from": {"yesterday"}})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, dto.ErrorCodeInvalidStatsFilter, errorCode(t, body))
+
+	status, body = stats(url.Values{"status": {"DRAFT"}})
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, dto.ErrorCodeInvalidStatsFilter, errorCode(t, body))
+
+	status, _ = stats(url.Values{"team_name": {"no-such-team"}})
+	require.Equal(t, http.StatusNotFound, status)
+}
@@ -0,0 +1,33 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/domain"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamSettings_InvalidReviewersCount(t *testing.T) {
+	srv, db := setup(t)
+	prID, _, _ := seedPR(t, srv)
+
+	var teamName string
+	require.NoError(t, db.QueryRow(`SELECT team_name FROM pull_requests WHERE id = $1`, prID).Scan(&teamName))
+
+	for _, count := range []int{-1, domain.MaxReviewersCount + 1} {
+		status, body := patch(t, srv, "/team/settings", dto.UpdateTeamSettingsRequest{
+			TeamName:       teamName,
+			ReviewerPolicy: &dto.ReviewerPolicyPatchDTO{ReviewersCount: &count},
+		})
+		require.Equal(t, http.StatusBadRequest, status, string(body))
+		require.Equal(t, dto.ErrorCodeInvalidTeamSettings, errorCode(t, body))
+	}
+
+	status, body := patch(t, srv, "/team/settings", dto.UpdateTeamSettingsRequest{})
+	require.Equal(t, http.StatusBadRequest, status, string(body))
+	require.Equal(t, dto.ErrorCodeInvalidTeamSettings, errorCode(t, body))
+}
@@ -0,0 +1,63 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline_FollowsPRLifecycle(t *testing.T) {
+	srv, _ := setup(t)
+	prID, _, reviewers := seedPR(t, srv)
+
+	status, body := post(t, srv, "/pullRequest/reassign", dto.ReassignReviewerRequest{
+		PullRequestID: prID,
+		OldReviewerID: reviewers[0],
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var reassigned dto.ReassignReviewerResponse
+	require.NoError(t, json.Unmarshal(body, &reassigned))
+
+	status, body = post(t, srv, "/pullRequest/review", dto.SubmitReviewRequest{
+		PullRequestID: prID,
+		ReviewerID:    reviewers[1],
+		Verdict:       "APPROVED",
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = post(t, srv, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	resp, err := http.Get(srv.URL + "/pullRequest/timeline?pull_request_id=" + prID)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var timeline dto.TimelineResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&timeline))
+
+	types := make([]string, 0, len(timeline.Events))
+	for _, e := range timeline.Events {
+		types = append(types, e.Type)
+	}
+	require.Equal(t, []string{
+		"created",
+		"reviewer_assigned",
+		"reviewer_assigned",
+		"reviewer_reassigned",
+		"review_submitted",
+		"merged",
+	}, types)
+
+	moved := timeline.Events[3]
+	require.Equal(t, reviewers[0], moved.PreviousReviewerID)
+	require.Equal(t, reassigned.ReplacedBy, moved.ReviewerID)
+	require.Equal(t, "random", moved.Strategy)
+	require.Equal(t, "APPROVED", timeline.Events[4].Verdict)
+}
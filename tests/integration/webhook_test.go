@@ -0,0 +1,98 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/dto"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/repository/postgres"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/internal/service"
+	"github.com/SALutHere/avito-2025-autumn-backend-internship/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks_DeliverCommittedEvents(t *testing.T) {
+	srv, db := setup(t)
+
+	var (
+		mu       sync.Mutex
+		received []string
+		secret   string
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		signed := append([]byte(r.Header.Get(service.WebhookTimestampHeader)+"."), body...)
+		if !signature.Verify(secret, signed, r.Header.Get(service.WebhookSignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received = append(received, string(body))
+	}))
+	defer receiver.Close()
+
+	status, body := post(t, srv, "/webhooks/create", dto.CreateWebhookRequest{
+		URL:        receiver.URL,
+		EventTypes: []string{"pr.created"},
+	})
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	var created dto.CreateWebhookResponse
+	require.NoError(t, json.Unmarshal(body, &created))
+	mu.Lock()
+	secret = created.Secret
+	mu.Unlock()
+
+	prID, _, _ := seedPR(t, srv)
+
+	dispatcher := service.NewWebhookService(postgres.NewWebhookPostgres(db), receiver.Client(), service.DeliveryPolicy{
+		BatchSize:   100,
+		MaxAttempts: 3,
+		BackoffBase: time.Second,
+		BackoffMax:  time.Second,
+		Lease:       time.Minute,
+	})
+
+	delivered := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, b := range received {
+			if strings.Contains(b, fmt.Sprintf("%q", prID)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	require.Eventually(t, func() bool {
+		_, err := dispatcher.Dispatch(context.Background())
+		require.NoError(t, err)
+		return delivered()
+	}, 10*time.Second, 100*time.Millisecond)
+
+	resp, err := http.Get(fmt.Sprintf("%s/webhooks/deliveries?subscription_id=%d", srv.URL, created.Webhook.ID))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var deliveries dto.ListDeliveriesResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&deliveries))
+	require.NotEmpty(t, deliveries.Deliveries)
+	require.Equal(t, "SUCCEEDED", deliveries.Deliveries[0].Status)
+
+	status, body = post(t, srv, "/webhooks/redeliver", dto.RedeliverRequest{DeliveryID: deliveries.Deliveries[0].ID})
+	require.Equal(t, http.StatusAccepted, status, string(body))
+}